@@ -266,28 +266,29 @@ func (asb *ArrowStreamBatch) downloadChunkStreamHelper(ctx context.Context) erro
 		}
 	}
 
-	defer func() {
-		if asb.rr == nil {
-			_ = resp.Body.Close()
-		}
-	}()
+	asb.rr, err = newChunkStream(resp.Body)
+	return err
+}
 
-	bufStream := bufio.NewReader(resp.Body)
+// newChunkStream returns the chunk content of the response body, uncompressed if it is gzipped.
+// The body is closed if an error is returned.
+func newChunkStream(body io.ReadCloser) (io.ReadCloser, error) {
+	bufStream := bufio.NewReader(body)
 	gzipMagic, err := bufStream.Peek(2)
 	if err != nil {
-		return err
+		_ = body.Close()
+		return nil, err
 	}
 
 	if gzipMagic[0] == 0x1f && gzipMagic[1] == 0x8b {
 		bufStream0, err := gzip.NewReader(bufStream)
 		if err != nil {
-			return err
+			_ = body.Close()
+			return nil, err
 		}
-		asb.rr = &streamWrapReader{Reader: bufStream0, wrapped: resp.Body}
-	} else {
-		asb.rr = &streamWrapReader{Reader: bufStream, wrapped: resp.Body}
+		return &streamWrapReader{Reader: bufStream0, wrapped: body}, nil
 	}
-	return nil
+	return &streamWrapReader{Reader: bufStream, wrapped: body}, nil
 }
 
 type snowflakeArrowStreamChunkDownloader struct {
@@ -2,6 +2,7 @@ package arrowbatches
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/snowflakedb/gosnowflake/v2/internal/query"
 	"github.com/snowflakedb/gosnowflake/v2/internal/types"
@@ -39,6 +40,22 @@ func arrowToRecord(ctx context.Context, record arrow.Record, pool memory.Allocat
 	for i, col := range record.Columns() {
 		fieldMetadata := rowType[i].ToFieldMetadata()
 
+		if sfType := types.GetSnowflakeType(fieldMetadata.Type); sfType == types.GeographyType || sfType == types.GeometryType {
+			newCol, srid, err := spatialToWKB(col, pool)
+			if err != nil {
+				return nil, err
+			}
+			cols = append(cols, newCol)
+			defer newCol.Release()
+			if sfType == types.GeometryType && srid != 0 {
+				fields := s.Fields()
+				fields[i].Metadata = geoArrowMetadata(sfType, srid, record.Schema().Field(i).Metadata)
+				meta := s.Metadata()
+				s = arrow.NewSchema(fields, &meta)
+			}
+			continue
+		}
+
 		newCol, err := arrowToRecordSingleColumn(ctxAlloc, s.Field(i), col, fieldMetadata, higherPrecision, timestampOption, pool, loc, numRows)
 		if err != nil {
 			return nil, err
@@ -186,6 +203,11 @@ func arrowToRecordSingleColumn(ctx context.Context, field arrow.Field, col arrow
 		} else if stringCol, ok := col.(*array.String); ok {
 			newCol = arrowStringRecordToColumn(ctx, stringCol, pool, numRows)
 		}
+	case types.VectorType:
+		if stringCol, ok := col.(*array.String); ok && fieldMetadata.Dimension > 0 {
+			return jsonToFixedSizeList(stringCol, field.Type.(*arrow.FixedSizeListType), pool)
+		}
+		col.Retain()
 	case types.MapType:
 		if mapCol, ok := col.(*array.Map); ok {
 			keyCol, err := arrowToRecordSingleColumn(ctx, field.Type.(*arrow.MapType).KeyField(), mapCol.Keys(), fieldMetadata.Fields[0], higherPrecisionEnabled, timestampOption, pool, loc, numRows)
@@ -244,6 +266,75 @@ func arrowStringRecordToColumn(
 	return stringCol
 }
 
+// jsonToFixedSizeList converts the VECTOR values sent as JSON text, e.g. [1.5,2], to the fixed-size list.
+func jsonToFixedSizeList(stringCol *array.String, dataType *arrow.FixedSizeListType, pool memory.Allocator) (arrow.Array, error) {
+	builder := array.NewFixedSizeListBuilderWithField(pool, dataType.Len(), dataType.ElemField())
+	defer builder.Release()
+	for i := 0; i < stringCol.Len(); i++ {
+		if stringCol.IsNull(i) {
+			builder.AppendNull()
+			continue
+		}
+		builder.Append(true)
+		value := stringCol.Value(i)
+		var n int
+		var err error
+		switch valueBuilder := builder.ValueBuilder().(type) {
+		case *array.Float32Builder:
+			var values []float32
+			err = json.Unmarshal([]byte(value), &values)
+			valueBuilder.AppendValues(values, nil)
+			n = len(values)
+		case *array.Int32Builder:
+			var values []int32
+			err = json.Unmarshal([]byte(value), &values)
+			valueBuilder.AppendValues(values, nil)
+			n = len(values)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if n != int(dataType.Len()) {
+			return nil, fmt.Errorf("invalid VECTOR value %v. expected %v elements", value, dataType.Len())
+		}
+	}
+	return builder.NewArray(), nil
+}
+
+// spatialToWKB converts the GEOGRAPHY and GEOMETRY values in any output format (GeoJSON, WKT, EWKT, WKB or EWKB) to ISO WKB.
+// It returns the SRID of the values, which is 0 if it is not known or the values have different SRIDs.
+func spatialToWKB(col arrow.Array, pool memory.Allocator) (arrow.Array, int, error) {
+	var value func(i int) any
+	switch arr := col.(type) {
+	case *array.String:
+		value = func(i int) any { return arr.Value(i) }
+	case *array.Binary:
+		value = func(i int) any { return arr.Value(i) }
+	default:
+		return nil, 0, fmt.Errorf("unsupported spatial column type %v", col.DataType())
+	}
+	builder := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+	defer builder.Release()
+	srid, first := 0, true
+	for i := 0; i < col.Len(); i++ {
+		if col.IsNull(i) {
+			builder.AppendNull()
+			continue
+		}
+		var g sf.Geometry
+		if err := g.Scan(value(i)); err != nil {
+			return nil, 0, err
+		}
+		builder.Append(g.Shape.WKB())
+		if first {
+			srid, first = g.SRID, false
+		} else if g.SRID != srid {
+			srid = 0
+		}
+	}
+	return builder.NewArray(), srid, nil
+}
+
 func intToBigFloat(val int64, scale int64) *big.Float {
 	f := new(big.Float).SetInt64(val)
 	s := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(scale), nil))
@@ -2,6 +2,7 @@ package arrowbatches
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"github.com/snowflakedb/gosnowflake/v2/internal/query"
 	"github.com/snowflakedb/gosnowflake/v2/internal/types"
@@ -1196,3 +1197,158 @@ func TestArrowToRecord(t *testing.T) {
 		})
 	}
 }
+
+func TestArrowToRecordVector(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+	meta := query.ExecResponseRowType{Type: "vector", Dimension: 2, Fields: []query.FieldMetadata{{Type: "fixed"}}}
+
+	for _, tc := range []struct {
+		name    string
+		sc      *arrow.Schema
+		builder func() arrow.Array
+	}{
+		{
+			name: "fixed size list",
+			sc:   arrow.NewSchema([]arrow.Field{{Type: arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Int32)}}, nil),
+			builder: func() arrow.Array {
+				b := array.NewFixedSizeListBuilder(pool, 2, arrow.PrimitiveTypes.Int32)
+				defer b.Release()
+				b.Append(true)
+				b.ValueBuilder().(*array.Int32Builder).AppendValues([]int32{1, 2}, nil)
+				b.AppendNull()
+				return b.NewArray()
+			},
+		},
+		{
+			name: "json",
+			sc:   arrow.NewSchema([]arrow.Field{{Type: &arrow.StringType{}}}, nil),
+			builder: func() arrow.Array {
+				b := array.NewStringBuilder(pool)
+				defer b.Release()
+				b.AppendValues([]string{"[1,2]", ""}, []bool{true, false})
+				return b.NewArray()
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := tc.builder()
+			defer arr.Release()
+			rawRec := array.NewRecord(tc.sc, []arrow.Array{arr}, 2)
+			defer rawRec.Release()
+
+			rec, err := arrowToRecord(context.Background(), rawRec, pool, []query.ExecResponseRowType{meta}, time.UTC)
+			if err != nil {
+				t.Fatalf("failed to convert the record. err: %v", err)
+			}
+			defer rec.Release()
+			vectors, ok := rec.Column(0).(*array.FixedSizeList)
+			if !ok {
+				t.Fatalf("expected fixed size list, got %v", rec.Column(0).DataType())
+			}
+			if vectors.IsNull(0) || !vectors.IsNull(1) {
+				t.Fatalf("unexpected nulls. %v", vectors)
+			}
+			start, end := vectors.ValueOffsets(0)
+			if values := vectors.ListValues().(*array.Int32).Int32Values()[start:end]; values[0] != 1 || values[1] != 2 {
+				t.Fatalf("unexpected vector %v", values)
+			}
+		})
+	}
+}
+
+func TestArrowToRecordSpatial(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	b := array.NewStringBuilder(pool)
+	defer b.Release()
+	b.AppendValues([]string{`{"coordinates": [1, 2], "type": "Point"}`, ""}, []bool{true, false})
+	arr := b.NewArray()
+	defer arr.Release()
+	rawRec := array.NewRecord(arrow.NewSchema([]arrow.Field{{Name: "G", Type: &arrow.StringType{}}}, nil), []arrow.Array{arr}, 2)
+	defer rawRec.Release()
+
+	rec, err := arrowToRecord(context.Background(), rawRec, pool, []query.ExecResponseRowType{{Name: "G", Type: "geography"}}, time.UTC)
+	if err != nil {
+		t.Fatalf("failed to convert the record. err: %v", err)
+	}
+	defer rec.Release()
+	wkb, ok := rec.Column(0).(*array.Binary)
+	if !ok {
+		t.Fatalf("expected binary, got %v", rec.Column(0).DataType())
+	}
+	if !wkb.IsNull(1) || fmt.Sprintf("%x", wkb.Value(0)) != "0101000000000000000000f03f0000000000000040" {
+		t.Fatalf("unexpected WKB values %v", wkb)
+	}
+	md := rec.Schema().Field(0).Metadata
+	if name, _ := md.GetValue("ARROW:extension:name"); name != "geoarrow.wkb" {
+		t.Fatalf("unexpected extension name %v", name)
+	}
+	if crs, _ := md.GetValue("ARROW:extension:metadata"); !strings.Contains(crs, "EPSG:4326") {
+		t.Fatalf("unexpected extension metadata %v", crs)
+	}
+}
+
+func TestArrowToRecordGeometrySRID(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+	ewkb, _ := hex.DecodeString("0101000020110f0000000000000000f03f0000000000000040")
+
+	for _, tc := range []struct {
+		name    string
+		builder func() arrow.Array
+		crs     string
+	}{
+		{
+			name: "ewkt",
+			builder: func() arrow.Array {
+				b := array.NewStringBuilder(pool)
+				defer b.Release()
+				b.AppendValues([]string{"SRID=3857;POINT(1 2)", ""}, []bool{true, false})
+				return b.NewArray()
+			},
+			crs: `{"crs":"EPSG:3857","crs_type":"authority_code"}`,
+		},
+		{
+			name: "ewkb",
+			builder: func() arrow.Array {
+				b := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+				defer b.Release()
+				b.AppendValues([][]byte{ewkb, nil}, []bool{true, false})
+				return b.NewArray()
+			},
+			crs: `{"crs":"EPSG:3857","crs_type":"authority_code"}`,
+		},
+		{
+			name: "mixed",
+			builder: func() arrow.Array {
+				b := array.NewStringBuilder(pool)
+				defer b.Release()
+				b.AppendValues([]string{"SRID=3857;POINT(1 2)", "POINT(1 2)"}, nil)
+				return b.NewArray()
+			},
+			crs: "{}",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := tc.builder()
+			defer arr.Release()
+			rawRec := array.NewRecord(arrow.NewSchema([]arrow.Field{{Name: "G", Type: arr.DataType()}}, nil), []arrow.Array{arr}, 2)
+			defer rawRec.Release()
+
+			rec, err := arrowToRecord(context.Background(), rawRec, pool, []query.ExecResponseRowType{{Name: "G", Type: "geometry"}}, time.UTC)
+			if err != nil {
+				t.Fatalf("failed to convert the record. err: %v", err)
+			}
+			defer rec.Release()
+			wkb := rec.Column(0).(*array.Binary)
+			if fmt.Sprintf("%x", wkb.Value(0)) != "0101000000000000000000f03f0000000000000040" {
+				t.Fatalf("expected ISO WKB, got %x", wkb.Value(0))
+			}
+			if crs, _ := rec.Schema().Field(0).Metadata.GetValue("ARROW:extension:metadata"); crs != tc.crs {
+				t.Fatalf("unexpected extension metadata %v", crs)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package arrowbatches
 
 import (
+	"fmt"
 	"github.com/snowflakedb/gosnowflake/v2/internal/query"
 	"github.com/snowflakedb/gosnowflake/v2/internal/types"
+	"slices"
 	"time"
 
 	ia "github.com/snowflakedb/gosnowflake/v2/internal/arrow"
@@ -31,6 +33,10 @@ func recordToSchemaRecursive(inFields []arrow.Field, rowType []query.ExecRespons
 				Metadata: f.Metadata,
 			}
 		}
+		if sfType := types.GetSnowflakeType(fieldMetadata.Type); sfType == types.GeographyType || sfType == types.GeometryType {
+			// the SRID of GEOMETRY values is set by arrowToRecord, as it is only known from the values
+			newField.Metadata = geoArrowMetadata(sfType, 0, f.Metadata)
+		}
 		outFields = append(outFields, newField)
 	}
 	return outFields
@@ -117,6 +123,18 @@ func recordToSchemaSingleField(fieldMetadata query.FieldMetadata, f arrow.Field,
 		} else {
 			t = f.Type
 		}
+	case types.VectorType:
+		converted = false
+		if f.Type.ID() == arrow.STRING && fieldMetadata.Dimension > 0 {
+			converted = true
+			t = arrow.FixedSizeListOf(int32(fieldMetadata.Dimension), &arrow.Float32Type{})
+			if len(fieldMetadata.Fields) == 1 && types.GetSnowflakeType(fieldMetadata.Fields[0].Type) == types.FixedType {
+				t = arrow.FixedSizeListOf(int32(fieldMetadata.Dimension), arrow.PrimitiveTypes.Int32)
+			}
+		}
+	case types.GeographyType, types.GeometryType:
+		// the WKB and EWKB values are normalized to ISO WKB as well
+		t = arrow.BinaryTypes.Binary
 	case types.MapType:
 		convertedKey, keyDataType := recordToSchemaSingleField(fieldMetadata.Fields[0], f.Type.(*arrow.MapType).KeyField(), withHigherPrecision, timestampOption, loc)
 		convertedValue, valueDataType := recordToSchemaSingleField(fieldMetadata.Fields[1], f.Type.(*arrow.MapType).ItemField(), withHigherPrecision, timestampOption, loc)
@@ -129,3 +147,17 @@ func recordToSchemaSingleField(fieldMetadata query.FieldMetadata, f arrow.Field,
 	}
 	return converted, t
 }
+
+// geoArrowMetadata marks the WKB field with the GeoArrow extension type, see https://geoarrow.org/extension-types.
+// GEOGRAPHY values always use EPSG:4326, the CRS of GEOMETRY values is set if their SRID is not 0.
+func geoArrowMetadata(sfType types.SnowflakeType, srid int, md arrow.Metadata) arrow.Metadata {
+	extensionMetadata := "{}"
+	if sfType == types.GeographyType {
+		extensionMetadata = `{"crs":"EPSG:4326","crs_type":"authority_code","edges":"spherical"}`
+	} else if srid != 0 {
+		extensionMetadata = fmt.Sprintf(`{"crs":"EPSG:%v","crs_type":"authority_code"}`, srid)
+	}
+	keys := append(slices.Clone(md.Keys()), "ARROW:extension:name", "ARROW:extension:metadata")
+	values := append(slices.Clone(md.Values()), "geoarrow.wkb", extensionMetadata)
+	return arrow.NewMetadata(keys, values)
+}
@@ -0,0 +1,386 @@
+// Package arrowingest loads arrow records into Snowflake tables.
+//
+// The records are serialized to Parquet files, uploaded to a stage with streaming PUT and loaded with COPY INTO.
+// It is a separate package, so the Parquet dependencies are only pulled in when it is imported.
+package arrowingest
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/util"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	sf "github.com/snowflakedb/gosnowflake/v2"
+	sferrors "github.com/snowflakedb/gosnowflake/v2/internal/errors"
+)
+
+// onErrorRegexp matches the values of the ON_ERROR copy option, which is added to COPY INTO as is.
+var onErrorRegexp = regexp.MustCompile(`(?i)^(CONTINUE|SKIP_FILE(_[0-9]+%?)?|ABORT_STATEMENT)$`)
+
+const (
+	stageName                = "SYSTEM$ARROW_INGEST"
+	createTemporaryStageStmt = "CREATE TEMPORARY STAGE IF NOT EXISTS " + stageName
+
+	// uncompressed size (in bytes) of the arrow records written to a single staged Parquet file (64MB default)
+	defaultFileSizeThreshold int64 = 64 * 1024 * 1024
+)
+
+// Options configures how arrow records are loaded by Ingest.
+type Options struct {
+	// CreateTable creates the target table if it does not exist yet. The column types are derived from the arrow schema.
+	CreateTable bool
+	// Stage is the stage the Parquet files are uploaded to, e.g. "@my_stage/path".
+	// A temporary stage of the current session is used if it is empty.
+	Stage string
+	// FileSizeThreshold is the uncompressed size in bytes of the records written to a single Parquet file.
+	// 64MB is used if it is not positive.
+	FileSizeThreshold int64
+	// OnError is the ON_ERROR copy option: CONTINUE, SKIP_FILE, SKIP_FILE_n, SKIP_FILE_n% or ABORT_STATEMENT.
+	// The server default (ABORT_STATEMENT) is used if it is empty.
+	OnError string
+}
+
+// FileResult is the load result of a single staged file as reported by COPY INTO.
+type FileResult struct {
+	File       string
+	Status     string
+	RowsParsed int64
+	RowsLoaded int64
+	ErrorsSeen int64
+	FirstError string
+}
+
+// Result is the result of Ingest.
+type Result struct {
+	// QueryID is the ID of the COPY INTO query. It is empty if the reader did not return any records.
+	QueryID string
+	Files   []FileResult
+}
+
+// RowsLoaded returns the total number of rows loaded from all files.
+func (res *Result) RowsLoaded() int64 {
+	var rows int64
+	for _, file := range res.Files {
+		rows += file.RowsLoaded
+	}
+	return rows
+}
+
+// snowflakeConn is the part of the driver connection used to upload and load the files.
+type snowflakeConn interface {
+	driver.ExecerContext
+	driver.QueryerContext
+}
+
+// Ingest writes the records returned by reader into table, matching the columns by name.
+// All statements run on conn, so the temporary stage and the session state are shared.
+// The table name is used in the generated SQL as is. To ingest a single record use array.NewRecordReader.
+func Ingest(ctx context.Context, conn *sql.Conn, table string, reader array.RecordReader, options *Options) (*Result, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	if table == "" || reader == nil {
+		return nil, &sf.SnowflakeError{
+			Number:  sf.ErrArrowIngest,
+			Message: "table name and record reader are required to ingest arrow records",
+		}
+	}
+	if options.OnError != "" && !onErrorRegexp.MatchString(options.OnError) {
+		return nil, &sf.SnowflakeError{
+			Number:  sf.ErrArrowIngest,
+			Message: fmt.Sprintf("invalid ON_ERROR copy option %q, expected CONTINUE, SKIP_FILE, SKIP_FILE_n, SKIP_FILE_n%% or ABORT_STATEMENT", options.OnError),
+		}
+	}
+	columns, err := schemaToColumns(reader.Schema())
+	if err != nil {
+		return nil, err
+	}
+	var res *Result
+	err = conn.Raw(func(x any) error {
+		sc, ok := x.(snowflakeConn)
+		if !ok {
+			return errors.New("connection does not implement ExecerContext and QueryerContext")
+		}
+		res, err = ingest(ctx, sc, table, columns, reader, options)
+		return err
+	})
+	return res, err
+}
+
+func ingest(ctx context.Context, sc snowflakeConn, table string, columns []column, reader array.RecordReader, options *Options) (*Result, error) {
+	ctx = sf.WithInternal(ctx)
+	if options.CreateTable {
+		if _, err := sc.ExecContext(ctx, createTableStmt(table, columns), nil); err != nil {
+			return nil, err
+		}
+	}
+	stagePath, err := ingestStagePath(ctx, sc, options.Stage)
+	if err != nil {
+		return nil, err
+	}
+	fileCount, err := uploadRecords(ctx, sc, reader, stagePath, options.FileSizeThreshold)
+	if err != nil {
+		removeFiles(ctx, sc, stagePath)
+		return nil, err
+	}
+	if fileCount == 0 {
+		return &Result{}, nil
+	}
+	res, err := copyFiles(ctx, sc, table, stagePath, options.OnError)
+	if err != nil {
+		removeFiles(ctx, sc, stagePath)
+		return nil, err
+	}
+	return res, nil
+}
+
+// ingestStagePath returns a unique location for the files of a single ingest on the given or the temporary stage.
+func ingestStagePath(ctx context.Context, sc snowflakeConn, stage string) (string, error) {
+	if stage == "" {
+		if _, err := sc.ExecContext(ctx, createTemporaryStageStmt, nil); err != nil {
+			return "", err
+		}
+		stage = stageName
+	}
+	if !strings.HasPrefix(stage, "@") {
+		stage = "@" + stage
+	}
+	return strings.TrimSuffix(stage, "/") + "/" + sf.NewUUID().String(), nil
+}
+
+// uploadRecords writes the records to Parquet files of roughly fileSizeThreshold bytes and uploads them to stagePath.
+func uploadRecords(ctx context.Context, sc snowflakeConn, reader array.RecordReader, stagePath string, fileSizeThreshold int64) (int, error) {
+	if fileSizeThreshold <= 0 {
+		fileSizeThreshold = defaultFileSizeThreshold
+	}
+	var (
+		buf       bytes.Buffer
+		writer    *pqarrow.FileWriter
+		size      int64
+		fileCount int
+		err       error
+	)
+	defer func() {
+		// the writer of the file being written is not closed if the upload failed
+		if writer != nil {
+			_ = writer.Close()
+		}
+	}()
+	flush := func() error {
+		err := writer.Close()
+		writer = nil
+		if err != nil {
+			return serializationError(err)
+		}
+		fileCount++
+		if err := putFile(ctx, sc, &buf, stagePath, fmt.Sprintf("data_%v.parquet", fileCount)); err != nil {
+			return err
+		}
+		buf.Reset()
+		size = 0
+		return nil
+	}
+	for reader.Next() {
+		rec := reader.Record()
+		if writer == nil {
+			if writer, err = newParquetWriter(reader.Schema(), &buf); err != nil {
+				return fileCount, err
+			}
+		}
+		if err = writer.Write(rec); err != nil {
+			return fileCount, serializationError(err)
+		}
+		if size += util.TotalRecordSize(rec); size >= fileSizeThreshold {
+			if err = flush(); err != nil {
+				return fileCount, err
+			}
+		}
+	}
+	if err = reader.Err(); err != nil {
+		return fileCount, serializationError(err)
+	}
+	if writer != nil {
+		if err = flush(); err != nil {
+			return fileCount, err
+		}
+	}
+	return fileCount, nil
+}
+
+func newParquetWriter(schema *arrow.Schema, w io.Writer) (*pqarrow.FileWriter, error) {
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	writer, err := pqarrow.NewFileWriter(schema, w, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, serializationError(err)
+	}
+	return writer, nil
+}
+
+func putFile(ctx context.Context, sc snowflakeConn, buf *bytes.Buffer, stagePath string, fileName string) error {
+	// use a placeholder for source file, the Parquet extension lets PUT detect the (already compressed) format
+	putCommand := fmt.Sprintf("put 'file:///tmp/placeholder/%v' '%v' overwrite=true auto_compress=false", fileName, stagePath)
+	_, err := sc.ExecContext(sf.WithFilePutStream(ctx, buf), putCommand, nil)
+	return err
+}
+
+func copyFiles(ctx context.Context, sc snowflakeConn, table string, stagePath string, onError string) (*Result, error) {
+	copyCommand := fmt.Sprintf("COPY INTO %v FROM %v/ FILE_FORMAT=(TYPE=PARQUET) MATCH_BY_COLUMN_NAME=CASE_INSENSITIVE PURGE=TRUE", table, stagePath)
+	if onError != "" {
+		copyCommand += " ON_ERROR='" + onError + "'"
+	}
+	rows, err := sc.QueryContext(ctx, copyCommand, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := &Result{}
+	if sfRows, ok := rows.(sf.SnowflakeRows); ok {
+		res.QueryID = sfRows.GetQueryID()
+	}
+	columns := rows.Columns()
+	dest := make([]driver.Value, len(columns))
+	for {
+		if err = rows.Next(dest); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if file, ok := parseFileResult(columns, dest); ok {
+			res.Files = append(res.Files, file)
+		}
+	}
+	return res, nil
+}
+
+// parseFileResult maps a row of the COPY INTO result to FileResult.
+// Rows without a file column (e.g. "Copy executed with 0 files processed.") are skipped.
+func parseFileResult(columns []string, row []driver.Value) (FileResult, bool) {
+	var file FileResult
+	hasFile := false
+	for i, name := range columns {
+		if row[i] == nil {
+			continue
+		}
+		value := fmt.Sprint(row[i])
+		switch strings.ToLower(name) {
+		case "file":
+			file.File = value
+			hasFile = true
+		case "status":
+			file.Status = value
+		case "rows_parsed":
+			file.RowsParsed, _ = strconv.ParseInt(value, 10, 64)
+		case "rows_loaded":
+			file.RowsLoaded, _ = strconv.ParseInt(value, 10, 64)
+		case "errors_seen":
+			file.ErrorsSeen, _ = strconv.ParseInt(value, 10, 64)
+		case "first_error":
+			file.FirstError = value
+		}
+	}
+	return file, hasFile
+}
+
+// removeFiles removes the files of a failed ingest. Errors are ignored, the files of the temporary stage
+// are dropped with the session anyway.
+func removeFiles(ctx context.Context, sc snowflakeConn, stagePath string) {
+	_, _ = sc.ExecContext(ctx, fmt.Sprintf("REMOVE %v/", stagePath), nil)
+}
+
+type column struct {
+	name string
+	typ  string
+}
+
+func createTableStmt(table string, columns []column) string {
+	definitions := make([]string, len(columns))
+	for i, c := range columns {
+		definitions[i] = fmt.Sprintf("\"%v\" %v", strings.ReplaceAll(c.name, "\"", "\"\""), c.typ)
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %v (%v)", table, strings.Join(definitions, ", "))
+}
+
+func schemaToColumns(schema *arrow.Schema) ([]column, error) {
+	columns := make([]column, schema.NumFields())
+	for i, field := range schema.Fields() {
+		typ, ok := snowflakeColumnType(field.Type)
+		if !ok {
+			return nil, &sf.SnowflakeError{
+				Number:      sf.ErrUnsupportedArrowIngestType,
+				Message:     sferrors.ErrMsgUnsupportedArrowIngestType,
+				MessageArgs: []any{field.Name, field.Type},
+			}
+		}
+		columns[i] = column{name: field.Name, typ: typ}
+	}
+	return columns, nil
+}
+
+// snowflakeColumnType returns the Snowflake column type a Parquet column written from the arrow type is loaded into.
+func snowflakeColumnType(dt arrow.DataType) (string, bool) {
+	switch dt.ID() {
+	case arrow.BOOL:
+		return "BOOLEAN", true
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64, arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return "NUMBER(38, 0)", true
+	case arrow.FLOAT16, arrow.FLOAT32, arrow.FLOAT64:
+		return "FLOAT", true
+	case arrow.DECIMAL128, arrow.DECIMAL256:
+		if decimalType := dt.(arrow.DecimalType); decimalType.GetPrecision() <= 38 {
+			return fmt.Sprintf("NUMBER(%v, %v)", decimalType.GetPrecision(), decimalType.GetScale()), true
+		}
+	case arrow.STRING, arrow.LARGE_STRING, arrow.STRING_VIEW:
+		return "VARCHAR", true
+	case arrow.BINARY, arrow.LARGE_BINARY, arrow.BINARY_VIEW, arrow.FIXED_SIZE_BINARY:
+		return "BINARY", true
+	case arrow.DATE32, arrow.DATE64:
+		return "DATE", true
+	case arrow.TIME32, arrow.TIME64:
+		return fmt.Sprintf("TIME(%v)", timeUnitScale(dt.(arrow.TemporalWithUnit).TimeUnit())), true
+	case arrow.TIMESTAMP:
+		ts := dt.(*arrow.TimestampType)
+		if ts.TimeZone == "" {
+			return fmt.Sprintf("TIMESTAMP_NTZ(%v)", timeUnitScale(ts.Unit)), true
+		}
+		return fmt.Sprintf("TIMESTAMP_TZ(%v)", timeUnitScale(ts.Unit)), true
+	case arrow.LIST, arrow.LARGE_LIST, arrow.FIXED_SIZE_LIST:
+		return "ARRAY", true
+	case arrow.STRUCT, arrow.MAP:
+		return "OBJECT", true
+	case arrow.DICTIONARY:
+		return snowflakeColumnType(dt.(*arrow.DictionaryType).ValueType)
+	}
+	return "", false
+}
+
+func timeUnitScale(unit arrow.TimeUnit) int {
+	switch unit {
+	case arrow.Second:
+		return 0
+	case arrow.Millisecond:
+		return 3
+	case arrow.Microsecond:
+		return 6
+	}
+	return 9
+}
+
+func serializationError(err error) *sf.SnowflakeError {
+	return &sf.SnowflakeError{
+		Number:  sf.ErrArrowIngest,
+		Message: fmt.Sprintf("failed to serialize arrow records to Parquet. err: %v", err),
+	}
+}
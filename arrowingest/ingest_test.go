@@ -0,0 +1,307 @@
+package arrowingest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	sf "github.com/snowflakedb/gosnowflake/v2"
+)
+
+// readPrivateKey reads an RSA private key from a PEM file. If the path is
+// relative it is resolved against the repository root.
+func readPrivateKey(t *testing.T, path string) *rsa.PrivateKey {
+	t.Helper()
+	if !filepath.IsAbs(path) {
+		path = filepath.Join("..", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read private key file %q: %v", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("failed to decode PEM block from %q", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse private key from %q: %v", path, err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		t.Fatalf("private key in %q is not RSA (got %T)", path, key)
+	}
+	return rsaKey
+}
+
+func openTestConn(ctx context.Context, t *testing.T) (*sql.Conn, func()) {
+	t.Helper()
+	configParams := []*sf.ConfigParam{
+		{Name: "Account", EnvName: "SNOWFLAKE_TEST_ACCOUNT", FailOnMissing: true},
+		{Name: "User", EnvName: "SNOWFLAKE_TEST_USER", FailOnMissing: true},
+		{Name: "Host", EnvName: "SNOWFLAKE_TEST_HOST", FailOnMissing: false},
+		{Name: "Port", EnvName: "SNOWFLAKE_TEST_PORT", FailOnMissing: false},
+		{Name: "Protocol", EnvName: "SNOWFLAKE_TEST_PROTOCOL", FailOnMissing: false},
+		{Name: "Warehouse", EnvName: "SNOWFLAKE_TEST_WAREHOUSE", FailOnMissing: false},
+		{Name: "Database", EnvName: "SNOWFLAKE_TEST_DATABASE", FailOnMissing: false},
+		{Name: "Schema", EnvName: "SNOWFLAKE_TEST_SCHEMA", FailOnMissing: false},
+	}
+	isJWT := os.Getenv("SNOWFLAKE_TEST_AUTHENTICATOR") == "SNOWFLAKE_JWT"
+	if !isJWT {
+		configParams = append(configParams,
+			&sf.ConfigParam{Name: "Password", EnvName: "SNOWFLAKE_TEST_PASSWORD", FailOnMissing: true},
+		)
+	}
+	cfg, err := sf.GetConfigFromEnv(configParams)
+	if err != nil {
+		t.Fatalf("failed to get config from environment: %v", err)
+	}
+	if isJWT {
+		privKeyPath := os.Getenv("SNOWFLAKE_TEST_PRIVATE_KEY")
+		if privKeyPath == "" {
+			t.Fatal("SNOWFLAKE_TEST_PRIVATE_KEY must be set for JWT authentication")
+		}
+		cfg.PrivateKey = readPrivateKey(t, privKeyPath)
+		cfg.Authenticator = sf.AuthTypeJwt
+	}
+	db := sql.OpenDB(sf.NewConnector(sf.SnowflakeDriver{}, *cfg))
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		db.Close()
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	return conn, func() {
+		conn.Close()
+		db.Close()
+	}
+}
+
+func TestSnowflakeColumnType(t *testing.T) {
+	testcases := []struct {
+		dt       arrow.DataType
+		expected string
+	}{
+		{dt: arrow.FixedWidthTypes.Boolean, expected: "BOOLEAN"},
+		{dt: arrow.PrimitiveTypes.Int8, expected: "NUMBER(38, 0)"},
+		{dt: arrow.PrimitiveTypes.Uint64, expected: "NUMBER(38, 0)"},
+		{dt: arrow.PrimitiveTypes.Float32, expected: "FLOAT"},
+		{dt: &arrow.Decimal128Type{Precision: 10, Scale: 2}, expected: "NUMBER(10, 2)"},
+		{dt: arrow.BinaryTypes.String, expected: "VARCHAR"},
+		{dt: arrow.BinaryTypes.LargeBinary, expected: "BINARY"},
+		{dt: arrow.FixedWidthTypes.Date32, expected: "DATE"},
+		{dt: arrow.FixedWidthTypes.Time32ms, expected: "TIME(3)"},
+		{dt: arrow.FixedWidthTypes.Time64ns, expected: "TIME(9)"},
+		{dt: &arrow.TimestampType{Unit: arrow.Microsecond}, expected: "TIMESTAMP_NTZ(6)"},
+		{dt: &arrow.TimestampType{Unit: arrow.Second, TimeZone: "UTC"}, expected: "TIMESTAMP_TZ(0)"},
+		{dt: arrow.ListOf(arrow.PrimitiveTypes.Int32), expected: "ARRAY"},
+		{dt: arrow.StructOf(arrow.Field{Name: "a", Type: arrow.BinaryTypes.String}), expected: "OBJECT"},
+		{dt: arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int64), expected: "OBJECT"},
+		{dt: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int16, ValueType: arrow.BinaryTypes.String}, expected: "VARCHAR"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.dt.String(), func(t *testing.T) {
+			typ, ok := snowflakeColumnType(tc.dt)
+			if !ok {
+				t.Fatalf("type %v should be supported", tc.dt)
+			}
+			if typ != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, typ)
+			}
+		})
+	}
+}
+
+func TestSchemaToColumnsUnsupportedType(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "d", Type: &arrow.Decimal256Type{Precision: 76, Scale: 0}},
+	}, nil)
+	_, err := schemaToColumns(schema)
+	var se *sf.SnowflakeError
+	if !errors.As(err, &se) {
+		t.Fatalf("expected SnowflakeError, got %v", err)
+	}
+	if se.Number != sf.ErrUnsupportedArrowIngestType {
+		t.Fatalf("expected error code %v, got %v", sf.ErrUnsupportedArrowIngestType, se.Number)
+	}
+}
+
+func TestCreateTableStmt(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "quoted\"name", Type: arrow.BinaryTypes.String},
+	}, nil)
+	columns, err := schemaToColumns(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `CREATE TABLE IF NOT EXISTS db.schema.t ("id" NUMBER(38, 0), "quoted""name" VARCHAR)`
+	if stmt := createTableStmt("db.schema.t", columns); stmt != expected {
+		t.Fatalf("expected %v, got %v", expected, stmt)
+	}
+}
+
+func TestIngestInvalidOnError(t *testing.T) {
+	for _, onError := range []string{"CONTINUE", "skip_file", "SKIP_FILE_10", "SKIP_FILE_5%", "ABORT_STATEMENT"} {
+		if !onErrorRegexp.MatchString(onError) {
+			t.Errorf("ON_ERROR %v should be valid", onError)
+		}
+	}
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	reader, err := array.NewRecordReader(schema, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Release()
+	for _, onError := range []string{"SKIP", "SKIP_FILE_%", "CONTINUE PURGE=FALSE", "'CONTINUE'"} {
+		_, err = Ingest(context.Background(), nil, "t", reader, &Options{OnError: onError})
+		var se *sf.SnowflakeError
+		if !errors.As(err, &se) || se.Number != sf.ErrArrowIngest {
+			t.Errorf("ON_ERROR %v should be rejected, got %v", onError, err)
+		}
+	}
+}
+
+func TestParseFileResult(t *testing.T) {
+	columns := []string{"file", "status", "rows_parsed", "rows_loaded", "error_limit", "errors_seen", "first_error"}
+	result, ok := parseFileResult(columns, []driver.Value{"stage/data_1.parquet", "PARTIALLY_LOADED", "10", int64(8), "10", "2", "invalid number"})
+	if !ok {
+		t.Fatal("row with a file should be parsed")
+	}
+	expected := FileResult{
+		File:       "stage/data_1.parquet",
+		Status:     "PARTIALLY_LOADED",
+		RowsParsed: 10,
+		RowsLoaded: 8,
+		ErrorsSeen: 2,
+		FirstError: "invalid number",
+	}
+	if result != expected {
+		t.Fatalf("expected %+v, got %+v", expected, result)
+	}
+	if _, ok = parseFileResult([]string{"status"}, []driver.Value{"Copy executed with 0 files processed."}); ok {
+		t.Fatal("row without a file should be skipped")
+	}
+}
+
+func TestParquetWriterRoundTrip(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	builder := array.NewRecordBuilder(pool, schema)
+	defer builder.Release()
+	builder.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3}, nil)
+	builder.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "", "c"}, []bool{true, false, true})
+	rec := builder.NewRecord()
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	writer, err := newParquetWriter(schema, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = writer.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	pqReader, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pqReader.Close()
+	reader, err := pqarrow.NewFileReader(pqReader, pqarrow.ArrowReadProperties{}, pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := reader.ReadTable(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer table.Release()
+	if table.NumRows() != 3 {
+		t.Fatalf("expected 3 rows, got %v", table.NumRows())
+	}
+	for i, field := range table.Schema().Fields() {
+		if field.Name != schema.Field(i).Name || !arrow.TypeEqual(field.Type, schema.Field(i).Type) {
+			t.Fatalf("expected field %v, got %v", schema.Field(i), field)
+		}
+	}
+}
+
+func TestIngest(t *testing.T) {
+	ctx := context.Background()
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "ID", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "NAME", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	var records []arrow.Record
+	for i := range 3 {
+		builder := array.NewRecordBuilder(pool, schema)
+		builder.Field(0).(*array.Int64Builder).AppendValues([]int64{int64(2 * i), int64(2*i + 1)}, nil)
+		builder.Field(1).(*array.StringBuilder).AppendValues([]string{"even", "odd"}, nil)
+		records = append(records, builder.NewRecord())
+		builder.Release()
+	}
+	reader, err := array.NewRecordReader(schema, records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Release()
+	for _, rec := range records {
+		rec.Release()
+	}
+
+	conn, cleanup := openTestConn(ctx, t)
+	defer cleanup()
+	if _, err = conn.ExecContext(ctx, "CREATE OR REPLACE TEMPORARY TABLE test_arrow_ingest (id NUMBER, name VARCHAR)"); err != nil {
+		t.Fatal(err)
+	}
+	// a tiny threshold writes every record into a separate file
+	res, err := Ingest(ctx, conn, "test_arrow_ingest", reader, &Options{FileSizeThreshold: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.QueryID == "" {
+		t.Error("query ID of COPY INTO should be set")
+	}
+	if len(res.Files) != 3 {
+		t.Fatalf("expected 3 files, got %v", len(res.Files))
+	}
+	if res.RowsLoaded() != 6 {
+		t.Fatalf("expected 6 rows loaded, got %v", res.RowsLoaded())
+	}
+	for _, f := range res.Files {
+		if f.Status != "LOADED" {
+			t.Errorf("file %v was not loaded: %v", f.File, f.FirstError)
+		}
+	}
+	var count int
+	if err = conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM test_arrow_ingest WHERE name = 'odd'").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 rows, got %v", count)
+	}
+}
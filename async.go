@@ -70,7 +70,13 @@ func (sr *snowflakeRestful) getAsync(
 	token, _, _ := sr.TokenAccessor.GetTokens()
 	headers[headerAuthorizationKey] = fmt.Sprintf(headerSnowflakeToken, token)
 
-	respd, err := getQueryResultWithRetriesForAsyncMode(ctx, sr, URL, headers, timeout)
+	pollCtx, span := sr.instrumentation.startSpan(ctx, spanGetAsync, attrQueryID.String(sfError.QueryID))
+	respd, err := getQueryResultWithRetriesForAsyncMode(pollCtx, sr, URL, headers, timeout)
+	if err == nil && !respd.Success {
+		endSpan(span, fmt.Errorf("query failed. code: %v, message: %v", respd.Code, respd.Message))
+	} else {
+		endSpan(span, err)
+	}
 	if err != nil {
 		logger.WithContext(ctx).Errorf("error: %v", err)
 		sfError.Message = err.Error()
@@ -138,6 +144,9 @@ func (sr *snowflakeRestful) getAsync(
 	return nil
 }
 
+// asyncRetryPattern is the multiplier of the sleep time between the polls of a running query.
+var asyncRetryPattern = []int32{1, 1, 2, 3, 4, 8, 10}
+
 func getQueryResultWithRetriesForAsyncMode(
 	ctx context.Context,
 	sr *snowflakeRestful,
@@ -145,7 +154,7 @@ func getQueryResultWithRetriesForAsyncMode(
 	headers map[string]string,
 	timeout time.Duration) (respd *execResponse, err error) {
 	retry := 0
-	retryPattern := []int32{1, 1, 2, 3, 4, 8, 10}
+	retryPattern := asyncRetryPattern
 	retryPatternIndex := 0
 	retryCountForSessionRenewal := 0
 
@@ -0,0 +1,189 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	ia "github.com/snowflakedb/gosnowflake/v2/internal/arrow"
+)
+
+const defaultAsyncQueryPollInterval = 500 * time.Millisecond
+
+// AsyncQuery is the handle of a query submitted with SubmitAsync.
+// The query runs on the server independently of the handle, so it can be resumed by its ID
+// with ResumeAsync, also on another connection or in another process.
+type AsyncQuery struct {
+	sc           *snowflakeConn
+	queryID      string
+	pollInterval time.Duration
+}
+
+// AsyncQuerySubmitter is implemented by the connections of the driver and submits queries
+// without waiting for their results.
+type AsyncQuerySubmitter interface {
+	SubmitAsync(ctx context.Context, query string, args []driver.NamedValue) (*AsyncQuery, error)
+	ResumeAsync(queryID string) (*AsyncQuery, error)
+}
+
+// SubmitAsync submits the query without waiting for its result and returns the handle of the query.
+func (sc *snowflakeConn) SubmitAsync(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue) (
+	*AsyncQuery, error) {
+	if sc.rest == nil {
+		return nil, driver.ErrBadConn
+	}
+	ctx = WithAsyncMode(ctx)
+	if !sc.shouldIntercept(ctx) {
+		return sc.submitAsync(ctx, query, args)
+	}
+	var asyncQuery *AsyncQuery
+	_, err := sc.intercept(ctx, QueryKindQuery, query, args, func(ctx context.Context, q *InterceptedQuery) (err error) {
+		asyncQuery, err = sc.submitAsync(ctx, q.SQL, q.Bindings)
+		return err
+	})
+	if err == nil && asyncQuery == nil {
+		// the rows set by a short-circuiting interceptor are not a handle of a submitted query
+		return nil, errQueryNotExecutedByInterceptor
+	}
+	return asyncQuery, err
+}
+
+func (sc *snowflakeConn) submitAsync(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue) (
+	*AsyncQuery, error) {
+	_, _, sessionID := safeGetTokens(sc.rest)
+	ctx = context.WithValue(ctx, SFSessionIDKey, sessionID)
+	logger.WithContext(ctx).Debug("SubmitAsync:")
+	// the result is fetched with the handle, so no goroutine waits for it
+	ctx = setResultType(ctx, asyncResultType)
+	data, err := sc.exec(ctx, query, true, isInternal(ctx), false, args)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("error: %v", err)
+		if data != nil {
+			code, e := strconv.Atoi(data.Code)
+			if e != nil {
+				return nil, e
+			}
+			return nil, exceptionTelemetry(&SnowflakeError{
+				Number:   code,
+				SQLState: data.Data.SQLState,
+				Message:  err.Error(),
+				QueryID:  data.Data.QueryID,
+			}, sc)
+		}
+		return nil, err
+	}
+	return sc.newAsyncQuery(data.Data.QueryID), nil
+}
+
+// ResumeAsync returns the handle of the query submitted earlier, given its ID.
+func (sc *snowflakeConn) ResumeAsync(queryID string) (*AsyncQuery, error) {
+	if err := validateQueryID(queryID); err != nil {
+		return nil, err
+	}
+	return sc.newAsyncQuery(queryID), nil
+}
+
+func (sc *snowflakeConn) newAsyncQuery(queryID string) *AsyncQuery {
+	return &AsyncQuery{
+		sc:           sc,
+		queryID:      queryID,
+		pollInterval: defaultAsyncQueryPollInterval,
+	}
+}
+
+// ID returns the query ID used to resume the query with ResumeAsync.
+func (aq *AsyncQuery) ID() string {
+	return aq.queryID
+}
+
+// Status returns the current status of the query. ErrQueryStatus is returned if the server
+// has no status of the query yet, e.g. right after the submission.
+func (aq *AsyncQuery) Status(ctx context.Context) (*SnowflakeQueryStatus, error) {
+	queryRet, err := aq.sc.fetchQueryStatus(ctx, aq.queryID)
+	if err != nil {
+		return nil, err
+	}
+	return newSnowflakeQueryStatus(queryRet), nil
+}
+
+// Wait polls the status of the query with backoff until the query completes or the context is done.
+// onStatusChange, if not nil, is called whenever the status changes, e.g. to QUEUED, RESUMING_WAREHOUSE or BLOCKED.
+// The error is returned if the query failed or was aborted.
+func (aq *AsyncQuery) Wait(ctx context.Context, onStatusChange func(status *SnowflakeQueryStatus)) error {
+	lastStatus := ""
+	retryPatternIndex := 0
+	for {
+		queryRet, err := aq.sc.fetchQueryStatus(ctx, aq.queryID)
+		var se *SnowflakeError
+		switch {
+		case errors.As(err, &se) && se.Number == ErrQueryStatus:
+			logger.WithContext(ctx).Debugf("no status of query %v yet. %v", aq.queryID, err)
+		case err != nil:
+			return err
+		default:
+			if queryRet.Status != lastStatus {
+				lastStatus = queryRet.Status
+				if onStatusChange != nil {
+					onStatusChange(newSnowflakeQueryStatus(queryRet))
+				}
+			}
+			// a blocked query waits for a lock and continues once it is released
+			if qStatus := strToQueryStatus(queryRet.Status); queryRet.ErrorCode != "" || (!qStatus.isRunning() && qStatus != SFQueryBlocked) {
+				return aq.sc.queryStatusError(aq.queryID, queryRet)
+			}
+		}
+
+		sleepTime := aq.pollInterval * time.Duration(asyncRetryPattern[retryPatternIndex])
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sleepTime):
+		}
+		if retryPatternIndex < len(asyncRetryPattern)-1 {
+			retryPatternIndex++
+		}
+	}
+}
+
+// Cancel aborts the query.
+func (aq *AsyncQuery) Cancel(ctx context.Context) error {
+	return aq.sc.abortQuery(ctx, aq.queryID)
+}
+
+// Rows returns the result of the query, waiting for the query to complete.
+// Use it with the context returned by arrowbatches.WithArrowBatches to read the result with arrowbatches.GetArrowBatches.
+func (aq *AsyncQuery) Rows(ctx context.Context) (driver.Rows, error) {
+	return aq.sc.buildRowsForRunningQuery(ctx, aq.queryID)
+}
+
+// ArrowBatches returns the ArrowStreamLoader of the query result, waiting for the query to complete.
+func (aq *AsyncQuery) ArrowBatches(ctx context.Context) (ArrowStreamLoader, error) {
+	ctx = ia.EnableArrowBatches(ctx)
+	resp, err := aq.sc.getQueryResultResp(ctx, fmt.Sprintf(urlQueriesResultFmt, aq.queryID))
+	if err != nil {
+		logger.WithContext(ctx).Errorf("error: %v", err)
+		return nil, err
+	}
+	if !resp.Success {
+		code, err := strconv.Atoi(resp.Code)
+		if err != nil {
+			return nil, err
+		}
+		return nil, exceptionTelemetry(&SnowflakeError{
+			Number:   code,
+			SQLState: resp.Data.SQLState,
+			Message:  resp.Message,
+			QueryID:  resp.Data.QueryID,
+		}, aq.sc)
+	}
+	return newArrowStreamChunkDownloader(ctx, aq.sc, resp.Data)
+}
@@ -0,0 +1,130 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newAsyncQueryTestConn(t *testing.T, requests *[]execRequest, statuses ...string) *snowflakeConn {
+	getMock := func(_ context.Context, _ *snowflakeRestful, _ *url.URL, _ map[string]string, _ time.Duration) (*http.Response, error) {
+		if len(statuses) == 0 {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"success": false}`))}, nil
+		}
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		body := fmt.Sprintf(`{"success": true, "data": {"queries": [{"status": %q, "errorMessage": "failed"}]}}`, status)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+	return &snowflakeConn{
+		cfg: &Config{},
+		rest: &snowflakeRestful{
+			TokenAccessor: getSimpleTokenAccessor(),
+			FuncPostQuery: recordingPostQuery(t, requests, func(execRequest) *execResponse {
+				return &execResponse{Data: execResponseData{QueryID: "async-query-id"}, Code: queryInProgressAsyncCode, Success: true}
+			}),
+			FuncGet: getMock,
+		},
+	}
+}
+
+func TestAsyncQuerySubmitAndWait(t *testing.T) {
+	var requests []execRequest
+	sc := newAsyncQueryTestConn(t, &requests, "QUEUED", "QUEUED", "BLOCKED", "RUNNING", "SUCCESS")
+	asyncQuery, err := sc.SubmitAsync(context.Background(), "SELECT ?", []driver.NamedValue{{Ordinal: 1, Value: int64(1)}})
+	assertNilF(t, err)
+	assertEqualE(t, asyncQuery.ID(), "async-query-id")
+	assertEqualF(t, len(requests), 1)
+	assertTrueE(t, requests[0].AsyncExec)
+
+	asyncQuery.pollInterval = time.Millisecond
+	var changes []string
+	assertNilF(t, asyncQuery.Wait(context.Background(), func(status *SnowflakeQueryStatus) {
+		changes = append(changes, status.Status)
+	}))
+	assertDeepEqualE(t, changes, []string{"QUEUED", "BLOCKED", "RUNNING", "SUCCESS"})
+
+	status, err := asyncQuery.Status(context.Background())
+	assertNilF(t, err)
+	assertEqualE(t, status.Status, "SUCCESS")
+}
+
+func TestAsyncQueryWaitFailed(t *testing.T) {
+	var requests []execRequest
+	sc := newAsyncQueryTestConn(t, &requests, "RUNNING", "FAILED_WITH_ERROR")
+	asyncQuery, err := sc.ResumeAsync("async-query-id")
+	assertNilF(t, err)
+	asyncQuery.pollInterval = time.Millisecond
+	var se *SnowflakeError
+	assertErrorsAsF(t, asyncQuery.Wait(context.Background(), nil), &se)
+	assertEqualE(t, se.Number, ErrQueryReportedError)
+	assertEqualE(t, se.QueryID, "async-query-id")
+}
+
+func TestAsyncQueryWaitContextDone(t *testing.T) {
+	var requests []execRequest
+	sc := newAsyncQueryTestConn(t, &requests)
+	asyncQuery, err := sc.ResumeAsync("async-query-id")
+	assertNilF(t, err)
+	asyncQuery.pollInterval = time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assertErrIsE(t, asyncQuery.Wait(ctx, nil), context.DeadlineExceeded)
+}
+
+func TestAsyncQueryCancel(t *testing.T) {
+	var requests []execRequest
+	sc := newAsyncQueryTestConn(t, &requests)
+	asyncQuery, err := sc.ResumeAsync("async-query-id")
+	assertNilF(t, err)
+	assertNilF(t, asyncQuery.Cancel(context.Background()))
+	assertEqualF(t, len(requests), 1)
+	assertEqualE(t, requests[0].SQLText, "SELECT SYSTEM$CANCEL_QUERY(?)")
+	assertTrueE(t, requests[0].IsInternal)
+	assertFalseE(t, requests[0].AsyncExec)
+	assertEqualE(t, requests[0].Bindings["1"].Value, any("async-query-id"))
+}
+
+func TestResumeAsyncInvalidQueryID(t *testing.T) {
+	sc := newAsyncQueryTestConn(t, nil)
+	_, err := sc.ResumeAsync("")
+	var se *SnowflakeError
+	assertErrorsAsF(t, err, &se)
+	assertEqualE(t, se.Number, ErrQueryIDFormat)
+}
+
+func TestCancelQueryByID(t *testing.T) {
+	testcases := []struct {
+		statuses []string
+		aborted  bool
+	}{
+		{statuses: []string{"ABORTED"}, aborted: true},
+		{statuses: []string{"ABORTING", "ABORTED"}, aborted: true},
+		{statuses: []string{"SUCCESS"}, aborted: false},
+	}
+	for _, tc := range testcases {
+		t.Run(strings.Join(tc.statuses, ","), func(t *testing.T) {
+			var requests []execRequest
+			sc := newAsyncQueryTestConn(t, &requests, tc.statuses...)
+			aborted, err := sc.CancelQuery(context.Background(), "async-query-id")
+			assertNilF(t, err)
+			assertEqualE(t, aborted, tc.aborted)
+			assertEqualF(t, len(requests), 1)
+			assertEqualE(t, requests[0].SQLText, "SELECT SYSTEM$CANCEL_QUERY(?)")
+		})
+	}
+
+	sc := newAsyncQueryTestConn(t, nil)
+	_, err := sc.CancelQuery(context.Background(), "")
+	var se *SnowflakeError
+	assertErrorsAsF(t, err, &se)
+	assertEqualE(t, se.Number, ErrQueryIDFormat)
+}
@@ -2,6 +2,9 @@ package gosnowflake
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
@@ -63,10 +66,12 @@ const (
 	AuthTypeOAuthClientCredentials = sfconfig.AuthTypeOAuthClientCredentials
 	// AuthTypeWorkloadIdentityFederation is to use CSP identity for authentication
 	AuthTypeWorkloadIdentityFederation = sfconfig.AuthTypeWorkloadIdentityFederation
+	// AuthTypeOAuthDeviceCode is to use OAuth2 device authorization flow for devices without a browser
+	AuthTypeOAuthDeviceCode = sfconfig.AuthTypeOAuthDeviceCode
 )
 
 func isOauthNativeFlow(authType AuthType) bool {
-	return authType == AuthTypeOAuthAuthorizationCode || authType == AuthTypeOAuthClientCredentials
+	return authType == AuthTypeOAuthAuthorizationCode || authType == AuthTypeOAuthClientCredentials || authType == AuthTypeOAuthDeviceCode
 }
 
 var refreshOAuthTokenErrorCodes = []string{
@@ -271,6 +276,10 @@ func authenticate(
 			SessionInfo: sessionInfo,
 		}, nil
 	}
+	ctx, span := sc.instrumentation().startSpan(ctx, spanAuthenticate, attrAuthenticator.String(authenticatorName(sc.cfg)))
+	defer func() {
+		endSpan(span, err)
+	}()
 
 	headers := getHeaders()
 	// Get the current application path
@@ -286,6 +295,8 @@ func authenticate(
 		oauthType = "OAUTH_AUTHORIZATION_CODE"
 	case AuthTypeOAuthClientCredentials:
 		oauthType = "OAUTH_CLIENT_CREDENTIALS"
+	case AuthTypeOAuthDeviceCode:
+		oauthType = "OAUTH_DEVICE_CODE"
 	}
 
 	clientEnvironment := newAuthRequestClientEnvironment()
@@ -327,7 +338,7 @@ func authenticate(
 	}
 
 	logger.WithContext(ctx).Infof("Information for Auth: Host: %v, User: %v, Authenticator: %v, Params: %v, Protocol: %v, Port: %v, LoginTimeout: %v",
-		sc.rest.Host, sc.cfg.User, sc.cfg.Authenticator.String(), params, sc.rest.Protocol, sc.rest.Port, sc.rest.LoginTimeout)
+		sc.rest.Host, sc.cfg.User, authenticatorName(sc.cfg), params, sc.rest.Protocol, sc.rest.Port, sc.rest.LoginTimeout)
 
 	respd, err := sc.rest.FuncPostAuth(ctx, sc.rest, sc.rest.getClientFor(sc.cfg.Authenticator), params, headers, bodyCreator, sc.rest.LoginTimeout)
 	if err != nil {
@@ -337,13 +348,13 @@ func authenticate(
 		logger.WithContext(ctx).Error("Authentication FAILED")
 		sc.rest.TokenAccessor.SetTokens("", "", -1)
 		if sessionParameters[clientRequestMfaToken] == true {
-			credentialsStorage.deleteCredential(newMfaTokenSpec(sc.cfg.Host, sc.cfg.User))
+			credentialsStorageFor(sc.cfg).deleteCredential(newMfaTokenSpec(sc.cfg.Host, sc.cfg.User))
 		}
 		if sessionParameters[clientStoreTemporaryCredential] == true && sc.cfg.Authenticator == AuthTypeExternalBrowser {
-			credentialsStorage.deleteCredential(newIDTokenSpec(sc.cfg.Host, sc.cfg.User))
+			credentialsStorageFor(sc.cfg).deleteCredential(newIDTokenSpec(sc.cfg.Host, sc.cfg.User))
 		}
 		if sessionParameters[clientStoreTemporaryCredential] == true && isOauthNativeFlow(sc.cfg.Authenticator) {
-			credentialsStorage.deleteCredential(newOAuthAccessTokenSpec(sc.cfg.OauthTokenRequestURL, sc.cfg.User))
+			credentialsStorageFor(sc.cfg).deleteCredential(newOAuthAccessTokenSpec(sc.cfg.OauthTokenRequestURL, sc.cfg.User))
 		}
 		code, err := strconv.Atoi(respd.Code)
 		if err != nil {
@@ -359,11 +370,11 @@ func authenticate(
 	sc.rest.TokenAccessor.SetTokens(respd.Data.Token, respd.Data.MasterToken, respd.Data.SessionID)
 	if sessionParameters[clientRequestMfaToken] == true {
 		token := respd.Data.MfaToken
-		credentialsStorage.setCredential(newMfaTokenSpec(sc.cfg.Host, sc.cfg.User), token)
+		credentialsStorageFor(sc.cfg).setCredential(newMfaTokenSpec(sc.cfg.Host, sc.cfg.User), token)
 	}
 	if sessionParameters[clientStoreTemporaryCredential] == true {
 		token := respd.Data.IDToken
-		credentialsStorage.setCredential(newIDTokenSpec(sc.cfg.Host, sc.cfg.User), token)
+		credentialsStorageFor(sc.cfg).setCredential(newIDTokenSpec(sc.cfg.Host, sc.cfg.User), token)
 	}
 	return &respd.Data, nil
 }
@@ -430,6 +441,10 @@ func createRequestBody(sc *snowflakeConn, sessionParameters map[string]any,
 		SpcsToken:         spcs.GetToken(sc.ctx),
 	}
 
+	if sc.cfg.CustomAuthenticator != nil {
+		return createCustomRequestBody(sc, requestMain)
+	}
+
 	switch sc.cfg.Authenticator {
 	case AuthTypeExternalBrowser:
 		if sc.idToken != "" {
@@ -523,6 +538,14 @@ func createRequestBody(sc *snowflakeConn, sessionParameters map[string]any,
 		}
 		requestMain.LoginName = sc.cfg.User
 		requestMain.Token = token
+	case AuthTypeOAuthDeviceCode:
+		logger.WithContext(sc.ctx).Debug("OAuth device code")
+		token, err := authenticateByDeviceCode(sc)
+		if err != nil {
+			return nil, err
+		}
+		requestMain.LoginName = sc.cfg.User
+		requestMain.Token = token
 	case AuthTypeWorkloadIdentityFederation:
 		logger.WithContext(sc.ctx).Debug("Workload Identity Federation")
 		wifAttestationProvider := createWifAttestationProvider(sc.ctx, sc.cfg, sc.telemetry)
@@ -565,6 +588,14 @@ func newOAuthAuthorizationCodeLockKey(tokenRequestURL, user string) *oauthLockKe
 	}
 }
 
+func newOAuthDeviceCodeLockKey(tokenRequestURL, user string) *oauthLockKey {
+	return &oauthLockKey{
+		tokenRequestURL: tokenRequestURL,
+		user:            user,
+		flowType:        "device_code",
+	}
+}
+
 func newRefreshTokenLockKey(tokenRequestURL, user string) *oauthLockKey {
 	return &oauthLockKey{
 		tokenRequestURL: tokenRequestURL,
@@ -578,19 +609,29 @@ func (o *oauthLockKey) lockID() string {
 }
 
 func authenticateByAuthorizationCode(sc *snowflakeConn) (string, error) {
+	return authenticateByInteractiveOAuthFlow(sc, newOAuthAuthorizationCodeLockKey, (*oauthClient).authenticateByOAuthAuthorizationCode)
+}
+
+func authenticateByDeviceCode(sc *snowflakeConn) (string, error) {
+	return authenticateByInteractiveOAuthFlow(sc, newOAuthDeviceCodeLockKey, (*oauthClient).authenticateByOAuthDeviceCode)
+}
+
+// authenticateByInteractiveOAuthFlow runs the OAuth flow requiring the user interaction.
+// If parallel login is enabled, only one connection prompts the user and the others wait for the cached access token.
+func authenticateByInteractiveOAuthFlow(sc *snowflakeConn, newLockKey func(tokenRequestURL, user string) *oauthLockKey, flow func(*oauthClient) (string, error)) (string, error) {
 	oauthClient, err := newOauthClient(sc.ctx, sc.cfg, sc)
 	if err != nil {
 		return "", err
 	}
 	if !isEligibleForParallelLogin(sc.cfg, sc.cfg.ClientStoreTemporaryCredential) {
-		return oauthClient.authenticateByOAuthAuthorizationCode()
+		return flow(oauthClient)
 	}
 
-	lockKey := newOAuthAuthorizationCodeLockKey(oauthClient.tokenURL(), sc.cfg.User)
+	lockKey := newLockKey(oauthClient.tokenURL(), sc.cfg.User)
 	valueAwaiter := valueAwaitHolder.get(lockKey)
 	defer valueAwaiter.resumeOne()
 	token, err := awaitValue(valueAwaiter, func() (string, error) {
-		return credentialsStorage.getCredential(newOAuthAccessTokenSpec(oauthClient.tokenURL(), sc.cfg.User)), nil
+		return credentialsStorageFor(sc.cfg).getCredential(newOAuthAccessTokenSpec(oauthClient.tokenURL(), sc.cfg.User)), nil
 	}, func(s string, err error) bool {
 		return s != ""
 	}, func() string {
@@ -599,7 +640,7 @@ func authenticateByAuthorizationCode(sc *snowflakeConn) (string, error) {
 	if err != nil || token != "" {
 		return token, err
 	}
-	token, err = oauthClient.authenticateByOAuthAuthorizationCode()
+	token, err = flow(oauthClient)
 	if err != nil {
 		return "", err
 	}
@@ -609,11 +650,15 @@ func authenticateByAuthorizationCode(sc *snowflakeConn) (string, error) {
 
 // Generate a JWT token in string given the configuration
 func prepareJWTToken(config *Config) (string, error) {
-	if config.PrivateKey == nil {
-		return "", errors.New("trying to use keypair authentication, but PrivateKey was not provided in the driver config")
+	signer, err := sfconfig.GetPrivateKeySigner(config)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+		return "", fmt.Errorf("keypair authentication requires an RSA key, but the public key is %T", signer.Public())
 	}
 	logger.Debug("preparing JWT for keypair authentication")
-	pubBytes, err := x509.MarshalPKIXPublicKey(config.PrivateKey.Public())
+	pubBytes, err := x509.MarshalPKIXPublicKey(signer.Public())
 	if err != nil {
 		return "", err
 	}
@@ -630,9 +675,9 @@ func prepareJWTToken(config *Config) (string, error) {
 		"nbf": time.Date(2015, 10, 10, 12, 0, 0, 0, time.UTC).Unix(),
 		"exp": issueAtTime.Add(config.JWTExpireTimeout).Unix(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwtClaims)
+	token := jwt.NewWithClaims(signingMethodRS256Signer, jwtClaims)
 
-	tokenString, err := token.SignedString(config.PrivateKey)
+	tokenString, err := token.SignedString(signer)
 
 	if err != nil {
 		return "", err
@@ -642,6 +687,28 @@ func prepareJWTToken(config *Config) (string, error) {
 	return tokenString, err
 }
 
+// signingMethodRS256Signer signs JWT with RS256 using a crypto.Signer, so the private key doesn't have to be exported.
+var signingMethodRS256Signer = &rs256SignerMethod{}
+
+type rs256SignerMethod struct{}
+
+func (m *rs256SignerMethod) Alg() string {
+	return jwt.SigningMethodRS256.Alg()
+}
+
+func (m *rs256SignerMethod) Sign(signingString string, key any) ([]byte, error) {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, jwt.ErrInvalidKeyType
+	}
+	digest := sha256.Sum256([]byte(signingString))
+	return signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+func (m *rs256SignerMethod) Verify(signingString string, sig []byte, key any) error {
+	return jwt.SigningMethodRS256.Verify(signingString, sig, key)
+}
+
 type tokenLockKey struct {
 	snowflakeHost string
 	user          string
@@ -674,10 +741,14 @@ func authenticateWithConfig(sc *snowflakeConn) error {
 	var proofKey []byte
 	var err error
 
+	if sc.cfg.CustomAuthenticator != nil {
+		return authenticateByCustomAuthenticator(sc)
+	}
+
 	mfaTokenLockKey := newMfaTokenLockKey(sc.cfg.Host, sc.cfg.User)
 	idTokenLockKey := newIDTokenLockKey(sc.cfg.Host, sc.cfg.User)
 
-	if sc.cfg.Authenticator == AuthTypeExternalBrowser || sc.cfg.Authenticator == AuthTypeOAuthAuthorizationCode || sc.cfg.Authenticator == AuthTypeOAuthClientCredentials {
+	if sc.cfg.Authenticator == AuthTypeExternalBrowser || isOauthNativeFlow(sc.cfg.Authenticator) {
 		if (runtime.GOOS == "windows" || runtime.GOOS == "darwin") && sc.cfg.ClientStoreTemporaryCredential == sfconfig.BoolNotSet {
 			sc.cfg.ClientStoreTemporaryCredential = ConfigBoolTrue
 		}
@@ -686,7 +757,7 @@ func authenticateWithConfig(sc *snowflakeConn) error {
 				valueAwaiter := valueAwaitHolder.get(idTokenLockKey)
 				defer valueAwaiter.resumeOne()
 				sc.idToken, _ = awaitValue(valueAwaiter, func() (string, error) {
-					credential := credentialsStorage.getCredential(newIDTokenSpec(sc.cfg.Host, sc.cfg.User))
+					credential := credentialsStorageFor(sc.cfg).getCredential(newIDTokenSpec(sc.cfg.Host, sc.cfg.User))
 					return credential, nil
 				}, func(s string, err error) bool {
 					return s != ""
@@ -694,7 +765,7 @@ func authenticateWithConfig(sc *snowflakeConn) error {
 					return ""
 				})
 			} else if sc.cfg.ClientStoreTemporaryCredential == ConfigBoolTrue {
-				sc.idToken = credentialsStorage.getCredential(newIDTokenSpec(sc.cfg.Host, sc.cfg.User))
+				sc.idToken = credentialsStorageFor(sc.cfg).getCredential(newIDTokenSpec(sc.cfg.Host, sc.cfg.User))
 			}
 		}
 		// Disable console login by default
@@ -711,7 +782,7 @@ func authenticateWithConfig(sc *snowflakeConn) error {
 			valueAwaiter := valueAwaitHolder.get(mfaTokenLockKey)
 			defer valueAwaiter.resumeOne()
 			sc.mfaToken, _ = awaitValue(valueAwaiter, func() (string, error) {
-				credential := credentialsStorage.getCredential(newMfaTokenSpec(sc.cfg.Host, sc.cfg.User))
+				credential := credentialsStorageFor(sc.cfg).getCredential(newMfaTokenSpec(sc.cfg.Host, sc.cfg.User))
 				return credential, nil
 			}, func(s string, err error) bool {
 				return s != ""
@@ -719,7 +790,7 @@ func authenticateWithConfig(sc *snowflakeConn) error {
 				return ""
 			})
 		} else if sc.cfg.ClientRequestMfaToken == ConfigBoolTrue {
-			sc.mfaToken = credentialsStorage.getCredential(newMfaTokenSpec(sc.cfg.Host, sc.cfg.User))
+			sc.mfaToken = credentialsStorageFor(sc.cfg).getCredential(newMfaTokenSpec(sc.cfg.Host, sc.cfg.User))
 		}
 	}
 
@@ -750,13 +821,13 @@ func authenticateWithConfig(sc *snowflakeConn) error {
 	if err != nil {
 		var se *SnowflakeError
 		if errors.As(err, &se) && slices.Contains(refreshOAuthTokenErrorCodes, strconv.Itoa(se.Number)) {
-			credentialsStorage.deleteCredential(newOAuthAccessTokenSpec(sc.cfg.OauthTokenRequestURL, sc.cfg.User))
+			credentialsStorageFor(sc.cfg).deleteCredential(newOAuthAccessTokenSpec(sc.cfg.OauthTokenRequestURL, sc.cfg.User))
 
-			if sc.cfg.Authenticator == AuthTypeOAuthAuthorizationCode {
+			if sc.cfg.Authenticator == AuthTypeOAuthAuthorizationCode || sc.cfg.Authenticator == AuthTypeOAuthDeviceCode {
 				doRefreshTokenWithLock(sc)
 			}
 
-			// if refreshing succeeds for authorization code, we will take a token from cache
+			// if refreshing succeeds for authorization code or device code, we will take a token from cache
 			// if it fails, we will just run the full flow
 			authData, err = authenticate(sc.ctx, sc, nil, nil)
 		}
@@ -773,10 +844,16 @@ func authenticateWithConfig(sc *snowflakeConn) error {
 		valueAwaiter := valueAwaitHolder.get(idTokenLockKey)
 		valueAwaiter.done()
 	}
+	sc.initSession(authData)
+	return nil
+}
+
+// initSession configures the connection with the session created by the login.
+func (sc *snowflakeConn) initSession(authData *authResponseMain) {
 	sc.populateSessionParameters(authData.Parameters)
+	sc.initSessionState(authData.SessionInfo)
 	sc.configureTelemetry()
 	sc.ctx = context.WithValue(sc.ctx, SFSessionIDKey, authData.SessionID)
-	return nil
 }
 
 func doRefreshTokenWithLock(sc *snowflakeConn) {
@@ -787,7 +864,7 @@ func doRefreshTokenWithLock(sc *snowflakeConn) {
 		if _, err = getValueWithLock(chooseLockerForAuth(sc.cfg), lockKey, func() (string, error) {
 			if err = oauthClient.refreshToken(); err != nil {
 				logger.Warnf("cannot refresh token. %v", err)
-				credentialsStorage.deleteCredential(newOAuthRefreshTokenSpec(sc.cfg.OauthTokenRequestURL, sc.cfg.User))
+				credentialsStorageFor(sc.cfg).deleteCredential(newOAuthRefreshTokenSpec(sc.cfg.OauthTokenRequestURL, sc.cfg.User))
 				return "", err
 			}
 			return "", nil
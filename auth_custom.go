@@ -0,0 +1,92 @@
+package gosnowflake
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+
+	sfconfig "github.com/snowflakedb/gosnowflake/v2/internal/config"
+)
+
+// Authenticator is a custom authentication method set in Config.CustomAuthenticator.
+// It is used instead of the built-in authenticator selected with Config.Authenticator.
+type Authenticator = sfconfig.Authenticator
+
+// AuthenticatorRenewer is implemented by the Authenticator able to renew its credentials,
+// e.g. with a refresh token.
+type AuthenticatorRenewer = sfconfig.AuthenticatorRenewer
+
+// AuthenticatorErrorHandler is implemented by the Authenticator handling the login errors,
+// e.g. asking for the MFA passcode or changing the expired password.
+type AuthenticatorErrorHandler = sfconfig.AuthenticatorErrorHandler
+
+// LoginRequest is the login request sent to Snowflake, filled by the custom Authenticator.
+type LoginRequest = sfconfig.LoginRequest
+
+// maxCustomAuthenticatorAttempts limits the logins retried after the renewal or the error handling.
+const maxCustomAuthenticatorAttempts = 3
+
+func authenticatorName(cfg *Config) string {
+	if cfg.CustomAuthenticator != nil {
+		return cfg.CustomAuthenticator.Name()
+	}
+	return cfg.Authenticator.String()
+}
+
+// authenticateByCustomAuthenticator logs in with the custom authenticator. If the login fails,
+// the authenticator can renew the rejected token or handle the error before the login is retried.
+func authenticateByCustomAuthenticator(sc *snowflakeConn) error {
+	authenticator := sc.cfg.CustomAuthenticator
+	logger.WithContext(sc.ctx).Infof("Authenticating via custom authenticator %v", authenticator.Name())
+	authData, err := authenticate(sc.ctx, sc, nil, nil)
+	for attempt := 1; err != nil && attempt < maxCustomAuthenticatorAttempts; attempt++ {
+		var se *SnowflakeError
+		renewer, canRenew := authenticator.(AuthenticatorRenewer)
+		errorHandler, canHandleError := authenticator.(AuthenticatorErrorHandler)
+		switch {
+		case canRenew && errors.As(err, &se) && slices.Contains(refreshOAuthTokenErrorCodes, strconv.Itoa(se.Number)):
+			logger.WithContext(sc.ctx).Debugf("renewing credentials of custom authenticator. %v", err)
+			err = renewer.Renew(sc.ctx)
+		case canHandleError:
+			logger.WithContext(sc.ctx).Debugf("handling login error by custom authenticator. %v", err)
+			err = errorHandler.HandleLoginError(sc.ctx, err)
+		}
+		if err != nil {
+			break
+		}
+		authData, err = authenticate(sc.ctx, sc, nil, nil)
+	}
+	if err != nil {
+		sc.cleanup()
+		return err
+	}
+	sc.initSession(authData)
+	return nil
+}
+
+// createCustomRequestBody creates the login request body filled by the custom authenticator.
+func createCustomRequestBody(sc *snowflakeConn, requestMain authRequestData) ([]byte, error) {
+	req := &LoginRequest{
+		Account:   requestMain.AccountName,
+		LoginName: sc.cfg.User,
+		// the parameters are cloned, so changes are not kept between the attempts
+		SessionParameters: maps.Clone(requestMain.SessionParameters),
+	}
+	if err := sc.cfg.CustomAuthenticator.Authenticate(sc.ctx, req); err != nil {
+		return nil, err
+	}
+	requestMain.AccountName = req.Account
+	requestMain.LoginName = req.LoginName
+	requestMain.Authenticator = req.Authenticator
+	requestMain.Password = req.Password
+	requestMain.Passcode = req.Passcode
+	requestMain.ExtAuthnDuoMethod = req.ExtAuthnDuoMethod
+	requestMain.Token = req.Token
+	requestMain.RawSAMLResponse = req.RawSAMLResponse
+	requestMain.Provider = req.Provider
+	requestMain.SessionParameters = req.SessionParameters
+	logger.WithContext(sc.ctx).Debugf("Request body is created for the authentication. Authenticator: %s, User: %s, Account: %s", sc.cfg.CustomAuthenticator.Name(), req.LoginName, req.Account)
+	return json.Marshal(authRequest{Data: requestMain})
+}
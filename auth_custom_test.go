@@ -0,0 +1,123 @@
+package gosnowflake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type testCustomAuthenticator struct {
+	token          string
+	renewals       int
+	handledErrors  []error
+	handleErrorErr error
+}
+
+func (a *testCustomAuthenticator) Name() string {
+	return "TEST_SSO"
+}
+
+func (a *testCustomAuthenticator) Authenticate(_ context.Context, req *LoginRequest) error {
+	req.Authenticator = "OAUTH"
+	req.Token = a.token
+	req.SessionParameters["QUERY_TAG"] = "custom"
+	return nil
+}
+
+func (a *testCustomAuthenticator) Renew(context.Context) error {
+	a.renewals++
+	a.token = "renewed-token"
+	return nil
+}
+
+func (a *testCustomAuthenticator) HandleLoginError(_ context.Context, err error) error {
+	a.handledErrors = append(a.handledErrors, err)
+	return a.handleErrorErr
+}
+
+func newCustomAuthenticatorTestConn(authenticator Authenticator, responses func(ar authRequest) *authResponse) *snowflakeConn {
+	initPlatformDetection()
+	sc := getDefaultSnowflakeConn()
+	sc.ctx = context.Background()
+	sc.cfg.CustomAuthenticator = authenticator
+	sc.rest = &snowflakeRestful{
+		TokenAccessor: getSimpleTokenAccessor(),
+		FuncPostAuth: func(_ context.Context, _ *snowflakeRestful, _ *http.Client, _ *url.Values, _ map[string]string, bodyCreator bodyCreatorType, _ time.Duration) (*authResponse, error) {
+			jsonBody, err := bodyCreator()
+			if err != nil {
+				return nil, err
+			}
+			var ar authRequest
+			if err = json.Unmarshal(jsonBody, &ar); err != nil {
+				return nil, err
+			}
+			return responses(ar), nil
+		},
+	}
+	return sc
+}
+
+func TestUnitCustomAuthenticator(t *testing.T) {
+	t.Setenv(disablePlatformDetectionEnv, "true")
+	authenticator := &testCustomAuthenticator{token: "sso-token"}
+	var requests []authRequestData
+	sc := newCustomAuthenticatorTestConn(authenticator, func(ar authRequest) *authResponse {
+		requests = append(requests, ar.Data)
+		return &authResponse{Success: true, Data: authResponseMain{Token: "t", MasterToken: "m", SessionID: 1}}
+	})
+
+	assertNilF(t, authenticateWithConfig(sc))
+	assertEqualF(t, len(requests), 1)
+	assertEqualE(t, requests[0].Authenticator, "OAUTH")
+	assertEqualE(t, requests[0].Token, "sso-token")
+	assertEqualE(t, requests[0].LoginName, sc.cfg.User)
+	assertEqualE(t, requests[0].AccountName, sc.cfg.Account)
+	assertEqualE(t, requests[0].SessionParameters["QUERY_TAG"], "custom")
+	assertEqualE(t, authenticatorName(sc.cfg), "TEST_SSO")
+	token, _, _ := sc.rest.TokenAccessor.GetTokens()
+	assertEqualE(t, token, "t")
+}
+
+func TestUnitCustomAuthenticatorRenew(t *testing.T) {
+	t.Setenv(disablePlatformDetectionEnv, "true")
+	authenticator := &testCustomAuthenticator{token: "expired-token"}
+	sc := newCustomAuthenticatorTestConn(authenticator, func(ar authRequest) *authResponse {
+		if ar.Data.Token == "expired-token" {
+			return &authResponse{Success: false, Code: expiredOAuthAccessTokenCode, Message: "token expired"}
+		}
+		return &authResponse{Success: true, Data: authResponseMain{Token: "t", MasterToken: "m", SessionID: 1}}
+	})
+
+	assertNilF(t, authenticateWithConfig(sc))
+	assertEqualE(t, authenticator.renewals, 1)
+	assertEqualE(t, len(authenticator.handledErrors), 0)
+}
+
+func TestUnitCustomAuthenticatorHandleLoginError(t *testing.T) {
+	t.Setenv(disablePlatformDetectionEnv, "true")
+	passwordExpired := errors.New("password expired")
+	authenticator := &testCustomAuthenticator{token: "sso-token", handleErrorErr: passwordExpired}
+	attempts := 0
+	sc := newCustomAuthenticatorTestConn(authenticator, func(authRequest) *authResponse {
+		attempts++
+		return &authResponse{Success: false, Code: strconv.Itoa(ErrFailedToAuth), Message: "failed"}
+	})
+
+	assertErrIsE(t, authenticateWithConfig(sc), passwordExpired)
+	assertEqualE(t, attempts, 1)
+	assertEqualF(t, len(authenticator.handledErrors), 1)
+	var se *SnowflakeError
+	assertErrorsAsF(t, authenticator.handledErrors[0], &se)
+	assertEqualE(t, se.Number, ErrFailedToAuth)
+
+	// the login is retried while the handler succeeds, up to the limit
+	authenticator.handleErrorErr = nil
+	attempts = 0
+	assertErrorsAsF(t, authenticateWithConfig(sc), &se)
+	assertEqualE(t, attempts, maxCustomAuthenticatorAttempts)
+}
@@ -13,6 +13,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -43,6 +44,8 @@ type oauthClient struct {
 	redirectURITemplate string
 
 	authorizationCodeProviderFactory func() authorizationCodeProvider
+	// deviceCodeIntervalUnit is the unit of the polling interval returned by the device authorization endpoint
+	deviceCodeIntervalUnit time.Duration
 }
 
 func newOauthClient(ctx context.Context, cfg *Config, sc *snowflakeConn) (*oauthClient, error) {
@@ -81,6 +84,7 @@ func newOauthClient(ctx context.Context, cfg *Config, sc *snowflakeConn) (*oauth
 		port:                             port,
 		redirectURITemplate:              redirectURITemplate,
 		authorizationCodeProviderFactory: defaultAuthorizationCodeProviderFactory,
+		deviceCodeIntervalUnit:           time.Second,
 	}, nil
 }
 
@@ -93,11 +97,11 @@ type oauthBrowserResult struct {
 func (oauthClient *oauthClient) authenticateByOAuthAuthorizationCode() (string, error) {
 	accessTokenSpec := oauthClient.accessTokenSpec()
 	if oauthClient.cfg.ClientStoreTemporaryCredential == ConfigBoolTrue {
-		if accessToken := credentialsStorage.getCredential(accessTokenSpec); accessToken != "" {
+		if accessToken := credentialsStorageFor(oauthClient.cfg).getCredential(accessTokenSpec); accessToken != "" {
 			logger.Debugf("Access token retrieved from cache")
 			return accessToken, nil
 		}
-		if refreshToken := credentialsStorage.getCredential(oauthClient.refreshTokenSpec()); refreshToken != "" {
+		if refreshToken := credentialsStorageFor(oauthClient.cfg).getCredential(oauthClient.refreshTokenSpec()); refreshToken != "" {
 			return "", &SnowflakeError{Number: ErrMissingAccessATokenButRefreshTokenPresent}
 		}
 	}
@@ -123,8 +127,8 @@ func (oauthClient *oauthClient) authenticateByOAuthAuthorizationCode() (string,
 	case result := <-resultChan:
 		if oauthClient.cfg.ClientStoreTemporaryCredential == ConfigBoolTrue {
 			logger.Debug("saving oauth access token in cache")
-			credentialsStorage.setCredential(oauthClient.accessTokenSpec(), result.accessToken)
-			credentialsStorage.setCredential(oauthClient.refreshTokenSpec(), result.refreshToken)
+			credentialsStorageFor(oauthClient.cfg).setCredential(oauthClient.accessTokenSpec(), result.accessToken)
+			credentialsStorageFor(oauthClient.cfg).setCredential(oauthClient.refreshTokenSpec(), result.refreshToken)
 		}
 		return result.accessToken, result.err
 	}
@@ -354,7 +358,7 @@ func (provider *browserBasedAuthorizationCodeProvider) createCodeVerifier() stri
 func (oauthClient *oauthClient) authenticateByOAuthClientCredentials() (string, error) {
 	accessTokenSpec := oauthClient.accessTokenSpec()
 	if oauthClient.cfg.ClientStoreTemporaryCredential == ConfigBoolTrue {
-		if accessToken := credentialsStorage.getCredential(accessTokenSpec); accessToken != "" {
+		if accessToken := credentialsStorageFor(oauthClient.cfg).getCredential(accessTokenSpec); accessToken != "" {
 			return accessToken, nil
 		}
 	}
@@ -367,7 +371,7 @@ func (oauthClient *oauthClient) authenticateByOAuthClientCredentials() (string,
 		return "", err
 	}
 	if oauthClient.cfg.ClientStoreTemporaryCredential == ConfigBoolTrue {
-		credentialsStorage.setCredential(accessTokenSpec, token.AccessToken)
+		credentialsStorageFor(oauthClient.cfg).setCredential(accessTokenSpec, token.AccessToken)
 	}
 	return token.AccessToken, nil
 }
@@ -384,13 +388,159 @@ func (oauthClient *oauthClient) buildClientCredentialsConfig() (*clientcredentia
 	}, nil
 }
 
+func (oauthClient *oauthClient) authenticateByOAuthDeviceCode() (string, error) {
+	if oauthClient.cfg.ClientStoreTemporaryCredential == ConfigBoolTrue {
+		if accessToken := credentialsStorageFor(oauthClient.cfg).getCredential(oauthClient.accessTokenSpec()); accessToken != "" {
+			logger.Debugf("Access token retrieved from cache")
+			return accessToken, nil
+		}
+		if refreshToken := credentialsStorageFor(oauthClient.cfg).getCredential(oauthClient.refreshTokenSpec()); refreshToken != "" {
+			return "", &SnowflakeError{Number: ErrMissingAccessATokenButRefreshTokenPresent}
+		}
+	}
+	logger.Debugf("Access token not present in cache, running full device code flow")
+
+	oauth2cfg, err := oauthClient.buildDeviceCodeConfig()
+	if err != nil {
+		return "", err
+	}
+	deviceAuth, err := oauth2cfg.DeviceAuth(oauthClient.ctx)
+	if err != nil {
+		return "", err
+	}
+	callback := oauthClient.cfg.OauthDeviceCodeCallback
+	if callback == nil {
+		callback = printDeviceCode
+	}
+	callback(cmp.Or(deviceAuth.VerificationURIComplete, deviceAuth.VerificationURI), deviceAuth.UserCode)
+
+	tokenResponse, err := oauthClient.pollDeviceAccessToken(deviceAuth)
+	if err != nil {
+		return "", err
+	}
+	logger.Debugf("Received token from %v", oauthClient.tokenURL())
+	if oauthClient.cfg.ClientStoreTemporaryCredential == ConfigBoolTrue {
+		logger.Debug("saving oauth access token in cache")
+		credentialsStorageFor(oauthClient.cfg).setCredential(oauthClient.accessTokenSpec(), tokenResponse.AccessToken)
+		credentialsStorageFor(oauthClient.cfg).setCredential(oauthClient.refreshTokenSpec(), tokenResponse.RefreshToken)
+	}
+	return tokenResponse.AccessToken, nil
+}
+
+func printDeviceCode(verificationURI string, userCode string) {
+	fmt.Fprintf(os.Stderr, "To authenticate, visit %v and enter the code: %v\n", verificationURI, userCode)
+}
+
+func (oauthClient *oauthClient) buildDeviceCodeConfig() (*oauth2.Config, error) {
+	if oauthClient.cfg.OauthDeviceAuthorizationURL == "" || oauthClient.cfg.OauthTokenRequestURL == "" {
+		return nil, errors.New("device code flow requires deviceAuthorizationURL and tokenRequestURL")
+	}
+	if oauthClient.cfg.OauthClientID == "" {
+		return nil, errors.New("device code flow requires clientID")
+	}
+	oauthClient.logIfHTTPInUse(oauthClient.cfg.OauthDeviceAuthorizationURL)
+	oauthClient.logIfHTTPInUse(oauthClient.tokenURL())
+	return &oauth2.Config{
+		ClientID:     oauthClient.cfg.OauthClientID,
+		ClientSecret: oauthClient.cfg.OauthClientSecret,
+		Scopes:       oauthClient.buildScopes(),
+		Endpoint: oauth2.Endpoint{
+			DeviceAuthURL: oauthClient.cfg.OauthDeviceAuthorizationURL,
+			TokenURL:      oauthClient.tokenURL(),
+		},
+	}, nil
+}
+
+type deviceAccessTokenErrorBody struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// pollDeviceAccessToken polls the token endpoint until the user authorizes the device, as described in RFC 8628.
+func (oauthClient *oauthClient) pollDeviceAccessToken(deviceAuth *oauth2.DeviceAuthResponse) (*tokenExchangeResponseBody, error) {
+	ctx := oauthClient.ctx
+	if !deviceAuth.Expiry.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deviceAuth.Expiry)
+		defer cancel()
+	}
+	body := url.Values{}
+	body.Add("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
+	body.Add("device_code", deviceAuth.DeviceCode)
+	body.Add("client_id", oauthClient.cfg.OauthClientID)
+
+	// the default interval is 5 seconds, it must be increased by 5 seconds on each slow_down error
+	interval := cmp.Or(deviceAuth.Interval, 5)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("device code flow was not completed. %w", ctx.Err())
+		case <-time.After(time.Duration(interval) * oauthClient.deviceCodeIntervalUnit):
+		}
+		tokenResponse, errorCode, err := oauthClient.requestDeviceAccessToken(ctx, body)
+		if err != nil {
+			return nil, err
+		}
+		switch errorCode {
+		case "":
+			return tokenResponse, nil
+		case "authorization_pending":
+			logger.Debug("device authorization pending")
+		case "slow_down":
+			interval += 5
+			logger.Debugf("slowing down device code polling, new interval: %v", interval)
+		}
+	}
+}
+
+// requestDeviceAccessToken returns the error code if the token endpoint asks to poll again.
+func (oauthClient *oauthClient) requestDeviceAccessToken(ctx context.Context, body url.Values) (*tokenExchangeResponseBody, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", oauthClient.tokenURL(), strings.NewReader(body.Encode()))
+	if err != nil {
+		return nil, "", err
+	}
+	if oauthClient.cfg.OauthClientSecret != "" {
+		req.SetBasicAuth(oauthClient.cfg.OauthClientID, oauthClient.cfg.OauthClientSecret)
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+	resp, err := oauthClient.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warnf("error while closing response body for %v. %v", req.URL, err)
+		}
+	}()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errorBody deviceAccessTokenErrorBody
+		if err = json.Unmarshal(respBody, &errorBody); err != nil {
+			return nil, "", fmt.Errorf("unexpected response from token endpoint, status code: %v, body: %v", resp.StatusCode, string(respBody))
+		}
+		if errorBody.Error == "authorization_pending" || errorBody.Error == "slow_down" {
+			return nil, errorBody.Error, nil
+		}
+		return nil, "", fmt.Errorf("error while getting access token from oauth: %v. Details: %v", errorBody.Error, errorBody.ErrorDescription)
+	}
+	var tokenResponse tokenExchangeResponseBody
+	if err = json.Unmarshal(respBody, &tokenResponse); err != nil {
+		return nil, "", err
+	}
+	return &tokenResponse, "", nil
+}
+
 func (oauthClient *oauthClient) refreshToken() error {
 	if oauthClient.cfg.ClientStoreTemporaryCredential != ConfigBoolTrue {
 		logger.Debug("credentials storage is disabled, cannot use refresh tokens")
 		return nil
 	}
 	refreshTokenSpec := newOAuthRefreshTokenSpec(oauthClient.cfg.OauthTokenRequestURL, oauthClient.cfg.User)
-	refreshToken := credentialsStorage.getCredential(refreshTokenSpec)
+	refreshToken := credentialsStorageFor(oauthClient.cfg).getCredential(refreshTokenSpec)
 	if refreshToken == "" {
 		logger.Debug("no refresh token in cache, full flow must be run")
 		return nil
@@ -419,7 +569,7 @@ func (oauthClient *oauthClient) refreshToken() error {
 		if err != nil {
 			return err
 		}
-		credentialsStorage.deleteCredential(refreshTokenSpec)
+		credentialsStorageFor(oauthClient.cfg).deleteCredential(refreshTokenSpec)
 		return errors.New(string(respBody))
 	}
 	var tokenResponse tokenExchangeResponseBody
@@ -427,9 +577,9 @@ func (oauthClient *oauthClient) refreshToken() error {
 		return err
 	}
 	accessTokenSpec := oauthClient.accessTokenSpec()
-	credentialsStorage.setCredential(accessTokenSpec, tokenResponse.AccessToken)
+	credentialsStorageFor(oauthClient.cfg).setCredential(accessTokenSpec, tokenResponse.AccessToken)
 	if tokenResponse.RefreshToken != "" {
-		credentialsStorage.setCredential(refreshTokenSpec, tokenResponse.RefreshToken)
+		credentialsStorageFor(oauthClient.cfg).setCredential(refreshTokenSpec, tokenResponse.RefreshToken)
 	}
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	sfconfig "github.com/snowflakedb/gosnowflake/v2/internal/config"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -634,6 +635,128 @@ func TestClientCredentialsFlow(t *testing.T) {
 	})
 }
 
+type fakeDeviceCodeIdP struct {
+	t             *testing.T
+	tokenErrors   []string
+	tokenRequests []time.Time
+}
+
+func (idp *fakeDeviceCodeIdP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	assertNilF(idp.t, r.ParseForm())
+	assertEqualE(idp.t, r.Form.Get("client_id"), "testClientId")
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/oauth/device":
+		assertEqualE(idp.t, r.Form.Get("scope"), "session:role:ANALYST")
+		_, err := w.Write([]byte(`{"device_code": "device-code-123", "user_code": "ABCD-EFGH", "verification_uri": "https://idp.example.com/device", "expires_in": 60, "interval": 1}`))
+		assertNilE(idp.t, err)
+	case "/oauth/token":
+		idp.tokenRequests = append(idp.tokenRequests, time.Now())
+		assertEqualE(idp.t, r.Form.Get("grant_type"), "urn:ietf:params:oauth:grant-type:device_code")
+		assertEqualE(idp.t, r.Form.Get("device_code"), "device-code-123")
+		if len(idp.tokenErrors) > 0 {
+			tokenError := idp.tokenErrors[0]
+			idp.tokenErrors = idp.tokenErrors[1:]
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write([]byte(`{"error": "` + tokenError + `", "error_description": "some error desc"}`))
+			assertNilE(idp.t, err)
+			return
+		}
+		_, err := w.Write([]byte(`{"access_token": "access-token-123", "refresh_token": "refresh-token-123", "token_type": "Bearer"}`))
+		assertNilE(idp.t, err)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestUnitOAuthDeviceCode(t *testing.T) {
+	skipOnMac(t, "keychain requires password")
+	idp := &fakeDeviceCodeIdP{t: t}
+	server := httptest.NewServer(idp)
+	defer server.Close()
+
+	var verificationURI, userCode string
+	cfg := &Config{
+		User:                        "testUser",
+		Role:                        "ANALYST",
+		OauthClientID:               "testClientId",
+		OauthDeviceAuthorizationURL: server.URL + "/oauth/device",
+		OauthTokenRequestURL:        server.URL + "/oauth/token",
+		OauthDeviceCodeCallback: func(uri string, code string) {
+			verificationURI, userCode = uri, code
+		},
+		Transporter: createTestNoRevocationTransport(),
+	}
+	newClient := func() *oauthClient {
+		client, err := newOauthClient(context.Background(), cfg, &snowflakeConn{})
+		assertNilF(t, err)
+		client.deviceCodeIntervalUnit = time.Millisecond
+		return client
+	}
+
+	t.Run("Success after polling", func(t *testing.T) {
+		idp.tokenErrors = []string{"authorization_pending", "slow_down", "authorization_pending"}
+		idp.tokenRequests = nil
+		token, err := newClient().authenticateByOAuthDeviceCode()
+		assertNilF(t, err)
+		assertEqualE(t, token, "access-token-123")
+		assertEqualE(t, verificationURI, "https://idp.example.com/device")
+		assertEqualE(t, userCode, "ABCD-EFGH")
+		assertEqualE(t, len(idp.tokenRequests), 4)
+		// the interval is increased by 5 units after slow_down
+		assertTrueE(t, idp.tokenRequests[2].Sub(idp.tokenRequests[1]) >= 6*time.Millisecond)
+	})
+
+	t.Run("Access denied", func(t *testing.T) {
+		idp.tokenErrors = []string{"authorization_pending", "access_denied"}
+		_, err := newClient().authenticateByOAuthDeviceCode()
+		assertNotNilF(t, err)
+		assertEqualE(t, err.Error(), "error while getting access token from oauth: access_denied. Details: some error desc")
+	})
+
+	t.Run("Store tokens in cache", func(t *testing.T) {
+		skipOnMissingHome(t)
+		accessTokenSpec := newOAuthAccessTokenSpec(cfg.OauthTokenRequestURL, cfg.User)
+		refreshTokenSpec := newOAuthRefreshTokenSpec(cfg.OauthTokenRequestURL, cfg.User)
+		credentialsStorage.deleteCredential(accessTokenSpec)
+		credentialsStorage.deleteCredential(refreshTokenSpec)
+		defer credentialsStorage.deleteCredential(accessTokenSpec)
+		defer credentialsStorage.deleteCredential(refreshTokenSpec)
+		cfg.ClientStoreTemporaryCredential = ConfigBoolTrue
+		defer func() {
+			cfg.ClientStoreTemporaryCredential = sfconfig.BoolNotSet
+		}()
+
+		idp.tokenErrors = nil
+		idp.tokenRequests = nil
+		_, err := newClient().authenticateByOAuthDeviceCode()
+		assertNilF(t, err)
+		assertEqualE(t, credentialsStorage.getCredential(accessTokenSpec), "access-token-123")
+		assertEqualE(t, credentialsStorage.getCredential(refreshTokenSpec), "refresh-token-123")
+
+		token, err := newClient().authenticateByOAuthDeviceCode()
+		assertNilF(t, err)
+		assertEqualE(t, token, "access-token-123")
+		assertEqualE(t, len(idp.tokenRequests), 1)
+
+		credentialsStorage.deleteCredential(accessTokenSpec)
+		_, err = newClient().authenticateByOAuthDeviceCode()
+		var se *SnowflakeError
+		assertErrorsAsF(t, err, &se)
+		assertEqualE(t, se.Number, ErrMissingAccessATokenButRefreshTokenPresent)
+	})
+
+	t.Run("Missing device authorization URL", func(t *testing.T) {
+		deviceAuthorizationURL := cfg.OauthDeviceAuthorizationURL
+		cfg.OauthDeviceAuthorizationURL = ""
+		defer func() {
+			cfg.OauthDeviceAuthorizationURL = deviceAuthorizationURL
+		}()
+		_, err := newClient().authenticateByOAuthDeviceCode()
+		assertEqualE(t, err.Error(), "device code flow requires deviceAuthorizationURL and tokenRequestURL")
+	})
+}
+
 func TestEligibleForDefaultClientCredentials(t *testing.T) {
 	tests := []struct {
 		name        string
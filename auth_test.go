@@ -3,6 +3,9 @@ package gosnowflake
 import (
 	"cmp"
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"database/sql"
@@ -10,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	sfconfig "github.com/snowflakedb/gosnowflake/v2/internal/config"
+	"io"
 	"net/http"
 	"net/url"
 	"os"
@@ -670,6 +674,43 @@ func TestUnitAuthenticateJWT(t *testing.T) {
 	}
 }
 
+// opaqueSigner hides the private key, like signers of keys kept in an HSM or a KMS
+type opaqueSigner struct {
+	key crypto.Signer
+}
+
+func (s *opaqueSigner) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+func (s *opaqueSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.key.Sign(rand, digest, opts)
+}
+
+func TestPrepareJWTTokenWithSigner(t *testing.T) {
+	localTestKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assertNilF(t, err)
+	cfg := &Config{
+		Account:          "a",
+		User:             "u",
+		JWTExpireTimeout: time.Duration(sfconfig.DefaultJWTTimeout),
+		PrivateKeySigner: &opaqueSigner{key: localTestKey},
+	}
+	tokenString, err := prepareJWTToken(cfg)
+	assertNilF(t, err)
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		return localTestKey.Public(), nil
+	}, jwt.WithValidMethods([]string{"RS256"}))
+	assertNilF(t, err)
+	assertTrueE(t, token.Valid)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assertNilF(t, err)
+	cfg.PrivateKeySigner = &opaqueSigner{key: ecdsaKey}
+	_, err = prepareJWTToken(cfg)
+	assertNotNilE(t, err, "only RSA keys are supported by Snowflake")
+}
+
 func TestUnitAuthenticateUsernamePasswordMfa(t *testing.T) {
 	var err error
 	sr := &snowflakeRestful{
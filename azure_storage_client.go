@@ -5,6 +5,7 @@ import (
 	"cmp"
 	"context"
 	"crypto/md5"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,9 +18,11 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 )
 
@@ -33,6 +36,12 @@ type azureLocation struct {
 	path          string
 }
 
+// implemented by the block blob client, used by resumable uploads
+type azureBlockAPI interface {
+	StageBlock(ctx context.Context, base64BlockID string, body io.ReadSeekCloser, options *blockblob.StageBlockOptions) (blockblob.StageBlockResponse, error)
+	CommitBlockList(ctx context.Context, base64BlockIDs []string, options *blockblob.CommitBlockListOptions) (blockblob.CommitBlockListResponse, error)
+}
+
 type azureAPI interface {
 	UploadStream(ctx context.Context, body io.Reader, o *azblob.UploadStreamOptions) (azblob.UploadStreamResponse, error)
 	UploadFile(ctx context.Context, file *os.File, o *azblob.UploadFileOptions) (azblob.UploadFileResponse, error)
@@ -253,9 +262,16 @@ func (util *snowflakeAzureClient) uploadFile(
 		if meta.options.putAzureCallback != nil {
 			blobOptions.Progress = meta.options.putAzureCallback.call
 		}
-		_, err = withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (azblob.UploadFileResponse, error) {
-			return blobClient.UploadFile(ctx, f, blobOptions)
-		})
+		if blockClient, ok := blobClient.(azureBlockAPI); ok && meta.resumable != nil {
+			err = util.uploadFileInBlocks(ctx, blockClient, f, meta, maxConcurrency, multiPartThreshold, &blockblob.CommitBlockListOptions{
+				HTTPHeaders: blobOptions.HTTPHeaders,
+				Metadata:    azureMeta,
+			})
+		} else {
+			_, err = withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (azblob.UploadFileResponse, error) {
+				return blobClient.UploadFile(ctx, f, blobOptions)
+			})
+		}
 	}
 	if err != nil {
 		var se *azcore.ResponseError
@@ -277,6 +293,46 @@ func (util *snowflakeAzureClient) uploadFile(
 	return nil
 }
 
+// uploadFileInBlocks stages the blocks of f recorded in the checkpoint of meta and commits them,
+// so an interrupted upload continues with the blocks not staged yet.
+func (util *snowflakeAzureClient) uploadFileInBlocks(
+	ctx context.Context,
+	blockClient azureBlockAPI,
+	f *os.File,
+	meta *fileMetadata,
+	maxConcurrency int,
+	multiPartThreshold int64,
+	commitOptions *blockblob.CommitBlockListOptions) error {
+	rt := meta.resumable
+	partSize := rt.partSize(meta.uploadSize, multiPartThreshold)
+	if err := rt.update(func(cp *transferCheckpoint) { cp.PartSize = partSize }); err != nil {
+		return err
+	}
+	parts, err := rt.uploadParts(ctx, meta.uploadSize, partSize, maxConcurrency, func(ctx context.Context, number int, offset, length int64) (string, error) {
+		// the IDs of all blocks of a blob must have the same length
+		blockID := base64.StdEncoding.EncodeToString(fmt.Appendf(nil, "%010d", number))
+		_, err := withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (blockblob.StageBlockResponse, error) {
+			return blockClient.StageBlock(ctx, blockID, streaming.NopCloser(io.NewSectionReader(f, offset, length)), nil)
+		})
+		return blockID, err
+	})
+	if err != nil {
+		return err
+	}
+	blockIDs := make([]string, len(parts))
+	for i, part := range parts {
+		blockIDs[i] = part.ID
+	}
+	_, err = withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (blockblob.CommitBlockListResponse, error) {
+		return blockClient.CommitBlockList(ctx, blockIDs, commitOptions)
+	})
+	// uncommitted blocks are discarded by Azure after a week
+	if bloberror.HasCode(err, bloberror.InvalidBlockList) {
+		rt.resetParts()
+	}
+	return err
+}
+
 // cloudUtil implementation
 func (util *snowflakeAzureClient) nativeDownloadFile(
 	ctx context.Context,
@@ -325,6 +381,34 @@ func (util *snowflakeAzureClient) nativeDownloadFile(
 		if err != nil {
 			return err
 		}
+	} else if meta.resumable != nil {
+		err = meta.resumable.writeFile(fullDstFileName, meta.srcFileSize, func(w io.Writer, offset int64) error {
+			resp, err := withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (azblob.DownloadStreamResponse, error) {
+				return blobClient.DownloadStream(ctx, &azblob.DownloadStreamOptions{
+					Range: azblob.HTTPRange{Offset: offset},
+				})
+			})
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err = resp.Body.Close(); err != nil {
+					logger.Warnf("failed to close the Azure reader: %v", err)
+				}
+			}()
+			_, err = io.Copy(w, resp.Body)
+			return err
+		})
+		if err != nil {
+			var se *azcore.ResponseError
+			if errors.As(err, &se) && se.StatusCode == 403 && util.detectAzureTokenExpireError(se.RawResponse) {
+				meta.resStatus = renewToken
+			} else {
+				meta.resStatus = needRetry
+				meta.lastError = err
+			}
+			return err
+		}
 	} else {
 		f, err := os.OpenFile(fullDstFileName, os.O_CREATE|os.O_WRONLY, readWriteFileMode)
 		if err != nil {
@@ -0,0 +1,302 @@
+package gosnowflake
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/snowflakedb/gosnowflake/v2/internal/errors"
+	"github.com/snowflakedb/gosnowflake/v2/internal/types"
+)
+
+// number of compressed row source files waiting for the upload to the bind stage
+const rowSourceUploadQueueSize = 4
+
+// RowSource provides the rows of a streaming array bind created with BindRowSource.
+type RowSource interface {
+	// Next returns the next row. It returns io.EOF when there are no more rows.
+	Next() ([]driver.Value, error)
+}
+
+type channelRowSource struct {
+	ch <-chan []driver.Value
+}
+
+// RowSourceFromChannel returns a RowSource reading the rows sent to ch until ch is closed.
+// The producer should stop sending when the context of the query is done, since the rows are not read after a failure.
+func RowSourceFromChannel(ch <-chan []driver.Value) RowSource {
+	return &channelRowSource{ch: ch}
+}
+
+func (rs *channelRowSource) Next() ([]driver.Value, error) {
+	row, ok := <-rs.ch
+	if !ok {
+		return nil, io.EOF
+	}
+	return row, nil
+}
+
+type seqRowSource struct {
+	next func() ([]driver.Value, error, bool)
+	stop func()
+}
+
+// RowSourceFromSeq returns a RowSource reading the rows yielded by seq. Reading stops at the first non-nil error.
+func RowSourceFromSeq(seq iter.Seq2[[]driver.Value, error]) RowSource {
+	next, stop := iter.Pull2(seq)
+	return &seqRowSource{next: next, stop: stop}
+}
+
+func (rs *seqRowSource) Next() ([]driver.Value, error) {
+	row, err, ok := rs.next()
+	if !ok {
+		return nil, io.EOF
+	}
+	return row, err
+}
+
+func (rs *seqRowSource) Close() error {
+	rs.stop()
+	return nil
+}
+
+type rowSourceBinding struct {
+	source RowSource
+}
+
+// BindRowSource returns a bind value that streams the rows of source to the bind stage, e.g.
+//
+//	db.ExecContext(ctx, "INSERT INTO t VALUES (?, ?)", BindRowSource(source))
+//
+// It must be the only argument of the query. Unlike array binding with Array, the rows are encoded and compressed
+// as they are read and the files are uploaded while later rows are still being read, so the memory usage does not
+// depend on the number of rows. Values are converted like regular bind values, time.Time is bound as TIMESTAMP_NTZ
+// unless it is wrapped in TypedNullTime.
+func BindRowSource(source RowSource) any {
+	return &rowSourceBinding{source: source}
+}
+
+func supportedRowSourceBind(nv *driver.NamedValue) bool {
+	_, ok := nv.Value.(*rowSourceBinding)
+	return ok
+}
+
+// getRowSourceBinding returns the row source binding if bindings contain one.
+func (sc *snowflakeConn) getRowSourceBinding(bindings []driver.NamedValue) (*rowSourceBinding, error) {
+	for _, binding := range bindings {
+		if rsb, ok := binding.Value.(*rowSourceBinding); ok {
+			if len(bindings) != 1 {
+				return nil, exceptionTelemetry(&SnowflakeError{
+					Number:  ErrBindSerialization,
+					Message: "a row source must be the only bind value of the query",
+				}, sc)
+			}
+			return rsb, nil
+		}
+	}
+	return nil, nil
+}
+
+// uploadRowSource encodes the rows of source into compressed CSV files and uploads them to the bind stage.
+// The rows are encoded while the previous files are uploaded, and at most rowSourceUploadQueueSize encoded files
+// wait for the upload. The files are uploaded one by one, since the PUT commands run on the connection of the query,
+// which must not be used concurrently.
+func (bu *bindUploader) uploadRowSource(source RowSource) error {
+	if closer, ok := source.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if err := bu.createStageIfNeeded(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(bu.ctx)
+	defer cancel()
+	bu.ctx = ctx
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	type stagedFile struct {
+		name int
+		data *bytes.Buffer
+	}
+	files := make(chan stagedFile, rowSourceUploadQueueSize)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for file := range files {
+			if ctx.Err() != nil {
+				continue // drain the files after a failure
+			}
+			if _, err := bu.uploadStreamInternal(file.data, file.name, false); err != nil {
+				fail(err)
+			}
+		}
+	}()
+
+	err := bu.encodeRowSource(ctx, source, inputStreamBufferSize, func(data *bytes.Buffer) bool {
+		bu.fileCount++
+		select {
+		case files <- stagedFile{name: bu.fileCount, data: data}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+	close(files)
+	if err != nil {
+		fail(err)
+	}
+	wg.Wait()
+	if firstErr == nil && ctx.Err() == nil && bu.fileCount == 0 {
+		firstErr = exceptionTelemetry(&SnowflakeError{
+			Number:  ErrBindSerialization,
+			Message: "no rows returned by the row source",
+		}, bu.sc)
+	}
+	if firstErr == nil {
+		firstErr = context.Cause(ctx)
+	}
+	return firstErr
+}
+
+// encodeRowSource writes the rows as gzip compressed CSV records and passes every file of roughly
+// fileSize compressed bytes to upload. It stops when upload returns false.
+func (bu *bindUploader) encodeRowSource(ctx context.Context, source RowSource, fileSize int, upload func(*bytes.Buffer) bool) error {
+	var (
+		buf        *bytes.Buffer
+		gz         *gzip.Writer
+		numColumns int
+	)
+	flush := func() (bool, error) {
+		if err := gz.Close(); err != nil {
+			return false, err
+		}
+		ok := upload(buf)
+		buf = nil
+		return ok, nil
+	}
+	for rowIdx := 1; ; rowIdx++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+		row, err := source.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if rowIdx == 1 {
+			numColumns = len(row)
+		} else if len(row) != numColumns {
+			return exceptionTelemetry(&SnowflakeError{
+				Number:      ErrBindSerialization,
+				Message:     errors.ErrMsgBindRowMismatch,
+				MessageArgs: []any{rowIdx, len(row), numColumns},
+			}, bu.sc)
+		}
+		record, err := bu.rowToCSVRecord(row)
+		if err != nil {
+			return err
+		}
+		if buf == nil {
+			buf = new(bytes.Buffer)
+			gz = gzip.NewWriter(buf)
+		}
+		if _, err = gz.Write(record); err != nil {
+			return err
+		}
+		if buf.Len() >= fileSize {
+			if ok, err := flush(); err != nil || !ok {
+				return err
+			}
+		}
+	}
+	if buf != nil {
+		if _, err := flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bu *bindUploader) rowToCSVRecord(row []driver.Value) ([]byte, error) {
+	values := make([]any, len(row))
+	for i, v := range row {
+		value, err := rowSourceValueToString(v)
+		if err != nil {
+			return nil, exceptionTelemetry(&SnowflakeError{
+				Number:  ErrBindSerialization,
+				Message: fmt.Sprintf("failed to convert value of column %v: %v", i+1, err),
+			}, bu.sc)
+		}
+		if value == nil {
+			values[i] = value
+		} else {
+			values[i] = *value
+		}
+	}
+	return bu.createCSVRecord(values), nil
+}
+
+// rowSourceValueToString converts a row source value to its CSV representation, nil for NULL.
+func rowSourceValueToString(v driver.Value) (*string, error) {
+	if tnt, ok := v.(TypedNullTime); ok {
+		if !tnt.Time.Valid {
+			return nil, nil
+		}
+		s, err := timeToStreamString(tnt.Time.Time, convertTzTypeToSnowflakeType(tnt.TzType))
+		return &s, err
+	}
+	v, err := driver.DefaultParameterConverter.ConvertValue(v)
+	if err != nil {
+		return nil, err
+	}
+	var s string
+	switch x := v.(type) {
+	case nil:
+		return nil, nil
+	case int64:
+		s = strconv.FormatInt(x, 10)
+	case float64:
+		s = fmt.Sprintf("%g", x)
+	case bool:
+		s = strconv.FormatBool(x)
+	case string:
+		s = x
+	case []byte:
+		s = hex.EncodeToString(x)
+	case time.Time:
+		if s, err = timeToStreamString(x, types.TimestampNtzType); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %T", v)
+	}
+	return &s, nil
+}
+
+func timeToStreamString(x time.Time, t types.SnowflakeType) (string, error) {
+	switch t {
+	case types.DateType:
+		return x.Format("2006-01-02"), nil
+	case types.TimeType:
+		return fmt.Sprintf("%02d:%02d:%02d.%09d", x.Hour(), x.Minute(), x.Second(), x.Nanosecond()), nil
+	}
+	return getTimestampBindValue(x, true, t)
+}
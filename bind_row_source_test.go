@@ -0,0 +1,233 @@
+package gosnowflake
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func decompressRowSourceFile(t *testing.T, buf *bytes.Buffer) string {
+	gz, err := gzip.NewReader(buf)
+	assertNilF(t, err)
+	data, err := io.ReadAll(gz)
+	assertNilF(t, err)
+	return string(data)
+}
+
+func TestRowSourceValueToString(t *testing.T) {
+	someTime := time.Date(2024, time.March, 18, 12, 34, 56, 123456789, time.UTC)
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	assertNilF(t, err)
+	testcases := []struct {
+		value    driver.Value
+		expected *string
+	}{
+		{value: nil, expected: nil},
+		{value: 42, expected: &[]string{"42"}[0]},
+		{value: int64(-1), expected: &[]string{"-1"}[0]},
+		{value: 1.5, expected: &[]string{"1.5"}[0]},
+		{value: true, expected: &[]string{"true"}[0]},
+		{value: "a,b", expected: &[]string{"a,b"}[0]},
+		{value: []byte{0x01, 0xab}, expected: &[]string{"01ab"}[0]},
+		{value: sql.NullString{}, expected: nil},
+		{value: sql.NullInt64{Int64: 7, Valid: true}, expected: &[]string{"7"}[0]},
+		{value: someTime, expected: &[]string{"2024-03-18 12:34:56.123456789"}[0]},
+		{value: TypedNullTime{Time: sql.NullTime{Time: someTime, Valid: true}, TzType: DateType}, expected: &[]string{"2024-03-18"}[0]},
+		{value: TypedNullTime{Time: sql.NullTime{Time: someTime, Valid: true}, TzType: TimeType}, expected: &[]string{"12:34:56.123456789"}[0]},
+		{value: TypedNullTime{Time: sql.NullTime{Time: someTime.In(warsaw), Valid: true}, TzType: TimestampLTZType}, expected: &[]string{"2024-03-18 13:34:56.123456789 +01:00"}[0]},
+		{value: TypedNullTime{Time: sql.NullTime{Time: someTime.In(warsaw).AddDate(0, 1, 0), Valid: true}, TzType: TimestampTZType}, expected: &[]string{"2024-04-18 13:34:56.123456789 +02:00"}[0]},
+		{value: TypedNullTime{TzType: TimestampLTZType}, expected: nil},
+	}
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%v", tc.value), func(t *testing.T) {
+			s, err := rowSourceValueToString(tc.value)
+			assertNilF(t, err)
+			if tc.expected == nil {
+				assertTrueE(t, s == nil, "should be NULL")
+			} else {
+				assertNotNilF(t, s)
+				assertEqualE(t, *s, *tc.expected)
+			}
+		})
+	}
+	_, err = rowSourceValueToString(struct{}{})
+	assertNotNilE(t, err)
+}
+
+func TestEncodeRowSource(t *testing.T) {
+	bu := &bindUploader{sc: &snowflakeConn{cfg: &Config{}}, ctx: context.Background()}
+	rows := make(chan []driver.Value)
+	go func() {
+		defer close(rows)
+		for i := range 3 {
+			rows <- []driver.Value{i, fmt.Sprintf("row %v", i), nil}
+		}
+	}()
+	var files []*bytes.Buffer
+	err := bu.encodeRowSource(context.Background(), RowSourceFromChannel(rows), inputStreamBufferSize, func(data *bytes.Buffer) bool {
+		files = append(files, data)
+		return true
+	})
+	assertNilF(t, err)
+	assertEqualF(t, len(files), 1)
+	assertEqualE(t, decompressRowSourceFile(t, files[0]), "0,row 0,\n1,row 1,\n2,row 2,\n")
+}
+
+func TestEncodeRowSourceSplitsFiles(t *testing.T) {
+	bu := &bindUploader{sc: &snowflakeConn{cfg: &Config{}}, ctx: context.Background()}
+	numRows := 30000
+	source := RowSourceFromSeq(func(yield func([]driver.Value, error) bool) {
+		for i := range numRows {
+			if !yield([]driver.Value{i, fmt.Sprintf("test%v", i)}, nil) {
+				return
+			}
+		}
+	})
+	defer source.(io.Closer).Close()
+	var files []*bytes.Buffer
+	err := bu.encodeRowSource(context.Background(), source, 16*1024, func(data *bytes.Buffer) bool {
+		files = append(files, data)
+		return true
+	})
+	assertNilF(t, err)
+	assertTrueF(t, len(files) > 1, "rows should be split into multiple files")
+	lines := 0
+	for _, file := range files {
+		lines += strings.Count(decompressRowSourceFile(t, file), "\n")
+	}
+	assertEqualE(t, lines, numRows)
+}
+
+func TestEncodeRowSourceErrors(t *testing.T) {
+	bu := &bindUploader{sc: &snowflakeConn{cfg: &Config{}}, ctx: context.Background()}
+	upload := func(*bytes.Buffer) bool { return true }
+
+	t.Run("row mismatch", func(t *testing.T) {
+		source := RowSourceFromSeq(func(yield func([]driver.Value, error) bool) {
+			_ = yield([]driver.Value{1, 2}, nil) && yield([]driver.Value{3}, nil)
+		})
+		defer source.(io.Closer).Close()
+		err := bu.encodeRowSource(context.Background(), source, inputStreamBufferSize, upload)
+		var se *SnowflakeError
+		assertErrorsAsF(t, err, &se)
+		assertEqualE(t, se.Number, ErrBindSerialization)
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		sourceErr := errors.New("source failed")
+		source := RowSourceFromSeq(func(yield func([]driver.Value, error) bool) {
+			_ = yield([]driver.Value{1}, nil) && yield(nil, sourceErr)
+		})
+		defer source.(io.Closer).Close()
+		assertErrIsE(t, bu.encodeRowSource(context.Background(), source, inputStreamBufferSize, upload), sourceErr)
+	})
+}
+
+func TestRowSourceMustBeOnlyBinding(t *testing.T) {
+	sc := &snowflakeConn{cfg: &Config{}}
+	source := RowSourceFromChannel(make(chan []driver.Value))
+	_, err := sc.getRowSourceBinding([]driver.NamedValue{
+		{Ordinal: 1, Value: BindRowSource(source)},
+		{Ordinal: 2, Value: int64(1)},
+	})
+	var se *SnowflakeError
+	assertErrorsAsF(t, err, &se)
+	assertEqualE(t, se.Number, ErrBindSerialization)
+
+	rsb, err := sc.getRowSourceBinding([]driver.NamedValue{{Ordinal: 1, Value: BindRowSource(source)}})
+	assertNilF(t, err)
+	assertEqualE(t, rsb.source, source)
+}
+
+type rowSourceTestConnector struct {
+	sc *snowflakeConn
+}
+
+func (c *rowSourceTestConnector) Connect(context.Context) (driver.Conn, error) {
+	return rowSourceTestConn{c.sc}, nil
+}
+
+func (c *rowSourceTestConnector) Driver() driver.Driver {
+	return SnowflakeDriver{}
+}
+
+// rowSourceTestConn does not close the session of the mocked connection.
+type rowSourceTestConn struct {
+	*snowflakeConn
+}
+
+func (rowSourceTestConn) Close() error {
+	return nil
+}
+
+func TestPreparedStatementRejectsRowSource(t *testing.T) {
+	postQueryMock := func(_ context.Context, _ *snowflakeRestful, _ *url.Values, _ map[string]string, body []byte, _ time.Duration, _ UUID, _ *Config) (*execResponse, error) {
+		var req execRequest
+		assertNilF(t, json.Unmarshal(body, &req))
+		assertTrueF(t, req.DescribeOnly, "only the describe request should be sent")
+		return &execResponse{Data: execResponseData{QueryID: "describe-query-id", NumberOfBinds: 2}, Code: "0", Success: true}, nil
+	}
+	sc := &snowflakeConn{cfg: &Config{}, rest: &snowflakeRestful{FuncPostQuery: postQueryMock, TokenAccessor: getSimpleTokenAccessor()}}
+	db := sql.OpenDB(&rowSourceTestConnector{sc: sc})
+	defer db.Close()
+
+	stmt, err := db.Prepare("INSERT INTO t VALUES (?, ?)")
+	assertNilF(t, err)
+	defer stmt.Close()
+	// the prepared statement reports two bind parameters, so database/sql rejects the single row source argument
+	_, err = stmt.Exec(BindRowSource(RowSourceFromChannel(make(chan []driver.Value))))
+	assertNotNilF(t, err)
+	assertStringContainsE(t, err.Error(), "expected 2 arguments, got 1")
+}
+
+func TestBindRowSource(t *testing.T) {
+	runDBTest(t, func(dbt *DBTest) {
+		dbt.mustExec(fmt.Sprintf("create or replace table %v (c1 integer, c2 string, c3 timestamp_ntz, c4 date, c5 binary)", dbname))
+		defer dbt.mustExec("drop table if exists " + dbname)
+		someTime := time.Date(2024, time.March, 18, 12, 34, 56, 123456789, time.UTC)
+		numRows := 200000
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		rows := make(chan []driver.Value)
+		go func() {
+			defer close(rows)
+			for i := range numRows {
+				row := []driver.Value{i, fmt.Sprintf("test%v", i), someTime, TypedNullTime{Time: sql.NullTime{Time: someTime, Valid: true}, TzType: DateType}, []byte{0x01, 0x02}}
+				if i%2 == 1 {
+					row[1] = nil
+				}
+				select {
+				case rows <- row:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		res := dbt.mustExecContext(ctx, fmt.Sprintf("insert into %v values (?, ?, ?, ?, ?)", dbname), BindRowSource(RowSourceFromChannel(rows)))
+		affected, err := res.RowsAffected()
+		assertNilF(t, err)
+		assertEqualE(t, affected, int64(numRows))
+
+		var nulls, total int
+		var maxTime time.Time
+		var date time.Time
+		result := dbt.mustQuery(fmt.Sprintf("select count_if(c2 is null), count(*), max(c3), max(c4) from %v", dbname))
+		defer result.Close()
+		assertTrueF(t, result.Next())
+		assertNilF(t, result.Scan(&nulls, &total, &maxTime, &date))
+		assertEqualE(t, nulls, numRows/2)
+		assertEqualE(t, total, numRows)
+		assertTrueE(t, maxTime.Equal(someTime))
+		assertEqualE(t, date.Format("2006-01-02"), "2024-03-18")
+	})
+}
@@ -208,6 +208,26 @@ func (sc *snowflakeConn) processBindings(
 	describeOnly bool,
 	requestID UUID,
 	req *execRequest) error {
+	rowSource, err := sc.getRowSourceBinding(bindings)
+	if err != nil {
+		return err
+	}
+	if rowSource != nil {
+		req.Bindings = nil
+		if describeOnly {
+			return nil
+		}
+		uploader := bindUploader{
+			sc:        sc,
+			ctx:       ctx,
+			stagePath: "@" + bindStageName + "/" + requestID.String(),
+		}
+		if err = uploader.uploadRowSource(rowSource.source); err != nil {
+			return err
+		}
+		req.BindStage = uploader.stagePath
+		return nil
+	}
 	arrayBindThreshold := sc.getArrayBindStageThreshold()
 	numBinds, err := arrayBindValueCount(bindings)
 	if err != nil {
@@ -260,6 +280,9 @@ func getBindValues(bindings []driver.NamedValue, params *syncParams) (map[string
 				if err != nil {
 					return nil, err
 				}
+				if t == types.VariantType {
+					bv.format = jsonFormatStr
+				}
 			} else {
 				bv, err = valueToString(binding.Value, tsmode, params)
 				val = bv.value
@@ -274,6 +297,9 @@ func getBindValues(bindings []driver.NamedValue, params *syncParams) (map[string
 				t = types.ObjectType
 			case types.NilArrayType:
 				t = types.ArrayType
+			case types.GeographyType, types.GeometryType:
+				// spatial values are sent as text in any of the input formats and converted by the server
+				t = types.TextType
 			}
 			bindValues[bindingName(binding, idx)] = execBindParameter{
 				Type:   t.String(),
@@ -326,7 +352,9 @@ func supportedArrayBind(nv *driver.NamedValue) bool {
 		reflect.TypeFor[*boolArray](), reflect.TypeFor[*stringArray](),
 		reflect.TypeFor[*byteArray](), reflect.TypeFor[*timestampNtzArray](),
 		reflect.TypeFor[*timestampLtzArray](), reflect.TypeFor[*timestampTzArray](),
-		reflect.TypeFor[*dateArray](), reflect.TypeFor[*timeArray]():
+		reflect.TypeFor[*dateArray](), reflect.TypeFor[*timeArray](),
+		reflect.TypeFor[*float32VectorArray](), reflect.TypeFor[*int32VectorArray](),
+		reflect.TypeFor[*variantArray]():
 		return true
 	case reflect.TypeFor[[]uint8]():
 		// internal binding ts mode
@@ -337,10 +365,8 @@ func supportedArrayBind(nv *driver.NamedValue) bool {
 		if len(val) == 0 {
 			return true // for null binds
 		}
-		if types.FixedType <= types.SnowflakeType(val[0]) && types.SnowflakeType(val[0]) <= types.UnSupportedType {
-			return true
-		}
-		return false
+		t := types.SnowflakeType(val[0])
+		return types.FixedType <= t && t <= types.UnSupportedType || types.VectorType <= t && t <= types.GeometryType
 	default:
 		// Support for bulk array binding insertion using []interface{}
 		if isInterfaceArrayBinding(nv.Value) {
@@ -381,6 +407,10 @@ func supportedStructuredObjectWriterBind(nv *driver.NamedValue) bool {
 	return ok
 }
 
+func supportedVariantBind(nv *driver.NamedValue) bool {
+	return isVariantValue(nv.Value)
+}
+
 func supportedStructuredArrayBind(nv *driver.NamedValue) bool {
 	typ := reflect.TypeOf(nv.Value)
 	return typ != nil && (typ.Kind() == reflect.Array || typ.Kind() == reflect.Slice)
@@ -1060,6 +1060,49 @@ func TestBindingsWithSameValue(t *testing.T) {
 	})
 }
 
+func TestBulkArrayBindingTimestampAcrossDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assertNilF(t, err)
+	// 2024-03-10 02:00 EST is 03:00 EDT
+	expected := []time.Time{
+		time.Date(2024, time.March, 10, 1, 30, 0, 0, newYork),
+		time.Date(2024, time.March, 10, 3, 30, 0, 0, newYork),
+		time.Date(2024, time.November, 3, 1, 30, 0, 0, newYork).Add(time.Hour), // the second 01:30, EST
+	}
+	runDBTest(t, func(dbt *DBTest) {
+		// the session time zone differs from the location of the values, and the values are bound through the stage
+		dbt.mustExec("ALTER SESSION SET TIMEZONE = 'Asia/Tokyo'")
+		dbt.mustExec("ALTER SESSION SET CLIENT_STAGE_ARRAY_BINDING_THRESHOLD = 1")
+		dbt.mustExec("CREATE OR REPLACE TABLE array_bind_dst (id INT, tz TIMESTAMP_TZ, ltz TIMESTAMP_LTZ)")
+		defer dbt.mustExec("DROP TABLE IF EXISTS array_bind_dst")
+
+		ids := make([]int, len(expected))
+		for i := range ids {
+			ids[i] = i
+		}
+		dbt.mustExec("INSERT INTO array_bind_dst VALUES (?, ?, ?)",
+			mustArray(&ids), mustArray(&expected, TimestampTZType), mustArray(&expected, TimestampLTZType))
+
+		rows := dbt.mustQuery("SELECT id, tz, ltz FROM array_bind_dst ORDER BY id")
+		defer func() {
+			assertNilF(t, rows.Close())
+		}()
+		cnt := 0
+		for rows.Next() {
+			var id int
+			var tz, ltz time.Time
+			assertNilF(t, rows.Scan(&id, &tz, &ltz))
+			assertTrueE(t, tz.Equal(expected[id]), fmt.Sprintf("TIMESTAMP_TZ %v, expected %v", tz, expected[id]))
+			_, tzOffset := tz.Zone()
+			_, expectedOffset := expected[id].Zone()
+			assertEqualE(t, tzOffset, expectedOffset)
+			assertTrueE(t, ltz.Equal(expected[id]), fmt.Sprintf("TIMESTAMP_LTZ %v, expected %v", ltz, expected[id]))
+			cnt++
+		}
+		assertEqualE(t, cnt, len(expected))
+	})
+}
+
 func TestBulkArrayBindingTimeWithPrecision(t *testing.T) {
 	runDBTest(t, func(dbt *DBTest) {
 		dbt.mustExec(fmt.Sprintf("create or replace table %v (s time(0), ms time(3), us time(6), ns time(9))", dbname))
@@ -0,0 +1,248 @@
+package gosnowflake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	errors2 "github.com/snowflakedb/gosnowflake/v2/internal/errors"
+	"github.com/snowflakedb/gosnowflake/v2/internal/query"
+)
+
+// ChunkDescriptorVersion is the version of the ChunkDescriptor format written by this driver.
+const ChunkDescriptorVersion = 1
+
+// maximum number of retries of a chunk fetched with a ChunkDescriptor
+const chunkDescriptorMaxRetryCount = 7
+
+// ChunkDescriptorExporter is an optional interface that an ArrowStreamLoader
+// implements to export the chunks of the current result set as ChunkDescriptors.
+//
+//	if e, ok := loader.(ChunkDescriptorExporter); ok {
+//	    descriptors, err := e.ChunkDescriptors()
+//	    ...
+//	}
+type ChunkDescriptorExporter interface {
+	ChunkDescriptors() ([]ChunkDescriptor, error)
+}
+
+// ChunkDescriptor is a serializable description of a single chunk of a query result.
+// It contains everything needed to download and decode the chunk, so it can be sent
+// (e.g. as JSON) to another process which fetches the chunk without a Snowflake session.
+//
+// The chunk URLs are presigned and expire after some time (typically 6 hours),
+// so the chunks have to be fetched before that. The descriptor contains the key
+// decrypting the chunk and should be treated as a credential.
+type ChunkDescriptor struct {
+	Version           int                         `json:"version"`
+	Index             int                         `json:"index"`
+	URL               string                      `json:"url,omitempty"`
+	Qrmk              string                      `json:"qrmk,omitempty"`
+	ChunkHeader       map[string]string           `json:"chunkHeader,omitempty"`
+	InlineData        []byte                      `json:"inlineData,omitempty"` // first chunk returned in the query response
+	RowCount          int64                       `json:"rowCount"`
+	UncompressedSize  int64                       `json:"uncompressedSize,omitempty"`
+	QueryResultFormat string                      `json:"queryResultFormat"`
+	RowTypes          []query.ExecResponseRowType `json:"rowTypes"`
+	Location          string                      `json:"location,omitempty"` // IANA time zone name or offset, e.g. "-07:00"
+}
+
+// ParseChunkDescriptor reads the JSON encoded chunk descriptor and checks its version.
+func ParseChunkDescriptor(data []byte) (*ChunkDescriptor, error) {
+	var cd ChunkDescriptor
+	if err := json.Unmarshal(data, &cd); err != nil {
+		return nil, fmt.Errorf("parsing chunk descriptor: %w", err)
+	}
+	if err := cd.checkVersion(); err != nil {
+		return nil, err
+	}
+	return &cd, nil
+}
+
+func (cd *ChunkDescriptor) checkVersion() error {
+	if cd.Version != ChunkDescriptorVersion {
+		return &SnowflakeError{
+			Number:      ErrUnsupportedChunkDescriptorVersion,
+			Message:     errors2.ErrMsgUnsupportedChunkDescriptorVersion,
+			MessageArgs: []any{cd.Version, ChunkDescriptorVersion},
+		}
+	}
+	return nil
+}
+
+// TimeLocation returns the session time zone of the query, used for TIMESTAMP_LTZ values.
+// It returns an error if the time zone cannot be loaded, e.g. because the time zone database is not available.
+func (cd *ChunkDescriptor) TimeLocation() (*time.Location, error) {
+	if cd.Location == "" {
+		return time.Local, nil
+	}
+	if offset, err := time.Parse(locationOffsetLayout, cd.Location); err == nil {
+		_, seconds := offset.Zone()
+		return time.FixedZone(cd.Location, seconds), nil
+	}
+	loc, err := time.LoadLocation(cd.Location)
+	if err != nil {
+		return nil, fmt.Errorf("loading location %v of chunk descriptor: %w", cd.Location, err)
+	}
+	return loc, nil
+}
+
+const locationOffsetLayout = "-07:00"
+
+// descriptorLocation returns the IANA name of the location, or its offset if it has no IANA name,
+// e.g. time.FixedZone("", -7*60*60) is "-07:00".
+func descriptorLocation(loc *time.Location) string {
+	if name := loc.String(); name != "" && name != "Local" {
+		if _, err := time.LoadLocation(name); err == nil {
+			return name
+		}
+	}
+	return time.Now().In(loc).Format(locationOffsetLayout)
+}
+
+// Fetch downloads the chunk with the given HTTP client (http.DefaultClient if nil) and
+// returns its uncompressed content: an Arrow IPC stream if QueryResultFormat is "arrow",
+// JSON row fragments otherwise. The returned stream has to be closed.
+func (cd *ChunkDescriptor) Fetch(ctx context.Context, client *http.Client) (io.ReadCloser, error) {
+	if err := cd.checkVersion(); err != nil {
+		return nil, err
+	}
+	if cd.InlineData != nil {
+		return io.NopCloser(bytes.NewReader(cd.InlineData)), nil
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	headers := make(map[string]string)
+	if len(cd.ChunkHeader) > 0 {
+		maps.Copy(headers, cd.ChunkHeader)
+	} else {
+		headers[headerSseCAlgorithm] = headerSseCAes
+		headers[headerSseCKey] = cd.Qrmk
+	}
+	u, err := url.Parse(cd.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+	resp, err := newRetryHTTP(ctx, client, http.NewRequest, u, headers, 0, chunkDescriptorMaxRetryCount, defaultTimeProvider, nil).execute()
+	if err != nil {
+		return nil, fmt.Errorf("getting chunk: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, &SnowflakeError{
+			Number:      ErrFailedToGetChunk,
+			SQLState:    SQLStateConnectionFailure,
+			Message:     errors2.ErrMsgFailedToGetChunk,
+			MessageArgs: []any{cd.Index},
+		}
+	}
+	stream, err := newChunkStream(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return newCancelableStream(ctx, stream), nil
+}
+
+// FetchRecords downloads an Arrow chunk and returns its records as sent by the server.
+// The records have to be released by the caller.
+func (cd *ChunkDescriptor) FetchRecords(ctx context.Context, client *http.Client, pool memory.Allocator) (records []arrow.Record, err error) {
+	if cd.QueryResultFormat != string(arrowFormat) {
+		return nil, fmt.Errorf("chunk %v is in %v format, not in arrow format", cd.Index, cd.QueryResultFormat)
+	}
+	if pool == nil {
+		pool = memory.DefaultAllocator
+	}
+	stream, err := cd.Fetch(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := stream.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	rr, err := ipc.NewReader(stream, ipc.WithAllocator(pool))
+	if err != nil {
+		return nil, fmt.Errorf("creating ipc reader: %w", err)
+	}
+	defer rr.Release()
+	for rr.Next() {
+		rec := rr.Record()
+		rec.Retain()
+		records = append(records, rec)
+	}
+	if err = rr.Err(); err != nil {
+		for _, rec := range records {
+			rec.Release()
+		}
+		return nil, fmt.Errorf("reading arrow records: %w", err)
+	}
+	return records, nil
+}
+
+// ChunkDescriptors exports the chunks of the current result set, including the
+// first chunk returned inline in the query response.
+func (scd *snowflakeArrowStreamChunkDownloader) ChunkDescriptors() ([]ChunkDescriptor, error) {
+	batches, err := scd.GetBatches()
+	if err != nil {
+		return nil, err
+	}
+	var location string
+	if loc := scd.Location(); loc != nil {
+		location = descriptorLocation(loc)
+	}
+	newDescriptor := func(rowCount int64) ChunkDescriptor {
+		return ChunkDescriptor{
+			Version:           ChunkDescriptorVersion,
+			RowCount:          rowCount,
+			QueryResultFormat: scd.queryResultFormat,
+			RowTypes:          scd.RowSet.RowType,
+			Location:          location,
+		}
+	}
+	descriptors := make([]ChunkDescriptor, 0, len(batches)+1)
+	if len(scd.RowSet.JSON) > 0 {
+		// the JSON rows returned in the query response are sent as row fragments, like the chunks
+		var data []byte
+		for i, row := range scd.RowSet.JSON {
+			b, err := json.Marshal(row)
+			if err != nil {
+				return nil, fmt.Errorf("encoding JSON rows: %w", err)
+			}
+			if i > 0 {
+				data = append(data, ',')
+			}
+			data = append(data, b...)
+		}
+		cd := newDescriptor(int64(len(scd.RowSet.JSON)))
+		cd.InlineData = data
+		descriptors = append(descriptors, cd)
+	}
+	for i := range batches {
+		cd := newDescriptor(batches[i].NumRows())
+		if batches[i].inlineData != nil {
+			cd.InlineData = batches[i].inlineData
+		} else {
+			meta := scd.ChunkMetas[batches[i].idx]
+			cd.URL = meta.URL
+			cd.UncompressedSize = meta.UncompressedSize
+			cd.Qrmk = scd.Qrmk
+			cd.ChunkHeader = scd.ChunkHeader
+		}
+		descriptors = append(descriptors, cd)
+	}
+	for i := range descriptors {
+		descriptors[i].Index = i
+	}
+	return descriptors, nil
+}
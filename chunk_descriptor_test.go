@@ -0,0 +1,140 @@
+package gosnowflake
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/snowflakedb/gosnowflake/v2/internal/query"
+)
+
+func TestChunkDescriptorsExportAndFetch(t *testing.T) {
+	arrowBytes := buildArrowChunkBytes(t)
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	_, err := gw.Write(arrowBytes)
+	assertNilF(t, err)
+	assertNilF(t, gw.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(headerSseCKey) != "test-qrmk" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write(gzipped.Bytes())
+	}))
+	defer server.Close()
+
+	timezone := "Europe/Warsaw"
+	scd := &snowflakeArrowStreamChunkDownloader{
+		sc:                &snowflakeConn{syncParams: syncParams{params: map[string]*string{"timezone": &timezone}}},
+		ChunkMetas:        []query.ExecResponseChunk{{URL: server.URL + "/chunk", RowCount: 1, UncompressedSize: int64(len(arrowBytes))}},
+		Total:             2,
+		Qrmk:              "test-qrmk",
+		RowSet:            rowSetType{RowType: []query.ExecResponseRowType{{Name: "ID", Type: "fixed"}}, RowSetBase64: base64.StdEncoding.EncodeToString(arrowBytes)},
+		queryResultFormat: "arrow",
+	}
+	var loader ArrowStreamLoader = scd
+	exporter, ok := loader.(ChunkDescriptorExporter)
+	assertTrueF(t, ok, "arrow stream loader should export chunk descriptors")
+	descriptors, err := exporter.ChunkDescriptors()
+	assertNilF(t, err)
+	assertEqualF(t, len(descriptors), 2)
+	assertDeepEqualE(t, descriptors[0].InlineData, arrowBytes)
+	assertEqualE(t, descriptors[1].URL, server.URL+"/chunk")
+
+	for i, descriptor := range descriptors {
+		data, err := json.Marshal(descriptor)
+		assertNilF(t, err)
+		cd, err := ParseChunkDescriptor(data)
+		assertNilF(t, err)
+		assertEqualE(t, cd.Index, i)
+		assertEqualE(t, cd.RowCount, int64(1))
+		loc, err := cd.TimeLocation()
+		assertNilF(t, err)
+		assertEqualE(t, loc.String(), timezone)
+		assertEqualE(t, cd.RowTypes[0].Name, "ID")
+
+		pool := memory.NewCheckedAllocator(memory.DefaultAllocator)
+		records, err := cd.FetchRecords(context.Background(), server.Client(), pool)
+		assertNilF(t, err)
+		assertEqualF(t, len(records), 1)
+		assertEqualE(t, records[0].NumRows(), int64(1))
+		records[0].Release()
+		pool.AssertSize(t, 0)
+	}
+}
+
+func TestChunkDescriptorsJSONRowSet(t *testing.T) {
+	one, two := "1", "2"
+	scd := &snowflakeArrowStreamChunkDownloader{
+		Total:             2,
+		RowSet:            rowSetType{JSON: [][]*string{{&one}, {&two}}},
+		queryResultFormat: "json",
+	}
+	descriptors, err := scd.ChunkDescriptors()
+	assertNilF(t, err)
+	assertEqualF(t, len(descriptors), 1)
+	stream, err := descriptors[0].Fetch(context.Background(), nil)
+	assertNilF(t, err)
+	defer stream.Close()
+	data, err := io.ReadAll(stream)
+	assertNilF(t, err)
+	assertEqualE(t, string(data), `["1"],["2"]`)
+
+	_, err = descriptors[0].FetchRecords(context.Background(), nil, nil)
+	assertNotNilE(t, err, "json chunk should not be fetched as arrow records")
+}
+
+func TestChunkDescriptorFetchFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	cd := &ChunkDescriptor{Version: ChunkDescriptorVersion, Index: 3, URL: server.URL}
+	_, err := cd.Fetch(context.Background(), server.Client())
+	var se *SnowflakeError
+	assertErrorsAsF(t, err, &se)
+	assertEqualE(t, se.Number, ErrFailedToGetChunk)
+}
+
+func TestParseChunkDescriptorUnsupportedVersion(t *testing.T) {
+	_, err := ParseChunkDescriptor([]byte(`{"version": 2, "url": "https://example.com"}`))
+	var se *SnowflakeError
+	assertErrorsAsF(t, err, &se)
+	assertEqualE(t, se.Number, ErrUnsupportedChunkDescriptorVersion)
+
+	_, err = ParseChunkDescriptor([]byte(`not json`))
+	assertNotNilE(t, err)
+}
+
+func TestChunkDescriptorLocation(t *testing.T) {
+	testcases := []struct {
+		loc      *time.Location
+		location string
+		offset   int
+	}{
+		{loc: time.FixedZone("", -7*60*60), location: "-07:00", offset: -7 * 60 * 60},
+		{loc: time.FixedZone("IST", 5*60*60+30*60), location: "+05:30", offset: 5*60*60 + 30*60},
+		{loc: time.UTC, location: "UTC"},
+	}
+	for _, tc := range testcases {
+		assertEqualE(t, descriptorLocation(tc.loc), tc.location)
+		cd := &ChunkDescriptor{Location: tc.location}
+		loc, err := cd.TimeLocation()
+		assertNilF(t, err)
+		_, offset := time.Date(2024, 7, 1, 0, 0, 0, 0, loc).Zone()
+		assertEqualE(t, offset, tc.offset, tc.location)
+	}
+
+	_, err := (&ChunkDescriptor{Location: "Not/A_Zone"}).TimeLocation()
+	assertNotNilE(t, err)
+}
@@ -69,6 +69,22 @@ type snowflakeChunkDownloader struct {
 	FuncDownload       func(context.Context, *snowflakeChunkDownloader, int)
 	FuncDownloadHelper func(context.Context, *snowflakeChunkDownloader, int) error
 	FuncGet            func(context.Context, *snowflakeConn, string, map[string]string, time.Duration) (*http.Response, error)
+
+	/* memory budget of the downloaded chunks, guarded by ChunksMutex */
+	memoryBudget      int64
+	spillToDisk       bool
+	reservedMemory    int64
+	reservedChunks    map[int]int64
+	readerChunkIndex  int
+	nextAdmittedChunk int
+	spilledChunks     map[int]string
+	chunksClosed      bool
+
+	/* raw arrow records of the chunks read with NextColumnChunk */
+	columnar           bool
+	CurrentRecords     []arrow.Record
+	CurrentRecordIndex int
+	RecordChunks       map[int][]arrow.Record
 }
 
 func (scd *snowflakeChunkDownloader) totalUncompressedSize() (acc int64) {
@@ -89,7 +105,23 @@ func (scd *snowflakeChunkDownloader) start() error {
 	scd.CurrentChunk = make([]chunkRowType, scd.CurrentChunkSize)
 	populateJSONRowSet(scd.CurrentChunk, scd.RowSet.JSON)
 
-	if scd.getQueryResultFormat() == arrowFormat && scd.RowSet.RowSetBase64 != "" {
+	scd.columnar = columnarResultsEnabled(scd.ctx) && scd.getQueryResultFormat() == arrowFormat
+	scd.CurrentRecordIndex = -1
+	if scd.columnar && scd.RowSet.RowSetBase64 != "" {
+		params, err := scd.getConfigParams()
+		if err != nil {
+			return fmt.Errorf("getting config params: %w", err)
+		}
+		firstArrowChunk, err := buildFirstArrowChunk(scd.RowSet.RowSetBase64, getCurrentLocation(params), scd.pool)
+		if err != nil {
+			return fmt.Errorf("building first arrow chunk: %w", err)
+		}
+		records, err := firstArrowChunk.decodeArrowBatchRaw()
+		if err != nil {
+			return fmt.Errorf("decoding arrow records: %w", err)
+		}
+		scd.CurrentRecords = *records
+	} else if scd.getQueryResultFormat() == arrowFormat && scd.RowSet.RowSetBase64 != "" {
 		params, err := scd.getConfigParams()
 		if err != nil {
 			return fmt.Errorf("getting config params: %w", err)
@@ -131,8 +163,10 @@ func (scd *snowflakeChunkDownloader) start() error {
 		scd.ChunksMutex = &sync.Mutex{}
 		scd.DoneDownloadCond = sync.NewCond(scd.ChunksMutex)
 		scd.Chunks = make(map[int][]chunkRowType)
+		scd.RecordChunks = make(map[int][]arrow.Record)
 		scd.ChunksChan = make(chan int, chunkMetaLen)
 		scd.ChunksError = make(chan *chunkError, chunkDownloadWorkers)
+		scd.initChunkMemory()
 		for i := range chunkMetaLen {
 			chunk := scd.ChunkMetas[i]
 			logger.WithContext(scd.ctx).Debugf("Result Format: %v, add chunk to channel ChunksChan: %v, URL: %v, RowCount: %v, UncompressedSize: %v, ChunkResultFormat: %v",
@@ -198,50 +232,88 @@ func (scd *snowflakeChunkDownloader) checkErrorRetry() error {
 }
 
 func (scd *snowflakeChunkDownloader) next() (chunkRowType, error) {
+	if scd.columnar {
+		return chunkRowType{}, errColumnarResultsEnabled()
+	}
 	for {
 		scd.CurrentIndex++
 		if scd.CurrentIndex < scd.CurrentChunkSize {
 			return scd.CurrentChunk[scd.CurrentIndex], nil
 		}
-		scd.CurrentChunkIndex++ // next chunk
-		scd.CurrentIndex = -1   // reset
-		if scd.CurrentChunkIndex >= len(scd.ChunkMetas) {
-			break
+		if err := scd.nextChunk(); err != nil {
+			return chunkRowType{}, err
 		}
+	}
+}
 
-		scd.ChunksMutex.Lock()
-		if scd.CurrentChunkIndex > 0 {
-			scd.Chunks[scd.CurrentChunkIndex-1] = nil // detach the previously used chunk
-		}
+// nextChunk waits for the next chunk and makes it current. It returns io.EOF if there are no more chunks.
+func (scd *snowflakeChunkDownloader) nextChunk() error {
+	scd.CurrentChunkIndex++ // next chunk
+	scd.CurrentIndex = -1   // reset
+	if scd.CurrentChunkIndex >= len(scd.ChunkMetas) {
+		logger.WithContext(scd.ctx).Debugf("no more data")
+		if len(scd.ChunkMetas) > 0 {
+			scd.closeChunkMemory()
+			close(scd.ChunksError)
+			close(scd.ChunksChan)
+		}
+		return io.EOF
+	}
 
-		for scd.Chunks[scd.CurrentChunkIndex] == nil {
-			logger.WithContext(scd.ctx).Debugf("waiting for chunk idx: %v/%v",
-				scd.CurrentChunkIndex+1, len(scd.ChunkMetas))
+	scd.ChunksMutex.Lock()
+	if scd.CurrentChunkIndex > 0 {
+		scd.Chunks[scd.CurrentChunkIndex-1] = nil // detach the previously used chunk
+		scd.releaseChunkMemory(scd.CurrentChunkIndex - 1)
+	}
+	if scd.memoryBudget > 0 {
+		// wake up the downloads waiting for memory
+		scd.readerChunkIndex = scd.CurrentChunkIndex
+		scd.DoneDownloadCond.Broadcast()
+	}
 
-			if err := scd.checkErrorRetry(); err != nil {
-				scd.ChunksMutex.Unlock()
-				return chunkRowType{}, fmt.Errorf("checking for error: %w", err)
-			}
+	for !scd.chunkReady(scd.CurrentChunkIndex) {
+		logger.WithContext(scd.ctx).Debugf("waiting for chunk idx: %v/%v",
+			scd.CurrentChunkIndex+1, len(scd.ChunkMetas))
 
-			// wait for chunk downloader goroutine to broadcast the event,
-			// 1) one chunk download finishes or 2) an error occurs.
-			scd.DoneDownloadCond.Wait()
+		if err := scd.checkErrorRetry(); err != nil {
+			scd.ChunksMutex.Unlock()
+			return fmt.Errorf("checking for error: %w", err)
 		}
-		logger.WithContext(scd.ctx).Debugf("ready: chunk %v", scd.CurrentChunkIndex+1)
-		scd.CurrentChunk = scd.Chunks[scd.CurrentChunkIndex]
-		scd.ChunksMutex.Unlock()
-		scd.CurrentChunkSize = len(scd.CurrentChunk)
 
-		// kick off the next download
-		scd.schedule()
+		// wait for chunk downloader goroutine to broadcast the event,
+		// 1) one chunk download finishes or 2) an error occurs.
+		scd.DoneDownloadCond.Wait()
+	}
+	if path := scd.spilledChunks[scd.CurrentChunkIndex]; path != "" {
+		delete(scd.spilledChunks, scd.CurrentChunkIndex)
+		scd.reserveChunkMemory(scd.CurrentChunkIndex)
+		scd.ChunksMutex.Unlock()
+		if err := scd.loadSpilledChunk(scd.CurrentChunkIndex, path); err != nil {
+			return fmt.Errorf("loading spilled chunk: %w", err)
+		}
+		scd.ChunksMutex.Lock()
 	}
+	logger.WithContext(scd.ctx).Debugf("ready: chunk %v", scd.CurrentChunkIndex+1)
+	scd.CurrentChunk = scd.Chunks[scd.CurrentChunkIndex]
+	if scd.columnar {
+		scd.CurrentRecords = scd.RecordChunks[scd.CurrentChunkIndex]
+		delete(scd.RecordChunks, scd.CurrentChunkIndex)
+	}
+	scd.ChunksMutex.Unlock()
+	scd.CurrentChunkSize = len(scd.CurrentChunk)
 
-	logger.WithContext(scd.ctx).Debugf("no more data")
-	if len(scd.ChunkMetas) > 0 {
-		close(scd.ChunksError)
-		close(scd.ChunksChan)
+	// kick off the next download
+	scd.schedule()
+	return nil
+}
+
+// chunkReady must be called with ChunksMutex locked.
+func (scd *snowflakeChunkDownloader) chunkReady(idx int) bool {
+	if scd.Chunks[idx] != nil || scd.spilledChunks[idx] != "" {
+		return true
 	}
-	return chunkRowType{}, io.EOF
+	_, ok := scd.RecordChunks[idx]
+	return ok
 }
 
 func (scd *snowflakeChunkDownloader) reset() {
@@ -395,8 +467,24 @@ func downloadChunk(ctx context.Context, scd *snowflakeChunkDownloader, idx int)
 	logger.WithContext(ctx).Infof("download start chunk: %v", idx+1)
 	defer scd.DoneDownloadCond.Broadcast()
 
+	spill, err := scd.acquireChunkMemory(ctx, idx)
+	if errors.Is(err, errChunkDownloaderClosed) {
+		return
+	} else if err != nil {
+		scd.ChunksError <- &chunkError{Index: idx, Error: err}
+		return
+	}
+	if spill {
+		ctx = context.WithValue(ctx, spillChunkKey{}, true)
+	}
+
 	timer := time.Now()
-	if err := scd.FuncDownloadHelper(ctx, scd, idx); err != nil {
+	inst := scd.sc.instrumentation()
+	chunkCtx, span := inst.startSpan(ctx, spanChunk, attrChunkIndex.Int(idx), attrChunkRows.Int(scd.ChunkMetas[idx].RowCount))
+	err = scd.FuncDownloadHelper(chunkCtx, scd, idx)
+	endSpan(span, err)
+	inst.recordChunkDuration(ctx, time.Since(timer))
+	if err != nil {
 		logger.WithContext(ctx).Errorf(
 			"failed to extract HTTP response body. URL: %v, err: %v", scd.ChunkMetas[idx].URL, err)
 		scd.ChunksError <- &chunkError{Index: idx, Error: err}
@@ -425,8 +513,9 @@ func downloadChunkHelper(ctx context.Context, scd *snowflakeChunkDownloader, idx
 	if err != nil {
 		return fmt.Errorf("getting chunk: %w", err)
 	}
-	body := newCancelableStream(ctx, resp.Body)
+	body := &countingReadCloser{ReadCloser: newCancelableStream(ctx, resp.Body)}
 	defer func() {
+		scd.sc.instrumentation().recordDownloadedBytes(ctx, "chunk", body.count)
 		if err = body.Close(); err != nil {
 			logger.Warnf("downloadChunkHelper: closing response body %v: %v", scd.ChunkMetas[idx].URL, err)
 		}
@@ -447,6 +536,9 @@ func downloadChunkHelper(ctx context.Context, scd *snowflakeChunkDownloader, idx
 	}
 
 	bufStream := bufio.NewReader(body)
+	if shouldSpillChunk(ctx) {
+		return scd.spillChunk(idx, bufStream)
+	}
 	return decodeChunk(ctx, scd, idx, bufStream)
 }
 
@@ -522,6 +614,20 @@ func decodeChunk(ctx context.Context, scd *snowflakeChunkDownloader, idx int, bu
 			scd.rawBatches[idx].rowCount = countRawArrowBatchRows(scd.rawBatches[idx].records)
 			return nil
 		}
+		if scd.columnar {
+			records, err := arc.decodeArrowBatchRaw()
+			if err != nil {
+				return fmt.Errorf("decoding arrow records: %w", err)
+			}
+			scd.ChunksMutex.Lock()
+			defer scd.ChunksMutex.Unlock()
+			if scd.chunksClosed {
+				releaseRecords(*records)
+				return nil
+			}
+			scd.RecordChunks[idx] = *records
+			return nil
+		}
 		highPrec := higherPrecisionEnabled(scd.ctx)
 		respd, err = arc.decodeArrowChunk(ctx, scd.RowSet.RowType, highPrec, params)
 		if err != nil {
@@ -569,3 +675,15 @@ func getAllocator(ctx context.Context) memory.Allocator {
 func usesArrowBatches(ctx context.Context) bool {
 	return ia.BatchesEnabled(ctx)
 }
+
+// countingReadCloser counts the bytes read from the wrapped stream.
+type countingReadCloser struct {
+	io.ReadCloser
+	count int64
+}
+
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.count += int64(n)
+	return n, err
+}
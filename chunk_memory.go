@@ -0,0 +1,167 @@
+package gosnowflake
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// errChunkDownloaderClosed is returned to the downloads waiting for memory after the rows are closed.
+var errChunkDownloaderClosed = errors.New("chunk downloader is closed")
+
+type spillChunkKey struct{}
+
+func shouldSpillChunk(ctx context.Context) bool {
+	spill, _ := ctx.Value(spillChunkKey{}).(bool)
+	return spill
+}
+
+// initChunkMemory reads the memory budget from the configuration. It must be called after the mutex is created.
+func (scd *snowflakeChunkDownloader) initChunkMemory() {
+	scd.readerChunkIndex = scd.CurrentChunkIndex
+	if scd.sc == nil || scd.sc.cfg == nil || scd.sc.cfg.ResultMemoryBudget <= 0 {
+		return
+	}
+	scd.memoryBudget = scd.sc.cfg.ResultMemoryBudget
+	scd.spillToDisk = scd.sc.cfg.ResultSpillToDisk
+	scd.reservedChunks = make(map[int]int64)
+	scd.spilledChunks = make(map[int]string)
+}
+
+// acquireChunkMemory reserves the uncompressed size of the chunk before it is downloaded.
+// Chunks are admitted in order. The chunk the reader waits for is always admitted, so the
+// reader makes progress even if a single chunk is larger than the budget. Other chunks wait
+// until the reader releases enough memory or, if spilling is enabled, are written to disk
+// instead (spill is true then).
+func (scd *snowflakeChunkDownloader) acquireChunkMemory(ctx context.Context, idx int) (spill bool, err error) {
+	if scd.memoryBudget <= 0 {
+		return false, nil
+	}
+	scd.ChunksMutex.Lock()
+	defer scd.ChunksMutex.Unlock()
+	if _, ok := scd.reservedChunks[idx]; ok {
+		// retried download
+		return false, nil
+	}
+	stop := context.AfterFunc(ctx, func() {
+		scd.ChunksMutex.Lock()
+		defer scd.ChunksMutex.Unlock()
+		scd.DoneDownloadCond.Broadcast()
+	})
+	defer stop()
+	size := scd.ChunkMetas[idx].UncompressedSize
+	for {
+		if scd.chunksClosed {
+			return false, errChunkDownloaderClosed
+		}
+		if err = ctx.Err(); err != nil {
+			return false, err
+		}
+		if idx <= scd.nextAdmittedChunk {
+			if scd.reservedMemory+size <= scd.memoryBudget || idx <= scd.readerChunkIndex {
+				scd.reserveChunkMemory(idx)
+				break
+			}
+			if scd.spillToDisk {
+				logger.WithContext(ctx).Debugf("memory budget exceeded, spilling chunk %v to disk", idx+1)
+				spill = true
+				break
+			}
+			logger.WithContext(ctx).Debugf("memory budget exceeded, chunk %v waits for the reader", idx+1)
+		}
+		scd.DoneDownloadCond.Wait()
+	}
+	if idx == scd.nextAdmittedChunk {
+		scd.nextAdmittedChunk++
+		scd.DoneDownloadCond.Broadcast()
+	}
+	return spill, nil
+}
+
+// reserveChunkMemory must be called with ChunksMutex locked.
+func (scd *snowflakeChunkDownloader) reserveChunkMemory(idx int) {
+	if scd.memoryBudget <= 0 {
+		return
+	}
+	if _, ok := scd.reservedChunks[idx]; ok {
+		return
+	}
+	size := scd.ChunkMetas[idx].UncompressedSize
+	scd.reservedChunks[idx] = size
+	scd.reservedMemory += size
+}
+
+// releaseChunkMemory must be called with ChunksMutex locked.
+func (scd *snowflakeChunkDownloader) releaseChunkMemory(idx int) {
+	if scd.memoryBudget <= 0 {
+		return
+	}
+	if size, ok := scd.reservedChunks[idx]; ok {
+		delete(scd.reservedChunks, idx)
+		scd.reservedMemory -= size
+	}
+}
+
+// spillChunk writes the raw chunk to a file in TmpDirPath. It is decoded when the reader gets to it.
+func (scd *snowflakeChunkDownloader) spillChunk(idx int, r io.Reader) error {
+	f, err := os.CreateTemp(scd.sc.cfg.TmpDirPath, "snowflake_chunk_*")
+	if err != nil {
+		return fmt.Errorf("creating spill file: %w", err)
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		f.Close()
+		removeSpillFile(f.Name())
+		return fmt.Errorf("writing chunk to spill file: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		removeSpillFile(f.Name())
+		return fmt.Errorf("closing spill file: %w", err)
+	}
+	scd.ChunksMutex.Lock()
+	defer scd.ChunksMutex.Unlock()
+	if scd.chunksClosed {
+		removeSpillFile(f.Name())
+		return nil
+	}
+	scd.spilledChunks[idx] = f.Name()
+	logger.Debugf("chunk %v spilled to %v", idx+1, f.Name())
+	return nil
+}
+
+// loadSpilledChunk decodes the chunk from the spill file and removes it.
+func (scd *snowflakeChunkDownloader) loadSpilledChunk(idx int, path string) error {
+	defer removeSpillFile(path)
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening spill file: %w", err)
+	}
+	defer f.Close()
+	return decodeChunk(scd.ctx, scd, idx, bufio.NewReader(f))
+}
+
+// closeChunkMemory releases the reserved memory, removes the spill files that have
+// not been read and stops the downloads waiting for memory.
+func (scd *snowflakeChunkDownloader) closeChunkMemory() {
+	if scd.ChunksMutex == nil {
+		return
+	}
+	scd.ChunksMutex.Lock()
+	defer scd.ChunksMutex.Unlock()
+	scd.chunksClosed = true
+	clear(scd.reservedChunks)
+	scd.reservedMemory = 0
+	for idx, path := range scd.spilledChunks {
+		removeSpillFile(path)
+		delete(scd.spilledChunks, idx)
+	}
+	scd.DoneDownloadCond.Broadcast()
+}
+
+func removeSpillFile(path string) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warnf("failed to remove spill file %v: %v", path, err)
+	}
+}
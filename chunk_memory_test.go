@@ -0,0 +1,129 @@
+package gosnowflake
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/snowflakedb/gosnowflake/v2/internal/query"
+)
+
+const testChunkSize = 10
+
+func newBudgetTestChunkDownloader(cfg *Config, chunkCount int, onGet func(scd *snowflakeChunkDownloader)) *snowflakeChunkDownloader {
+	threads := strconv.Itoa(chunkCount)
+	scd := newCancelableTestChunkDownloader(context.Background(), nil)
+	scd.sc.cfg = cfg
+	scd.sc.syncParams = syncParams{params: map[string]*string{clientPrefetchThreadsKey: &threads}}
+	scd.ChunkMetas = make([]query.ExecResponseChunk, chunkCount)
+	for i := range scd.ChunkMetas {
+		scd.ChunkMetas[i] = query.ExecResponseChunk{URL: strconv.Itoa(i + 1), RowCount: 1, UncompressedSize: testChunkSize}
+	}
+	scd.FuncGet = func(_ context.Context, _ *snowflakeConn, url string, _ map[string]string, _ time.Duration) (*http.Response, error) {
+		if onGet != nil {
+			onGet(scd)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`["` + url + `"]`))}, nil
+	}
+	return scd
+}
+
+func readAllChunkRows(t *testing.T, scd *snowflakeChunkDownloader) []string {
+	var values []string
+	for {
+		row, err := scd.next()
+		if err == io.EOF {
+			return values
+		}
+		assertNilF(t, err)
+		values = append(values, *row.RowSet[0])
+	}
+}
+
+func waitForChunks(t *testing.T, scd *snowflakeChunkDownloader, count int) {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		scd.ChunksMutex.Lock()
+		done := len(scd.Chunks) + len(scd.spilledChunks)
+		scd.ChunksMutex.Unlock()
+		if done == count {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("chunks were not downloaded in time")
+}
+
+func TestChunkMemoryBudgetBackpressure(t *testing.T) {
+	budget := int64(15)
+	exceeded := false
+	scd := newBudgetTestChunkDownloader(&Config{ResultMemoryBudget: budget}, 4, func(scd *snowflakeChunkDownloader) {
+		scd.ChunksMutex.Lock()
+		defer scd.ChunksMutex.Unlock()
+		if scd.reservedMemory > budget {
+			exceeded = true
+		}
+	})
+	assertNilF(t, scd.start())
+	assertDeepEqualE(t, readAllChunkRows(t, scd), []string{"1", "2", "3", "4"})
+	assertFalseE(t, exceeded, "downloaded chunks should not exceed the memory budget")
+	assertEqualE(t, scd.reservedMemory, int64(0))
+}
+
+func TestChunkMemoryBudgetWithoutLimit(t *testing.T) {
+	scd := newBudgetTestChunkDownloader(&Config{}, 3, nil)
+	assertNilF(t, scd.start())
+	waitForChunks(t, scd, 3)
+	assertDeepEqualE(t, readAllChunkRows(t, scd), []string{"1", "2", "3"})
+}
+
+func TestChunkMemoryBudgetSpillToDisk(t *testing.T) {
+	tmpDir := t.TempDir()
+	scd := newBudgetTestChunkDownloader(&Config{ResultMemoryBudget: 15, ResultSpillToDisk: true, TmpDirPath: tmpDir}, 4, nil)
+	assertNilF(t, scd.start())
+	waitForChunks(t, scd, 4)
+	entries, err := os.ReadDir(tmpDir)
+	assertNilF(t, err)
+	assertEqualE(t, len(entries), 3, "chunks over the budget should be spilled")
+
+	assertDeepEqualE(t, readAllChunkRows(t, scd), []string{"1", "2", "3", "4"})
+	entries, err = os.ReadDir(tmpDir)
+	assertNilF(t, err)
+	assertEqualE(t, len(entries), 0, "spill files should be removed")
+}
+
+func TestChunkMemoryBudgetCloseRemovesSpilledChunks(t *testing.T) {
+	tmpDir := t.TempDir()
+	scd := newBudgetTestChunkDownloader(&Config{ResultMemoryBudget: 15, ResultSpillToDisk: true, TmpDirPath: tmpDir}, 3, nil)
+	assertNilF(t, scd.start())
+	waitForChunks(t, scd, 3)
+	rows := &snowflakeRows{sc: scd.sc, ChunkDownloader: scd}
+	rows.sc.ctx = context.Background()
+	assertNilF(t, rows.Close())
+	entries, err := os.ReadDir(tmpDir)
+	assertNilF(t, err)
+	assertEqualE(t, len(entries), 0, "spill files should be removed on close")
+}
+
+func TestChunkMemoryBudgetCloseStopsWaitingDownloads(t *testing.T) {
+	scd := newBudgetTestChunkDownloader(&Config{ResultMemoryBudget: 15}, 3, nil)
+	assertNilF(t, scd.start())
+	waitForChunks(t, scd, 1)
+	done := make(chan error)
+	go func() {
+		_, err := scd.acquireChunkMemory(context.Background(), 2)
+		done <- err
+	}()
+	scd.closeChunkMemory()
+	select {
+	case err := <-done:
+		assertErrIsE(t, err, errChunkDownloaderClosed)
+	case <-time.After(10 * time.Second):
+		t.Fatal("waiting download was not stopped")
+	}
+}
@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
+	"sync"
+	"sync/atomic"
 
 	sf "github.com/snowflakedb/gosnowflake/v2"
 )
@@ -37,35 +42,73 @@ func main() {
 	}
 	defer db.Close()
 
-	_ = sf.GetLogger().SetLogLevel("debug")
-
-	query := "SELECT 1 FROM TABLE(GENERATOR(ROWCOUNT=>10000000))"
-	rows, err := db.Query(query) // no cancel is allowed
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
 	if err != nil {
-		log.Fatalf("failed to run a query. %v, err: %v", query, err)
+		log.Fatalf("failed to get Conn. err: %v", err)
 	}
-	defer rows.Close()
-	var v int
-	for rows.Next() {
-		err := rows.Scan(&v)
+	defer conn.Close()
+
+	query := "SELECT 1 FROM TABLE(GENERATOR(ROWCOUNT=>10000000))"
+	var messages [][]byte
+	var total int64
+	err = conn.Raw(func(x any) error {
+		loader, err := x.(interface {
+			QueryArrowStream(context.Context, string, ...driver.NamedValue) (sf.ArrowStreamLoader, error)
+		}).QueryArrowStream(ctx, query)
 		if err != nil {
-			log.Fatalf("failed to get result. err: %v", err)
+			return err
 		}
-		if v != 1 {
-			log.Fatalf("failed to get 1. got: %v", v)
+		total = loader.TotalRows()
+		descriptors, err := loader.(sf.ChunkDescriptorExporter).ChunkDescriptors()
+		if err != nil {
+			return err
 		}
-	}
-	if rows.Err() != nil {
-		fmt.Printf("ERROR: %v\n", rows.Err())
-		return
+		// the descriptors are serialized, so they can be sent to workers on other hosts
+		for _, descriptor := range descriptors {
+			message, err := json.Marshal(descriptor)
+			if err != nil {
+				return err
+			}
+			messages = append(messages, message)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("failed to run a query. %v, err: %v", query, err)
 	}
 
-	_ = sf.GetLogger().SetLogLevel("error")
+	// the workers fetch the chunks without a Snowflake session
+	var fetched atomic.Int64
+	var wg sync.WaitGroup
+	for _, message := range messages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			descriptor, err := sf.ParseChunkDescriptor(message)
+			if err != nil {
+				log.Fatalf("failed to parse chunk descriptor. err: %v", err)
+			}
+			records, err := descriptor.FetchRecords(ctx, nil, nil)
+			if err != nil {
+				log.Fatalf("failed to fetch chunk %v. err: %v", descriptor.Index, err)
+			}
+			for _, record := range records {
+				fetched.Add(record.NumRows())
+				record.Release()
+			}
+		}()
+	}
+	wg.Wait()
+	if fetched.Load() != total {
+		log.Fatalf("failed to fetch all rows. expected: %v, got: %v", total, fetched.Load())
+	}
+	fmt.Printf("fetched %v rows from %v chunks\n", fetched.Load(), len(messages))
 }
 
 func printExampleDescription() {
 	fmt.Printf(`
-		Logs should present links from which particular chunks has been downloaded.
-		Also, notice logs like "decoded <number> rows" from multiple goroutines (the number after log level), as chunks are downloaded in parallel.
+		The query result is exported as serializable chunk descriptors, which are fetched and decoded
+		in parallel by workers without a Snowflake session, as worker processes on other hosts would do.
 	`)
 }
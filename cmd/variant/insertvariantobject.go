@@ -52,16 +52,12 @@ func main() {
 
 	tablename := "insert_variant_object_" + strconv.FormatInt(time.Now().UnixNano(), 10)
 	param := map[string]string{"key": "value"}
-	jsonStr, err := json.Marshal(param)
-	if err != nil {
-		log.Fatalf("failed to marshal json. err: %v", err)
-	}
 
 	createTableQuery := "CREATE TABLE " + tablename + " (c1 VARIANT, c2 OBJECT)"
 
-	// https://docs.snowflake.com/en/sql-reference/functions/parse_json
-	// can do with TO_VARIANT(PARSE_JSON(..)) as well, but PARSE_JSON already produces VARIANT
-	insertQuery := "INSERT INTO " + tablename + " (c1, c2) SELECT PARSE_JSON(?), TO_OBJECT(PARSE_JSON(?))"
+	// values wrapped in sf.Variant, or implementing json.Marshaler, are bound as VARIANT,
+	// so there is no need to marshal them and call PARSE_JSON
+	insertQuery := "INSERT INTO " + tablename + " (c1, c2) SELECT ?, TO_OBJECT(?)"
 	// https://docs.snowflake.com/en/sql-reference/data-types-semistructured#object
 	insertOnlyObject := "INSERT INTO " + tablename + " (c2) SELECT OBJECT_CONSTRUCT('name', 'Jones'::VARIANT, 'age',  42::VARIANT)"
 
@@ -83,8 +79,8 @@ func main() {
 	}()
 	fmt.Printf("Inserting VARIANT and OBJECT data into table: %v\n", insertQuery)
 	_, err = conn.ExecContext(ctx, insertQuery,
-		string(jsonStr),
-		string(jsonStr),
+		sf.NewVariant(param),
+		sf.NewVariant(param),
 	)
 	if err != nil {
 		log.Fatalf("failed to run the query. %v, err: %v", insertQuery, err)
@@ -101,13 +97,15 @@ func main() {
 		log.Fatalf("failed to run the query. %v, err: %v", selectQuery, err)
 	}
 	defer rows.Close()
-	var c1, c2 any
+	// VARIANT and OBJECT values are decoded from JSON when scanned into sf.Variant, NULL values are not valid
+	var c1 sf.Variant[map[string]string]
+	var c2 sf.Variant[json.RawMessage]
 	for rows.Next() {
 		err := rows.Scan(&c1, &c2)
 		if err != nil {
 			log.Fatalf("failed to get result. err: %v", err)
 		}
-		fmt.Printf("%v (type: %T), %v (type: %T)\n", c1, c1, c2, c2)
+		fmt.Printf("%v (valid: %v), %s (valid: %v)\n", c1.V, c1.Valid, c2.V, c2.Valid)
 	}
 	if rows.Err() != nil {
 		fmt.Printf("ERROR: %v\n", rows.Err())
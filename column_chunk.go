@@ -0,0 +1,337 @@
+package gosnowflake
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	errors2 "github.com/snowflakedb/gosnowflake/v2/internal/errors"
+	"github.com/snowflakedb/gosnowflake/v2/internal/query"
+	"github.com/snowflakedb/gosnowflake/v2/internal/types"
+)
+
+// Column is a typed column of a ColumnChunk.
+// Null values have the zero value in Values.
+type Column[T any] struct {
+	Values []T
+	// Valid is false for null values. It is nil if the column has no null values.
+	Valid []bool
+}
+
+// IsNull returns true if the value at row i is null.
+func (c Column[T]) IsNull(i int) bool {
+	return c.Valid != nil && !c.Valid[i]
+}
+
+// ColumnChunkReader is implemented by the rows of the driver and reads the result as typed columns.
+//
+//	if r, ok := rows.(ColumnChunkReader); ok {
+//	    chunk, err := r.NextColumnChunk()
+//	    ...
+//	}
+type ColumnChunkReader interface {
+	// NextColumnChunk returns the next part of the result as typed columns.
+	// The query has to be run with WithColumnarResults.
+	// Returns io.EOF if there are no more rows.
+	NextColumnChunk() (*ColumnChunk, error)
+}
+
+// ColumnChunk is a part of a result read with ColumnChunkReader.NextColumnChunk.
+// The columns are converted to typed vectors using the same rules as the rows
+// read with database/sql, without boxing every value.
+//
+// The column values may share memory with the downloaded chunk, so they are
+// only valid until the next call of NextColumnChunk or Close.
+type ColumnChunk struct {
+	record   arrow.Record
+	rowTypes []query.ExecResponseRowType
+	loc      *time.Location
+	tzLoc    *time.Location
+}
+
+// NumRows returns the number of rows in the chunk.
+func (cc *ColumnChunk) NumRows() int {
+	return int(cc.record.NumRows())
+}
+
+// NumColumns returns the number of columns in the chunk.
+func (cc *ColumnChunk) NumColumns() int {
+	return int(cc.record.NumCols())
+}
+
+// Int64s returns the values of a NUMBER column with scale 0.
+func (cc *ColumnChunk) Int64s(idx int) (Column[int64], error) {
+	col, rowType, err := cc.column(idx)
+	if err != nil {
+		return Column[int64]{}, err
+	}
+	if types.GetSnowflakeType(rowType.Type) != types.FixedType || rowType.Scale != 0 {
+		return Column[int64]{}, errColumnType(idx, rowType, "int64")
+	}
+	switch arr := col.(type) {
+	case *array.Int64:
+		return Column[int64]{Values: arr.Int64Values(), Valid: columnValidity(arr)}, nil
+	case *array.Int32:
+		return convertColumn(arr, func(i int) int64 { return int64(arr.Value(i)) }), nil
+	case *array.Int16:
+		return convertColumn(arr, func(i int) int64 { return int64(arr.Value(i)) }), nil
+	case *array.Int8:
+		return convertColumn(arr, func(i int) int64 { return int64(arr.Value(i)) }), nil
+	case *array.Decimal128:
+		for i := range arr.Len() {
+			if arr.IsValid(i) && !decimal128FitsInt64(arr.Value(i)) {
+				return Column[int64]{}, fmt.Errorf("value %v of column %v does not fit in int64", arr.Value(i).BigInt(), idx)
+			}
+		}
+		return convertColumn(arr, func(i int) int64 { return int64(arr.Value(i).LowBits()) }), nil
+	}
+	return Column[int64]{}, errColumnType(idx, rowType, "int64")
+}
+
+// Float64s returns the values of a FLOAT or NUMBER column.
+func (cc *ColumnChunk) Float64s(idx int) (Column[float64], error) {
+	col, rowType, err := cc.column(idx)
+	if err != nil {
+		return Column[float64]{}, err
+	}
+	switch types.GetSnowflakeType(rowType.Type) {
+	case types.RealType:
+		if arr, ok := col.(*array.Float64); ok {
+			return Column[float64]{Values: arr.Float64Values(), Valid: columnValidity(arr)}, nil
+		}
+	case types.FixedType:
+		scale := math.Pow10(int(rowType.Scale))
+		switch arr := col.(type) {
+		case *array.Int64:
+			return convertColumn(arr, func(i int) float64 { return float64(arr.Value(i)) / scale }), nil
+		case *array.Int32:
+			return convertColumn(arr, func(i int) float64 { return float64(arr.Value(i)) / scale }), nil
+		case *array.Int16:
+			return convertColumn(arr, func(i int) float64 { return float64(arr.Value(i)) / scale }), nil
+		case *array.Int8:
+			return convertColumn(arr, func(i int) float64 { return float64(arr.Value(i)) / scale }), nil
+		case *array.Decimal128:
+			return convertColumn(arr, func(i int) float64 { return arr.Value(i).ToFloat64(int32(rowType.Scale)) }), nil
+		}
+	}
+	return Column[float64]{}, errColumnType(idx, rowType, "float64")
+}
+
+// Decimals returns the exact values of a NUMBER column, built from the unscaled integers and the scale of the column.
+func (cc *ColumnChunk) Decimals(idx int) (Column[Decimal], error) {
+	col, rowType, err := cc.column(idx)
+	if err != nil {
+		return Column[Decimal]{}, err
+	}
+	if types.GetSnowflakeType(rowType.Type) != types.FixedType {
+		return Column[Decimal]{}, errColumnType(idx, rowType, "Decimal")
+	}
+	scale := int32(rowType.Scale)
+	switch arr := col.(type) {
+	case *array.Int64:
+		return convertColumn(arr, func(i int) Decimal { return NewDecimal(big.NewInt(arr.Value(i)), scale) }), nil
+	case *array.Int32:
+		return convertColumn(arr, func(i int) Decimal { return NewDecimal(big.NewInt(int64(arr.Value(i))), scale) }), nil
+	case *array.Int16:
+		return convertColumn(arr, func(i int) Decimal { return NewDecimal(big.NewInt(int64(arr.Value(i))), scale) }), nil
+	case *array.Int8:
+		return convertColumn(arr, func(i int) Decimal { return NewDecimal(big.NewInt(int64(arr.Value(i))), scale) }), nil
+	case *array.Decimal128:
+		return convertColumn(arr, func(i int) Decimal { return NewDecimal(arr.Value(i).BigInt(), scale) }), nil
+	}
+	return Column[Decimal]{}, errColumnType(idx, rowType, "Decimal")
+}
+
+// Strings returns the values of a TEXT column or of a semi-structured column as JSON.
+func (cc *ColumnChunk) Strings(idx int) (Column[string], error) {
+	col, rowType, err := cc.column(idx)
+	if err != nil {
+		return Column[string]{}, err
+	}
+	switch types.GetSnowflakeType(rowType.Type) {
+	case types.TextType, types.VariantType, types.ObjectType, types.ArrayType, types.MapType:
+		if arr, ok := col.(*array.String); ok {
+			return convertColumn(arr, arr.Value), nil
+		}
+	}
+	return Column[string]{}, errColumnType(idx, rowType, "string")
+}
+
+// Bools returns the values of a BOOLEAN column.
+func (cc *ColumnChunk) Bools(idx int) (Column[bool], error) {
+	col, rowType, err := cc.column(idx)
+	if err != nil {
+		return Column[bool]{}, err
+	}
+	if arr, ok := col.(*array.Boolean); ok && types.GetSnowflakeType(rowType.Type) == types.BooleanType {
+		return convertColumn(arr, arr.Value), nil
+	}
+	return Column[bool]{}, errColumnType(idx, rowType, "bool")
+}
+
+// Binaries returns the values of a BINARY column.
+func (cc *ColumnChunk) Binaries(idx int) (Column[[]byte], error) {
+	col, rowType, err := cc.column(idx)
+	if err != nil {
+		return Column[[]byte]{}, err
+	}
+	if arr, ok := col.(*array.Binary); ok && types.GetSnowflakeType(rowType.Type) == types.BinaryType {
+		return convertColumn(arr, arr.Value), nil
+	}
+	return Column[[]byte]{}, errColumnType(idx, rowType, "[]byte")
+}
+
+// Times returns the values of a DATE, TIME or TIMESTAMP column.
+// TIMESTAMP_LTZ values are in the session time zone, TIMESTAMP_TZ values are
+// in the location set with WithTimestampTzLocation if it had the same offset.
+func (cc *ColumnChunk) Times(idx int) (Column[time.Time], error) {
+	col, rowType, err := cc.column(idx)
+	if err != nil {
+		return Column[time.Time]{}, err
+	}
+	switch sfType := types.GetSnowflakeType(rowType.Type); sfType {
+	case types.DateType:
+		if arr, ok := col.(*array.Date32); ok {
+			return convertColumn(arr, func(i int) time.Time { return arrowDateToTime(arr, i) }), nil
+		}
+	case types.TimeType:
+		return convertColumn(col, func(i int) time.Time { return arrowTimeToTime(col, i, int(rowType.Scale)) }), nil
+	case types.TimestampNtzType, types.TimestampLtzType:
+		return convertColumn(col, func(i int) time.Time {
+			return arrowSnowflakeTimestampValue(col, sfType, int(rowType.Scale), i, cc.loc)
+		}), nil
+	case types.TimestampTzType:
+		return convertColumn(col, func(i int) time.Time {
+			return timestampTzInLocation(arrowSnowflakeTimestampValue(col, sfType, int(rowType.Scale), i, cc.loc), cc.tzLoc)
+		}), nil
+	}
+	return Column[time.Time]{}, errColumnType(idx, rowType, "time.Time")
+}
+
+func (cc *ColumnChunk) column(idx int) (arrow.Array, query.ExecResponseRowType, error) {
+	if idx < 0 || idx >= cc.NumColumns() || idx >= len(cc.rowTypes) {
+		return nil, query.ExecResponseRowType{}, fmt.Errorf("column index %v out of range, number of columns: %v", idx, cc.NumColumns())
+	}
+	return cc.record.Column(idx), cc.rowTypes[idx], nil
+}
+
+func errColumnType(idx int, rowType query.ExecResponseRowType, goType string) error {
+	return fmt.Errorf("column %v of type %v cannot be read as %v", idx, rowType.Type, goType)
+}
+
+// convertColumn builds the column calling value for the not null rows.
+func convertColumn[T any](arr arrow.Array, value func(i int) T) Column[T] {
+	column := Column[T]{Values: make([]T, arr.Len()), Valid: columnValidity(arr)}
+	for i := range column.Values {
+		if column.Valid == nil || column.Valid[i] {
+			column.Values[i] = value(i)
+		}
+	}
+	return column
+}
+
+func decimal128FitsInt64(num decimal128.Num) bool {
+	return (num.HighBits() == 0 && num.LowBits() <= math.MaxInt64) ||
+		(num.HighBits() == -1 && num.LowBits() > math.MaxInt64)
+}
+
+func columnValidity(arr arrow.Array) []bool {
+	if arr.NullN() == 0 {
+		return nil
+	}
+	valid := make([]bool, arr.Len())
+	for i := range valid {
+		valid[i] = arr.IsValid(i)
+	}
+	return valid
+}
+
+func columnarResultsEnabled(ctx context.Context) bool {
+	v, ok := ctx.Value(columnarResults).(bool)
+	return ok && v
+}
+
+func errColumnarResultsEnabled() *SnowflakeError {
+	return &SnowflakeError{
+		Number:  ErrColumnarResultsEnabled,
+		Message: errors2.ErrMsgColumnarResultsEnabled,
+	}
+}
+
+func errColumnarResultsNotEnabled(queryID string) *SnowflakeError {
+	return &SnowflakeError{
+		QueryID: queryID,
+		Number:  ErrColumnarResultsNotEnabled,
+		Message: errors2.ErrMsgColumnarResultsNotEnabled,
+	}
+}
+
+// nextColumnChunk returns the next arrow record of the result, waiting for the chunk downloads.
+func (scd *snowflakeChunkDownloader) nextColumnChunk() (*ColumnChunk, error) {
+	for {
+		scd.CurrentRecordIndex++
+		if scd.CurrentRecordIndex < len(scd.CurrentRecords) {
+			params, err := scd.getConfigParams()
+			if err != nil {
+				return nil, fmt.Errorf("getting config params: %w", err)
+			}
+			return &ColumnChunk{
+				record:   scd.CurrentRecords[scd.CurrentRecordIndex],
+				rowTypes: scd.RowSet.RowType,
+				loc:      getCurrentLocation(params),
+				tzLoc:    getTimestampTzLocation(scd.ctx),
+			}, nil
+		}
+		// the records of the previous chunk are not used anymore
+		releaseRecords(scd.CurrentRecords)
+		scd.CurrentRecords = nil
+		scd.CurrentRecordIndex = -1
+		if err := scd.nextChunk(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// releaseColumnRecords releases the records of the chunks which have not been read.
+func (scd *snowflakeChunkDownloader) releaseColumnRecords() {
+	releaseRecords(scd.CurrentRecords)
+	scd.CurrentRecords = nil
+	if scd.ChunksMutex == nil {
+		return
+	}
+	scd.ChunksMutex.Lock()
+	defer scd.ChunksMutex.Unlock()
+	for idx, records := range scd.RecordChunks {
+		releaseRecords(records)
+		delete(scd.RecordChunks, idx)
+	}
+}
+
+func releaseRecords(records []arrow.Record) {
+	for _, rec := range records {
+		rec.Release()
+	}
+}
+
+// NextColumnChunk returns the next part of the result as typed columns. It requires
+// the query to be run with WithColumnarResults. It returns io.EOF if there are no more rows.
+func (rows *snowflakeRows) NextColumnChunk() (*ColumnChunk, error) {
+	if err := rows.waitForAsyncQueryStatus(); err != nil {
+		return nil, err
+	}
+	scd, ok := rows.ChunkDownloader.(*snowflakeChunkDownloader)
+	if !ok || !scd.columnar {
+		return nil, exceptionTelemetry(errColumnarResultsNotEnabled(rows.queryID), rows.sc)
+	}
+	cc, err := scd.nextColumnChunk()
+	if err == io.EOF {
+		rows.ChunkDownloader.reset()
+	}
+	return cc, err
+}
@@ -0,0 +1,153 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/snowflakedb/gosnowflake/v2/internal/query"
+)
+
+func TestColumnChunkTypedColumns(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer pool.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "int", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "scaled", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "big", Type: &arrow.Decimal128Type{Precision: 38, Scale: 0}},
+		{Name: "real", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "text", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "bool", Type: arrow.FixedWidthTypes.Boolean},
+		{Name: "date", Type: arrow.FixedWidthTypes.Date32},
+		{Name: "ntz", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	bldr := array.NewRecordBuilder(pool, schema)
+	defer bldr.Release()
+	bldr.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 0}, []bool{true, false})
+	bldr.Field(1).(*array.Int32Builder).AppendValues([]int32{1234, -5}, nil)
+	bldr.Field(2).(*array.Decimal128Builder).AppendValues([]decimal128.Num{decimal128.FromI64(-7), decimal128.FromI64(1 << 62)}, nil)
+	bldr.Field(3).(*array.Float64Builder).AppendValues([]float64{1.5, 2.5}, nil)
+	bldr.Field(4).(*array.StringBuilder).AppendValues([]string{"", "abc"}, []bool{false, true})
+	bldr.Field(5).(*array.BooleanBuilder).AppendValues([]bool{true, false}, nil)
+	bldr.Field(6).(*array.Date32Builder).AppendValues([]arrow.Date32{0, 19000}, nil)
+	bldr.Field(7).(*array.Int64Builder).AppendValues([]int64{1700000000123456789, 0}, nil)
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	cc := &ColumnChunk{
+		record: rec,
+		rowTypes: []query.ExecResponseRowType{
+			{Type: "fixed"}, {Type: "fixed", Scale: 2}, {Type: "fixed", Precision: 38}, {Type: "real"},
+			{Type: "text"}, {Type: "boolean"}, {Type: "date"}, {Type: "timestamp_ntz", Scale: 9},
+		},
+		loc: time.UTC,
+	}
+	assertEqualE(t, cc.NumRows(), 2)
+	assertEqualE(t, cc.NumColumns(), 8)
+
+	ints, err := cc.Int64s(0)
+	assertNilF(t, err)
+	assertEqualE(t, ints.Values[0], int64(1))
+	assertFalseE(t, ints.IsNull(0))
+	assertTrueE(t, ints.IsNull(1))
+
+	big, err := cc.Int64s(2)
+	assertNilF(t, err)
+	assertDeepEqualE(t, big.Values, []int64{-7, 1 << 62})
+	assertTrueE(t, big.Valid == nil, "column without nulls should not have validity")
+
+	_, err = cc.Int64s(1)
+	assertNotNilE(t, err, "scaled number should not be read as int64")
+	floats, err := cc.Float64s(1)
+	assertNilF(t, err)
+	assertDeepEqualE(t, floats.Values, []float64{12.34, -0.05})
+	decimals, err := cc.Decimals(1)
+	assertNilF(t, err)
+	assertEqualE(t, decimals.Values[0].String(), "12.34")
+	assertEqualE(t, decimals.Values[1].String(), "-0.05")
+	decimals, err = cc.Decimals(2)
+	assertNilF(t, err)
+	assertEqualE(t, decimals.Values[1].String(), "4611686018427387904")
+
+	reals, err := cc.Float64s(3)
+	assertNilF(t, err)
+	assertDeepEqualE(t, reals.Values, []float64{1.5, 2.5})
+
+	texts, err := cc.Strings(4)
+	assertNilF(t, err)
+	assertTrueE(t, texts.IsNull(0))
+	assertEqualE(t, texts.Values[1], "abc")
+	_, err = cc.Strings(0)
+	assertNotNilE(t, err, "number should not be read as string")
+
+	bools, err := cc.Bools(5)
+	assertNilF(t, err)
+	assertDeepEqualE(t, bools.Values, []bool{true, false})
+
+	dates, err := cc.Times(6)
+	assertNilF(t, err)
+	assertTrueE(t, dates.Values[1].Equal(time.Date(2022, 1, 8, 0, 0, 0, 0, time.UTC)))
+
+	timestamps, err := cc.Times(7)
+	assertNilF(t, err)
+	assertTrueE(t, timestamps.Values[0].Equal(time.Unix(1700000000, 123456789)))
+
+	_, err = cc.Times(8)
+	assertNotNilE(t, err, "column index should be checked")
+}
+
+func TestNextColumnChunk(t *testing.T) {
+	arrowBytes := buildArrowChunkBytes(t)
+	ctx := WithColumnarResults(context.Background())
+	scd := newCancelableTestChunkDownloader(ctx, nil)
+	scd.pool = memory.DefaultAllocator
+	scd.QueryResultFormat = "arrow"
+	scd.RowSet = rowSetType{
+		RowType:      []query.ExecResponseRowType{{Name: "ID", Type: "fixed"}},
+		RowSetBase64: base64.StdEncoding.EncodeToString(arrowBytes),
+	}
+	scd.FuncGet = func(context.Context, *snowflakeConn, string, map[string]string, time.Duration) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: &fakeResponseBody{body: arrowBytes}}, nil
+	}
+	assertNilF(t, scd.start())
+	var rows driver.Rows = &snowflakeRows{sc: scd.sc, ChunkDownloader: scd}
+	scd.sc.ctx = ctx
+	reader, ok := rows.(ColumnChunkReader)
+	assertTrueF(t, ok, "driver rows should implement ColumnChunkReader")
+
+	var se *SnowflakeError
+	err := rows.Next(make([]driver.Value, 1))
+	assertErrorsAsF(t, err, &se)
+	assertEqualE(t, se.Number, ErrColumnarResultsEnabled)
+
+	for range 2 {
+		cc, err := reader.NextColumnChunk()
+		assertNilF(t, err)
+		ids, err := cc.Int64s(0)
+		assertNilF(t, err)
+		assertDeepEqualE(t, ids.Values, []int64{1})
+	}
+	_, err = reader.NextColumnChunk()
+	assertErrIsE(t, err, io.EOF)
+	assertNilE(t, rows.Close())
+}
+
+func TestNextColumnChunkNotEnabled(t *testing.T) {
+	scd := newCancelableTestChunkDownloader(context.Background(), nil)
+	scd.ChunkMetas = nil
+	assertNilF(t, scd.start())
+	var rows driver.Rows = &snowflakeRows{sc: scd.sc, ChunkDownloader: scd}
+	_, err := rows.(ColumnChunkReader).NextColumnChunk()
+	var se *SnowflakeError
+	assertErrorsAsF(t, err, &se)
+	assertEqualE(t, se.Number, ErrColumnarResultsNotEnabled)
+}
@@ -6,6 +6,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -47,6 +48,7 @@ const (
 	sessionClientValidateDefaultParameters          = "CLIENT_VALIDATE_DEFAULT_PARAMETERS"
 	sessionArrayBindStageThreshold                  = "client_stage_array_binding_threshold"
 	serviceName                                     = "service_name"
+	sessionStatementTimeoutInSeconds                = "statement_timeout_in_seconds"
 )
 
 type resultType string
@@ -55,6 +57,7 @@ const (
 	snowflakeResultType ContextKey = "snowflakeResultType"
 	execResultType      resultType = "exec"
 	queryResultType     resultType = "query"
+	asyncResultType     resultType = "async"
 )
 
 type execKey string
@@ -79,6 +82,10 @@ type snowflakeConn struct {
 	syncParams          syncParams
 	idToken             string
 	mfaToken            string
+	defaultSession      sessionState
+	defaultParams       map[string]string
+	changedParams       map[string]bool
+	unknownParamChanged bool
 }
 
 var (
@@ -94,6 +101,12 @@ func (sc *snowflakeConn) exec(
 	describeOnly bool,
 	bindings []driver.NamedValue) (
 	*execResponse, error) {
+	if hasNamedBindings(bindings) {
+		var err error
+		if query, bindings, err = sc.bindNamedParameters(query, bindings); err != nil {
+			return nil, err
+		}
+	}
 	if sc.cfg.LogQueryText || isLogQueryTextEnabled(ctx) {
 		if len(bindings) > 0 && (sc.cfg.LogQueryParameters || isLogQueryParametersEnabled(ctx)) {
 			logger.WithContext(ctx).Infof("Executing query: %v with bindings: %v", query, bindings)
@@ -121,12 +134,18 @@ func (sc *snowflakeConn) exec(
 		SequenceID:   counter,
 		QueryContext: queryContext,
 	}
+	if params, ok := ctx.Value(queryParameters).(map[string]any); ok {
+		maps.Copy(req.Parameters, params)
+	}
 	if key := ctx.Value(multiStatementCount); key != nil {
 		req.Parameters[string(multiStatementCount)] = key
 	}
 	if tag := ctx.Value(queryTag); tag != nil {
 		req.Parameters[string(queryTag)] = tag
 	}
+	if !noResult {
+		sc.setStatementTimeoutFromDeadline(ctx, req.Parameters)
+	}
 	logger.WithContext(ctx).Debugf("parameters: %v", req.Parameters)
 
 	// handle bindings, if required
@@ -159,6 +178,9 @@ func (sc *snowflakeConn) exec(
 
 	data, err := sc.rest.FuncPostQuery(ctx, sc.rest, &url.Values{}, headers,
 		jsonBody, sc.rest.RequestTimeout, requestID, sc.cfg)
+	if !isInternal {
+		recordInterceptedQueryID(ctx, data)
+	}
 	if err != nil {
 		return data, err
 	}
@@ -205,19 +227,14 @@ func (sc *snowflakeConn) exec(
 	}
 
 	logger.WithContext(ctx).Debugf("Exec/Query: queryId=%v SUCCESS with total=%v, returned=%v ", data.Data.QueryID, data.Data.Total, data.Data.Returned)
-	if data.Data.FinalDatabaseName != "" {
-		sc.cfg.Database = data.Data.FinalDatabaseName
-	}
-	if data.Data.FinalSchemaName != "" {
-		sc.cfg.Schema = data.Data.FinalSchemaName
-	}
-	if data.Data.FinalWarehouseName != "" {
-		sc.cfg.Warehouse = data.Data.FinalWarehouseName
-	}
-	if data.Data.FinalRoleName != "" {
-		sc.cfg.Role = data.Data.FinalRoleName
-	}
+	sc.updateSessionState(sessionState{
+		database:  data.Data.FinalDatabaseName,
+		schema:    data.Data.FinalSchemaName,
+		warehouse: data.Data.FinalWarehouseName,
+		role:      data.Data.FinalRoleName,
+	})
 	sc.populateSessionParameters(data.Data.Parameters)
+	sc.trackChangedParameters(query)
 	return data, err
 }
 
@@ -298,6 +315,7 @@ func (sc *snowflakeConn) Close() (err error) {
 	}
 	sc.stopHeartBeat()
 	sc.rest.HeartBeat = nil
+	sc.rest.sessionInvalid.Store(true)
 	defer sc.cleanup()
 
 	if sc.cfg != nil && !sc.cfg.ServerSessionKeepAlive {
@@ -321,13 +339,44 @@ func (sc *snowflakeConn) PrepareContext(
 		return nil, driver.ErrBadConn
 	}
 	stmt := &snowflakeStmt{
-		sc:    sc,
-		query: query,
+		sc:       sc,
+		query:    query,
+		numInput: -1,
+	}
+	if err := stmt.describe(ctx); err != nil {
+		return nil, err
 	}
 	return stmt, nil
 }
 
 func (sc *snowflakeConn) ExecContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue) (
+	driver.Result, error) {
+	if !sc.shouldIntercept(ctx) {
+		return sc.execContext(ctx, query, args)
+	}
+	q, err := sc.intercept(ctx, QueryKindExec, query, args, func(ctx context.Context, q *InterceptedQuery) (err error) {
+		if q.Result, err = sc.execContext(ctx, q.SQL, q.Bindings); err != nil {
+			return err
+		}
+		// the async result waits for the query to finish
+		if q.Async {
+			return nil
+		}
+		if rowsAffected, err := q.Result.RowsAffected(); err == nil {
+			q.RowsAffected = rowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return q.Result, nil
+}
+
+func (sc *snowflakeConn) execContext(
 	ctx context.Context,
 	query string,
 	args []driver.NamedValue) (
@@ -402,6 +451,16 @@ func (sc *snowflakeConn) QueryContext(
 	if err != nil {
 		return nil, err
 	}
+	if qid == "" && sc.shouldIntercept(ctx) {
+		q, err := sc.intercept(ctx, QueryKindQuery, query, args, func(ctx context.Context, q *InterceptedQuery) (err error) {
+			q.Rows, err = sc.queryContextInternal(ctx, q.SQL, q.Bindings)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return q.Rows, nil
+	}
 	if qid == "" {
 		return sc.queryContextInternal(ctx, query, args)
 	}
@@ -507,7 +566,7 @@ func (sc *snowflakeConn) Ping(ctx context.Context) error {
 // CheckNamedValue determines which types are handled by this driver aside from
 // the instances captured by driver.Value
 func (sc *snowflakeConn) CheckNamedValue(nv *driver.NamedValue) error {
-	if supportedNullBind(nv) || supportedDecfloatBind(nv) || supportedArrayBind(nv) || supportedStructuredObjectWriterBind(nv) || supportedStructuredArrayBind(nv) || supportedStructuredMapBind(nv) {
+	if supportedNullBind(nv) || supportedDecfloatBind(nv) || supportedArrayBind(nv) || supportedRowSourceBind(nv) || supportedStructuredObjectWriterBind(nv) || supportedStructuredArrayBind(nv) || supportedStructuredMapBind(nv) || supportedVariantBind(nv) {
 		return nil
 	}
 	return driver.ErrSkip
@@ -521,15 +580,26 @@ func (sc *snowflakeConn) GetQueryStatus(
 	if err != nil {
 		return nil, err
 	}
-	return &SnowflakeQueryStatus{
-		queryRet.SQLText,
-		queryRet.StartTime,
-		queryRet.EndTime,
-		queryRet.ErrorCode,
-		queryRet.ErrorMessage,
-		queryRet.Stats.ScanBytes,
-		queryRet.Stats.ProducedRows,
-	}, nil
+	return newSnowflakeQueryStatus(queryRet), nil
+}
+
+// QueryCanceller is implemented by the connections of the driver and aborts queries given their IDs.
+type QueryCanceller interface {
+	CancelQuery(ctx context.Context, queryID string) (bool, error)
+}
+
+// CancelQuery aborts the query given the query ID. The query can be submitted by another connection
+// or process of the same user and role. It returns true if the status of the query confirms it was aborted,
+// and false if the query completed before or is still running.
+func (sc *snowflakeConn) CancelQuery(ctx context.Context, queryID string) (bool, error) {
+	if err := validateQueryID(queryID); err != nil {
+		return false, err
+	}
+	logger.WithContext(ctx).Infof("cancel query %v", queryID)
+	if err := sc.abortQuery(ctx, queryID); err != nil {
+		return false, err
+	}
+	return sc.waitForQueryAbort(ctx, queryID)
 }
 
 func (sc *snowflakeConn) AddTelemetryData(_ context.Context, eventDate time.Time, data map[string]string) error {
@@ -546,6 +616,18 @@ func (sc *snowflakeConn) AddTelemetryData(_ context.Context, eventDate time.Time
 // also implements QueryResultFormatProvider; callers should check the
 // format before passing batch streams to ipc.NewReader.
 func (sc *snowflakeConn) QueryArrowStream(ctx context.Context, query string, bindings ...driver.NamedValue) (ArrowStreamLoader, error) {
+	if !sc.shouldIntercept(ctx) {
+		return sc.queryArrowStream(ctx, query, bindings...)
+	}
+	var loader ArrowStreamLoader
+	_, err := sc.intercept(ctx, QueryKindArrowStream, query, bindings, func(ctx context.Context, q *InterceptedQuery) (err error) {
+		loader, err = sc.queryArrowStream(ctx, q.SQL, q.Bindings...)
+		return err
+	})
+	return loader, err
+}
+
+func (sc *snowflakeConn) queryArrowStream(ctx context.Context, query string, bindings ...driver.NamedValue) (ArrowStreamLoader, error) {
 	ctx = ia.EnableArrowBatches(context.WithValue(ctx, asyncMode, false))
 	ctx = setResultType(ctx, queryResultType)
 	isDesc := isDescribeOnly(ctx)
@@ -568,29 +650,34 @@ func (sc *snowflakeConn) QueryArrowStream(ctx context.Context, query string, bin
 		return nil, err
 	}
 
+	return newArrowStreamChunkDownloader(ctx, sc, data.Data)
+}
+
+// newArrowStreamChunkDownloader creates the ArrowStreamLoader for the query result.
+func newArrowStreamChunkDownloader(ctx context.Context, sc *snowflakeConn, data execResponseData) (ArrowStreamLoader, error) {
 	var resultIDs []string
-	if len(data.Data.ResultIDs) > 0 {
-		resultIDs = strings.Split(data.Data.ResultIDs, ",")
+	if len(data.ResultIDs) > 0 {
+		resultIDs = strings.Split(data.ResultIDs, ",")
 	}
 
 	scd := &snowflakeArrowStreamChunkDownloader{
 		sc:                sc,
-		ChunkMetas:        data.Data.Chunks,
-		Total:             data.Data.Total,
-		Qrmk:              data.Data.Qrmk,
-		ChunkHeader:       data.Data.ChunkHeaders,
+		ChunkMetas:        data.Chunks,
+		Total:             data.Total,
+		Qrmk:              data.Qrmk,
+		ChunkHeader:       data.ChunkHeaders,
 		FuncGet:           getChunk,
-		queryResultFormat: data.Data.QueryResultFormat,
+		queryResultFormat: data.QueryResultFormat,
 		RowSet: rowSetType{
-			RowType:      data.Data.RowType,
-			JSON:         data.Data.RowSet,
-			RowSetBase64: data.Data.RowSetBase64,
+			RowType:      data.RowType,
+			JSON:         data.RowSet,
+			RowSetBase64: data.RowSetBase64,
 		},
 		resultIDs: resultIDs,
 	}
 
 	if scd.hasNextResultSet() {
-		if err = scd.NextResultSet(ctx); err != nil {
+		if err := scd.NextResultSet(ctx); err != nil {
 			return nil, err
 		}
 	}
@@ -661,6 +748,7 @@ func buildSnowflakeConn(ctx context.Context, config Config) (*snowflakeConn, err
 		FuncPostAuthSAML:    postAuthSAML,
 		FuncPostAuthOKTA:    postAuthOKTA,
 		FuncGetSSO:          getSSO,
+		instrumentation:     newInstrumentation(sc.cfg),
 	}
 
 	telemetry.sr = sc.rest
@@ -0,0 +1,247 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// sessionState is the database, schema, warehouse and role used by the session.
+type sessionState struct {
+	database  string
+	schema    string
+	warehouse string
+	role      string
+}
+
+// initSessionState saves the session state and the session parameters after the login,
+// so they can be restored when the connection is returned to the pool.
+func (sc *snowflakeConn) initSessionState(info authResponseSessionInfo) {
+	sc.updateSessionState(sessionState{
+		database:  info.DatabaseName,
+		schema:    info.SchemaName,
+		warehouse: info.WarehouseName,
+		role:      info.RoleName,
+	})
+	sc.defaultSession = sc.currentSessionState()
+	sc.defaultParams = maps.Collect(sc.syncParams.All())
+}
+
+// updateSessionState applies the not empty names returned by the server to the config.
+func (sc *snowflakeConn) updateSessionState(state sessionState) {
+	if state.database != "" {
+		sc.cfg.Database = state.database
+	}
+	if state.schema != "" {
+		sc.cfg.Schema = state.schema
+	}
+	if state.warehouse != "" {
+		sc.cfg.Warehouse = state.warehouse
+	}
+	if state.role != "" {
+		sc.cfg.Role = state.role
+	}
+}
+
+func (sc *snowflakeConn) currentSessionState() sessionState {
+	return sessionState{
+		database:  sc.cfg.Database,
+		schema:    sc.cfg.Schema,
+		warehouse: sc.cfg.Warehouse,
+		role:      sc.cfg.Role,
+	}
+}
+
+// ResetSession is called by database/sql before a pooled connection is reused.
+// It restores the role, warehouse, database and schema of the session after the login,
+// unsets the session parameters changed since the login, and returns driver.ErrBadConn
+// if the session cannot be used or restored, so the connection is discarded.
+func (sc *snowflakeConn) ResetSession(ctx context.Context) error {
+	if !sc.IsValid() {
+		logger.WithContext(ctx).Info("discarding connection with invalid session")
+		return driver.ErrBadConn
+	}
+	statements, err := sessionResetStatements(sc.currentSessionState(), sc.defaultSession)
+	if err != nil {
+		logger.WithContext(ctx).Infof("discarding connection. %v", err)
+		return driver.ErrBadConn
+	}
+	params, err := sc.changedSessionParameters()
+	if err != nil {
+		logger.WithContext(ctx).Infof("discarding connection. %v", err)
+		return driver.ErrBadConn
+	}
+	if len(params) > 0 {
+		statements = append(statements, "ALTER SESSION UNSET "+strings.Join(params, ", "))
+	}
+	for _, statement := range statements {
+		if _, err = sc.exec(WithInternal(ctx), statement, false, true, false, nil); err != nil {
+			logger.WithContext(ctx).Warnf("discarding connection, failed to reset the session. %v", err)
+			return driver.ErrBadConn
+		}
+	}
+	for _, name := range params {
+		if value, ok := sc.defaultParams[name]; ok {
+			sc.syncParams.set(name, &value)
+		} else {
+			sc.syncParams.delete(name)
+		}
+	}
+	sc.changedParams = nil
+	return nil
+}
+
+// IsValid is called by database/sql before a connection is returned to the pool.
+// It reports false if the connection is closed, its session expired and cannot be renewed,
+// or the heartbeat reported that the session is gone.
+func (sc *snowflakeConn) IsValid() bool {
+	return sc.rest != nil && !sc.rest.sessionInvalid.Load()
+}
+
+var (
+	alterSessionRegexp          = regexp.MustCompile(`(?i)\bALTER\s+SESSION\b`)
+	alterSessionStatementRegexp = regexp.MustCompile(`(?is)^\s*ALTER\s+SESSION\s+(SET|UNSET)\s+(.*?)[\s;]*$`)
+	sessionParameterSetRegexp   = regexp.MustCompile(`(?:^|,)\s*([A-Za-z_][\w$]*)\s*=`)
+	sessionParameterNameRegexp  = regexp.MustCompile(`^[A-Za-z_][\w$]*$`)
+)
+
+// trackChangedParameters records the session parameters changed by the ALTER SESSION query,
+// so ResetSession can unset them, including the ones Snowflake does not return in the responses.
+func (sc *snowflakeConn) trackChangedParameters(query string) {
+	query = removeLiteralsAndComments(query)
+	if !alterSessionRegexp.MatchString(query) {
+		return
+	}
+	names, ok := alterSessionParameterNames(query)
+	if !ok {
+		// e.g. ALTER SESSION in a multi-statement query or a Snowflake Scripting block
+		sc.unknownParamChanged = true
+		return
+	}
+	if sc.changedParams == nil {
+		sc.changedParams = make(map[string]bool)
+	}
+	for _, name := range names {
+		sc.changedParams[strings.ToLower(name)] = true
+	}
+}
+
+// removeLiteralsAndComments replaces the string literals and quoted identifiers of the query with empty ones
+// and the comments with spaces, so the statements are parsed without their contents.
+func removeLiteralsAndComments(query string) string {
+	var b strings.Builder
+	for i := 0; i < len(query); {
+		end := i + 1
+		switch c := query[i]; {
+		case c == '\'' || c == '"':
+			end = skipQuoted(query, i+1, c)
+			b.Write([]byte{c, c})
+		case strings.HasPrefix(query[i:], "$$"):
+			end = skipUntil(query, i+2, "$$")
+			b.WriteString("''")
+		case strings.HasPrefix(query[i:], "--") || strings.HasPrefix(query[i:], "//"):
+			end = skipUntil(query, i+2, "\n")
+			b.WriteString(" ")
+		case strings.HasPrefix(query[i:], "/*"):
+			end = skipUntil(query, i+2, "*/")
+			b.WriteString(" ")
+		default:
+			b.WriteByte(c)
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// alterSessionParameterNames returns the parameter names of the single ALTER SESSION SET or UNSET statement,
+// which string literals and comments are already removed from.
+func alterSessionParameterNames(query string) ([]string, bool) {
+	match := alterSessionStatementRegexp.FindStringSubmatch(query)
+	if match == nil || strings.Contains(match[2], ";") {
+		return nil, false
+	}
+	var names []string
+	if strings.EqualFold(match[1], "SET") {
+		for _, m := range sessionParameterSetRegexp.FindAllStringSubmatch(match[2], -1) {
+			names = append(names, m[1])
+		}
+		return names, len(names) > 0
+	}
+	for name := range strings.SplitSeq(strings.Trim(match[2], "() \t\r\n"), ",") {
+		name = strings.TrimSpace(name)
+		if !sessionParameterNameRegexp.MatchString(name) {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+	return names, true
+}
+
+// changedSessionParameters returns the sorted names of the session parameters changed since the login.
+// It returns an error if the parameters cannot be restored with ALTER SESSION UNSET: the changed parameters are not known,
+// or a changed parameter was set in Config.Params, so unsetting it would not restore its value.
+func (sc *snowflakeConn) changedSessionParameters() ([]string, error) {
+	if sc.unknownParamChanged {
+		return nil, errors.New("session parameters were changed by a statement that cannot be parsed")
+	}
+	changed := maps.Clone(sc.changedParams)
+	if changed == nil {
+		changed = make(map[string]bool)
+	}
+	for name, value := range sc.syncParams.All() {
+		if defaultValue, ok := sc.defaultParams[name]; !ok || defaultValue != value {
+			changed[name] = true
+		}
+	}
+	names := slices.Sorted(maps.Keys(changed))
+	for _, name := range names {
+		for configName := range sc.cfg.Params {
+			if strings.EqualFold(name, configName) {
+				return nil, fmt.Errorf("session parameter %v set in the config was changed", name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// sessionResetStatements returns the statements changing the current session state to the default one.
+// The role is set first, because it may be required to use the other objects.
+func sessionResetStatements(current, defaults sessionState) ([]string, error) {
+	var statements []string
+	for _, obj := range []struct {
+		kind             string
+		current, initial string
+	}{
+		{"ROLE", current.role, defaults.role},
+		{"WAREHOUSE", current.warehouse, defaults.warehouse},
+		{"DATABASE", current.database, defaults.database},
+		{"SCHEMA", current.schema, defaults.schema},
+	} {
+		if obj.current == obj.initial {
+			continue
+		}
+		if obj.initial == "" {
+			return nil, fmt.Errorf("%v %v cannot be unset", strings.ToLower(obj.kind), obj.current)
+		}
+		statements = append(statements, fmt.Sprintf("USE %v %v", obj.kind, quoteIdentifier(obj.initial)))
+	}
+	return statements, nil
+}
+
+// quoteIdentifier quotes the object name returned by the server, so it is used with the exact case.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// markSessionInvalid marks the session as not usable anymore if the error was returned by Snowflake.
+func (sr *snowflakeRestful) markSessionInvalid(err error) {
+	var se *SnowflakeError
+	if errors.As(err, &se) {
+		sr.sessionInvalid.Store(true)
+	}
+}
@@ -0,0 +1,194 @@
+package gosnowflake
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSessionResetTestConn(t *testing.T, requests *[]execRequest) *snowflakeConn {
+	sc := &snowflakeConn{
+		cfg: &Config{Database: "DB"},
+		rest: &snowflakeRestful{FuncPostQuery: recordingPostQuery(t, requests, func(req execRequest) *execResponse {
+			data := execResponseData{}
+			if name, ok := strings.CutPrefix(req.SQLText, "USE ROLE "); ok {
+				data.FinalRoleName = strings.Trim(name, `"`)
+			}
+			if name, ok := strings.CutPrefix(req.SQLText, "USE WAREHOUSE "); ok {
+				data.FinalWarehouseName = strings.Trim(name, `"`)
+			}
+			return &execResponse{Data: data, Success: true}
+		})},
+	}
+	sc.populateSessionParameters([]nameValueParameter{{Name: "TIMEZONE", Value: "UTC"}})
+	sc.initSessionState(authResponseSessionInfo{DatabaseName: "DB", SchemaName: "PUBLIC", WarehouseName: "WH", RoleName: "ANALYST"})
+	return sc
+}
+
+func TestResetSessionRestoresDefaults(t *testing.T) {
+	var requests []execRequest
+	sc := newSessionResetTestConn(t, &requests)
+	assertEqualE(t, sc.cfg.Role, "ANALYST")
+
+	assertNilF(t, sc.ResetSession(context.Background()))
+	assertEqualE(t, len(requests), 0, "unchanged session should not be reset")
+
+	_, err := sc.exec(context.Background(), "USE ROLE ADMIN", false, false, false, nil)
+	assertNilF(t, err)
+	_, err = sc.exec(context.Background(), "USE WAREHOUSE OTHER_WH", false, false, false, nil)
+	assertNilF(t, err)
+	assertEqualE(t, sc.cfg.Role, "ADMIN")
+	requests = nil
+
+	assertNilF(t, sc.ResetSession(context.Background()))
+	assertDeepEqualE(t, sqlTexts(requests), []string{`USE ROLE "ANALYST"`, `USE WAREHOUSE "WH"`})
+	assertEqualE(t, sc.cfg.Role, "ANALYST")
+	assertEqualE(t, sc.cfg.Warehouse, "WH")
+}
+
+func TestResetSessionChangedParameters(t *testing.T) {
+	var requests []execRequest
+	sc := newSessionResetTestConn(t, &requests)
+	sc.populateSessionParameters([]nameValueParameter{{Name: "TIMEZONE", Value: "Europe/Warsaw"}})
+	_, err := sc.exec(context.Background(), "alter session set QUERY_TAG = 'a, b = c', statement_timeout_in_seconds=10;", false, false, false, nil)
+	assertNilF(t, err)
+	requests = nil
+
+	assertNilF(t, sc.ResetSession(context.Background()))
+	assertDeepEqualE(t, sqlTexts(requests), []string{"ALTER SESSION UNSET query_tag, statement_timeout_in_seconds, timezone"})
+	timezone, _ := sc.syncParams.get("timezone")
+	assertEqualE(t, *timezone, "UTC")
+	requests = nil
+
+	assertNilF(t, sc.ResetSession(context.Background()))
+	assertEqualE(t, len(requests), 0, "restored session should not be reset again")
+}
+
+func TestResetSessionDiscardsConnection(t *testing.T) {
+	for name, query := range map[string]string{
+		"config parameter": "ALTER SESSION SET CLIENT_RESULT_CHUNK_SIZE = 16",
+		"multi-statement":  "SELECT 1; ALTER SESSION SET QUERY_TAG = 'tag'",
+		"scripting block":  "BEGIN ALTER SESSION SET QUERY_TAG = 'tag'; END",
+	} {
+		t.Run(name, func(t *testing.T) {
+			var requests []execRequest
+			sc := newSessionResetTestConn(t, &requests)
+			sc.cfg.Params = map[string]*string{"CLIENT_RESULT_CHUNK_SIZE": nil}
+			_, err := sc.exec(context.Background(), query, false, false, false, nil)
+			assertNilF(t, err)
+			assertErrIsE(t, sc.ResetSession(context.Background()), driver.ErrBadConn)
+		})
+	}
+}
+
+func TestAlterSessionParameterNames(t *testing.T) {
+	testcases := []struct {
+		query   string
+		names   []string
+		unknown bool
+	}{
+		{query: "ALTER SESSION SET TIMEZONE = ''", names: []string{"timezone"}},
+		{query: "alter  session\nset a=1, b = TRUE;\n", names: []string{"a", "b"}},
+		{query: "ALTER SESSION UNSET QUERY_TAG", names: []string{"query_tag"}},
+		{query: "ALTER SESSION UNSET (a, b)", names: []string{"a", "b"}},
+		{query: "ALTER SESSION SET QUERY_TAG = 'x; ALTER SESSION SET b = 1'", names: []string{"query_tag"}},
+		{query: "ALTER SESSION SET a = 1 -- ALTER SESSION SET b = 2; DROP TABLE t", names: []string{"a"}},
+		{query: "/* reset */ ALTER SESSION /* all */ UNSET a", names: []string{"a"}},
+		{query: "SELECT 1 -- alter session set x=1"},
+		{query: "SELECT 1 // alter session set x=1"},
+		{query: "/* ALTER SESSION */ SELECT 1"},
+		{query: "SELECT $$ALTER SESSION SET x = 1$$"},
+		{query: "SELECT 1 AS \"alter session\""},
+		{query: "ALTER SESSION UNSET a b", unknown: true},
+		{query: "ALTER SESSION SET", unknown: true},
+		{query: "ALTER SESSION SET a = 1; ALTER SESSION SET b = 2", unknown: true},
+		{query: "SELECT 1; ALTER SESSION SET a = 1", unknown: true},
+	}
+	for _, tc := range testcases {
+		sc := &snowflakeConn{}
+		sc.trackChangedParameters(tc.query)
+		assertEqualE(t, sc.unknownParamChanged, tc.unknown, tc.query)
+		var names []string
+		if sc.changedParams != nil {
+			names = slices.Sorted(maps.Keys(sc.changedParams))
+		}
+		assertDeepEqualE(t, names, tc.names, tc.query)
+	}
+}
+
+func TestResetSessionInvalidSession(t *testing.T) {
+	var requests []execRequest
+	sc := newSessionResetTestConn(t, &requests)
+	sc.rest.TokenAccessor = getSimpleTokenAccessor()
+	sc.rest.FuncRenewSession = func(context.Context, *snowflakeRestful, time.Duration) error {
+		return &SnowflakeError{Number: 390114, Message: "Authentication token has expired."}
+	}
+	assertTrueE(t, sc.IsValid())
+
+	assertNotNilF(t, sc.rest.renewExpiredSessionToken(context.Background(), 0, ""))
+	assertFalseE(t, sc.IsValid())
+	assertErrIsE(t, sc.ResetSession(context.Background()), driver.ErrBadConn)
+}
+
+func TestIsValidAfterHeartbeat(t *testing.T) {
+	postHeartbeatCode := func(code string) funcPostType {
+		return func(_ context.Context, _ *snowflakeRestful, _ *url.URL, _ map[string]string, _ []byte, _ time.Duration, _ currentTimeProvider, _ *Config) (*http.Response, error) {
+			body, err := json.Marshal(execResponse{Code: code, Message: "heartbeat response", Success: false})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil
+		}
+	}
+	sc := &snowflakeConn{rest: &snowflakeRestful{FuncPost: postTestError, TokenAccessor: getSimpleTokenAccessor()}}
+	heartbeat := newDefaultHeartBeat(sc.rest)
+	assertNotNilF(t, heartbeat.heartbeatMain())
+	assertTrueE(t, sc.IsValid(), "network errors should not invalidate the session")
+
+	for _, post := range []funcPostType{postTestAppBadGatewayError, postTestAppForbiddenError, postHeartbeatCode("000001")} {
+		sc.rest.FuncPost = post
+		assertNotNilF(t, heartbeat.heartbeatMain())
+		assertTrueE(t, sc.IsValid(), "transient heartbeat failures should not invalidate the session")
+	}
+
+	sc.rest.FuncPost = postHeartbeatCode(sessionGoneCode)
+	err := heartbeat.heartbeatMain()
+	var se *SnowflakeError
+	assertErrorsAsF(t, err, &se)
+	assertEqualE(t, se.Number, ErrSessionGone)
+	assertFalseE(t, sc.IsValid())
+
+	sc = &snowflakeConn{rest: &snowflakeRestful{
+		FuncPost:         postHeartbeatCode(sessionExpiredCode),
+		FuncRenewSession: renewSessionTestError,
+		TokenAccessor:    getSimpleTokenAccessor(),
+	}}
+	heartbeat = newDefaultHeartBeat(sc.rest)
+	assertNotNilF(t, heartbeat.heartbeatMain())
+	assertTrueE(t, sc.IsValid(), "network errors of the renewal should not invalidate the session")
+
+	sc.rest.FuncRenewSession = func(context.Context, *snowflakeRestful, time.Duration) error {
+		return &SnowflakeError{Number: ErrSessionGone}
+	}
+	assertNotNilF(t, heartbeat.heartbeatMain())
+	assertFalseE(t, sc.IsValid())
+}
+
+func TestSessionResetStatements(t *testing.T) {
+	defaults := sessionState{database: "DB", schema: "my schema", role: "PUBLIC"}
+	statements, err := sessionResetStatements(sessionState{database: "OTHER", schema: "PUBLIC", role: "PUBLIC"}, defaults)
+	assertNilF(t, err)
+	assertDeepEqualE(t, statements, []string{`USE DATABASE "DB"`, `USE SCHEMA "my schema"`})
+
+	_, err = sessionResetStatements(sessionState{database: "DB", schema: "my schema", warehouse: "WH", role: "PUBLIC"}, defaults)
+	assertNotNilE(t, err, "warehouse without default cannot be reset")
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"maps"
+	"math"
 	"os"
 	"runtime"
 	"strconv"
@@ -237,6 +238,28 @@ func writeFileStream(ctx context.Context, streamBuf *bytes.Buffer) error {
 	return nil
 }
 
+// setStatementTimeoutFromDeadline sets STATEMENT_TIMEOUT_IN_SECONDS to the time left until the deadline of the context,
+// so the server stops the statement when the client gives up. The timeout set for the query and a shorter timeout
+// of the session are kept.
+func (sc *snowflakeConn) setStatementTimeoutFromDeadline(ctx context.Context, parameters map[string]any) {
+	parameterName := strings.ToUpper(sessionStatementTimeoutInSeconds)
+	if _, ok := parameters[parameterName]; ok {
+		return
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return
+	}
+	timeout := max(int64(math.Ceil(time.Until(deadline).Seconds())), 1)
+	if v, ok := sc.syncParams.get(sessionStatementTimeoutInSeconds); ok && v != nil {
+		if sessionTimeout, err := strconv.ParseInt(*v, 10, 64); err == nil && 0 < sessionTimeout && sessionTimeout <= timeout {
+			return
+		}
+	}
+	logger.WithContext(ctx).Debugf("setting %v to %v from the context deadline", parameterName, timeout)
+	parameters[parameterName] = timeout
+}
+
 func (sc *snowflakeConn) populateSessionParameters(parameters []nameValueParameter) {
 	// other session parameters (not all)
 	logger.WithContext(sc.ctx).Tracef("params: %#v", parameters)
@@ -16,6 +16,7 @@ import (
 	"math/big"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -27,6 +28,7 @@ import (
 )
 
 const format = "2006-01-02 15:04:05.999999999"
+const formatWithOffset = format + " -07:00"
 const numberDefaultPrecision = 38
 const jsonFormatStr = "json"
 
@@ -74,6 +76,10 @@ func isJSONFormatType(tsmode types.SnowflakeType) bool {
 	return tsmode == types.ObjectType || tsmode == types.ArrayType || tsmode == types.SliceType
 }
 
+func isSpatialType(tsmode types.SnowflakeType) bool {
+	return tsmode == types.GeographyType || tsmode == types.GeometryType
+}
+
 // goTypeToSnowflake translates Go data type to Snowflake data type.
 func goTypeToSnowflake(v driver.Value, tsmode types.SnowflakeType) types.SnowflakeType {
 	if isJSONFormatType(tsmode) {
@@ -99,6 +105,9 @@ func goTypeToSnowflake(v driver.Value, tsmode types.SnowflakeType) types.Snowfla
 			return types.NullType // invalid byte array. won't take as BINARY
 		}
 		if len(t) != 1 {
+			if isSpatialType(tsmode) {
+				return tsmode
+			}
 			return types.ArrayType
 		}
 		if _, err := dataTypeMode(t); err != nil {
@@ -107,10 +116,17 @@ func goTypeToSnowflake(v driver.Value, tsmode types.SnowflakeType) types.Snowfla
 		return types.ChangeType
 	case time.Time, sql.NullTime:
 		return tsmode
+	case []float32, []int32:
+		if tsmode == types.VectorType {
+			return types.VectorType
+		}
 	}
 	if supportedArrayBind(&driver.NamedValue{Value: v}) {
 		return types.SliceType
 	}
+	if isVariantValue(v) {
+		return types.VariantType
+	}
 	// structured objects
 	if _, ok := v.(StructuredObjectWriter); ok {
 		return types.ObjectType
@@ -135,6 +151,9 @@ func snowflakeTypeToGo(ctx context.Context, dbtype types.SnowflakeType, precisio
 	structuredTypesEnabled := structuredTypesEnabled(ctx)
 	switch dbtype {
 	case types.FixedType:
+		if scale != 0 && decimalMappingEnabled(ctx) {
+			return reflect.TypeFor[Decimal]()
+		}
 		if higherPrecisionEnabled(ctx) {
 			if scale == 0 {
 				if precision >= 19 {
@@ -161,7 +180,7 @@ func snowflakeTypeToGo(ctx context.Context, dbtype types.SnowflakeType, precisio
 			return reflect.TypeFor[*big.Float]()
 		}
 		return reflect.TypeFor[float64]()
-	case types.TextType, types.VariantType:
+	case types.TextType, types.VariantType, types.GeographyType, types.GeometryType:
 		return reflect.TypeFor[string]()
 	case types.DateType, types.TimeType, types.TimestampLtzType, types.TimestampNtzType, types.TimestampTzType:
 		return reflect.TypeOf(time.Now())
@@ -217,6 +236,11 @@ func snowflakeTypeToGo(ctx context.Context, dbtype types.SnowflakeType, precisio
 			return snowflakeTypeToGoForMaps[int64](ctx, fields[1])
 		}
 		return reflect.TypeFor[map[any]any]()
+	case types.VectorType:
+		if isIntVector(fields) {
+			return reflect.TypeFor[[]int32]()
+		}
+		return reflect.TypeFor[[]float32]()
 	}
 	logger.WithContext(ctx).Errorf("unsupported dbtype is specified. %v", dbtype)
 	return reflect.TypeFor[string]()
@@ -273,6 +297,20 @@ func valueToString(v driver.Value, tsmode types.SnowflakeType, params *syncParam
 		}
 	}
 
+	if isVariantValue(v) {
+		s, err := variantToString(v)
+		if err != nil {
+			return bindingValue{}, err
+		}
+		return bindingValue{s, jsonFormatStr, nil}, nil
+	}
+
+	if b, ok := v.([]byte); ok && isSpatialType(tsmode) {
+		// WKB is sent as hex, which the server converts like the other spatial input formats
+		s := hex.EncodeToString(b)
+		return bindingValue{&s, "", nil}, nil
+	}
+
 	if tsmode == types.DecfloatType && v1.Type() == reflect.TypeFor[big.Float]() {
 		s := v.(*big.Float).Text('g', decfloatPrintingPrec)
 		return bindingValue{&s, "", nil}, nil
@@ -337,6 +375,9 @@ func arrayToString(v driver.Value, tsmode types.SnowflakeType, params *syncParam
 	if v1.Kind() == reflect.Slice && v1.IsNil() {
 		return bindingValue{nil, jsonFormatStr, nil}, nil
 	}
+	if bv, ok := vectorToString(v); ok && tsmode == types.VectorType {
+		return bv, nil
+	}
 	if bd, ok := v.([][]byte); ok && tsmode == types.BinaryType {
 		schema := bindingSchema{
 			Typ:      "array",
@@ -994,6 +1035,14 @@ func stringToValue(ctx context.Context, dest *driver.Value, srcColumnMeta query.
 		*dest = *srcValue
 		return nil
 	case "fixed":
+		if srcColumnMeta.Scale != 0 && decimalMappingEnabled(ctx) {
+			d, err := ParseDecimal(*srcValue)
+			if err != nil {
+				return err
+			}
+			*dest = d
+			return nil
+		}
 		if higherPrecisionEnabled(ctx) {
 			if srcColumnMeta.Scale == 0 {
 				if srcColumnMeta.Precision >= 19 {
@@ -1086,7 +1135,7 @@ func stringToValue(ctx context.Context, dest *driver.Value, srcColumnMeta query.
 		}
 		loc := Location(int(offset) - 1440)
 		tt := time.Unix(sec, nsec)
-		*dest = tt.In(loc)
+		*dest = timestampTzInLocation(tt.In(loc), getTimestampTzLocation(ctx))
 		return nil
 	case "binary":
 		b, err := hex.DecodeString(*srcValue)
@@ -1122,11 +1171,58 @@ func stringToValue(ctx context.Context, dest *driver.Value, srcColumnMeta query.
 		var err error
 		*dest, err = jsonToMap(ctx, srcColumnMeta.Fields[0], srcColumnMeta.Fields[1], *srcValue, params)
 		return err
+	case "vector":
+		var err error
+		*dest, err = jsonToVector(*srcValue, isIntVector(srcColumnMeta.Fields))
+		return err
 	}
 	*dest = *srcValue
 	return nil
 }
 
+// isIntVector reports whether the elements of the VECTOR column are INT. The elements are FLOAT otherwise.
+func isIntVector(fields []query.FieldMetadata) bool {
+	return len(fields) == 1 && types.GetSnowflakeType(fields[0].Type) == types.FixedType
+}
+
+func jsonToVector(srcValue string, intElements bool) (snowflakeValue, error) {
+	if intElements {
+		var v []int32
+		if err := json.Unmarshal([]byte(srcValue), &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	var v []float32
+	if err := json.Unmarshal([]byte(srcValue), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// vectorToString converts a float32 or int32 slice to the VECTOR literal, e.g. [1.5,2].
+// False is returned for other types.
+func vectorToString(v driver.Value) (bindingValue, bool) {
+	var res string
+	switch t := v.(type) {
+	case []float32:
+		res = formatVector(t, func(x float32) string { return strconv.FormatFloat(float64(x), 'g', -1, 32) })
+	case []int32:
+		res = formatVector(t, func(x int32) string { return strconv.FormatInt(int64(x), 10) })
+	default:
+		return bindingValue{}, false
+	}
+	return bindingValue{&res, "", nil}, true
+}
+
+func formatVector[T float32 | int32](v []T, format func(T) string) string {
+	elements := make([]string, len(v))
+	for i, x := range v {
+		elements[i] = format(x)
+	}
+	return "[" + strings.Join(elements, ",") + "]"
+}
+
 func jsonToMap(ctx context.Context, keyMetadata, valueMetadata query.FieldMetadata, srcValue string, params *syncParams) (snowflakeValue, error) {
 	structuredTypesEnabled := structuredTypesEnabled(ctx)
 	if !structuredTypesEnabled {
@@ -1488,6 +1584,18 @@ func arrowSnowflakeTimestampToTime(
 	if column.IsNull(recIdx) {
 		return nil
 	}
+	ret := arrowSnowflakeTimestampValue(column, sfType, scale, recIdx, loc)
+	return &ret
+}
+
+// arrowSnowflakeTimestampValue converts the not null timestamp at recIdx.
+func arrowSnowflakeTimestampValue(
+	column arrow.Array,
+	sfType types.SnowflakeType,
+	scale int,
+	recIdx int,
+	loc *time.Location) time.Time {
+
 	var ret time.Time
 	switch sfType {
 	case types.TimestampNtzType:
@@ -1533,7 +1641,7 @@ func arrowSnowflakeTimestampToTime(
 			ret = time.Unix(epoch[recIdx], int64(fraction[recIdx])).In(locTz)
 		}
 	}
-	return &ret
+	return ret
 }
 
 func extractEpoch(value int64, scale int) int64 {
@@ -1576,6 +1684,9 @@ func arrowToValue(ctx context.Context, rowIdx int, srcColumnMeta query.FieldMeta
 	case types.FixedType:
 		// Snowflake data types that are fixed-point numbers will fall into this category
 		// e.g. NUMBER, DECIMAL/NUMERIC, INT/INTEGER
+		if srcColumnMeta.Scale != 0 && decimalMappingEnabled(ctx) {
+			return arrowToDecimal(srcValue, rowIdx, srcColumnMeta)
+		}
 		switch numericValue := srcValue.(type) {
 		case *array.Decimal128:
 			return arrowDecimal128ToValue(numericValue, rowIdx, higherPrecision, srcColumnMeta), nil
@@ -1603,6 +1714,20 @@ func arrowToValue(ctx context.Context, rowIdx int, srcColumnMeta query.FieldMeta
 			return strings.Value(rowIdx), nil
 		}
 		return nil, nil
+	case types.VectorType:
+		return arrowVectorToValue(srcValue, rowIdx, isIntVector(srcColumnMeta.Fields))
+	case types.GeographyType, types.GeometryType:
+		// the WKB and EWKB output formats are binary, the other ones are text
+		switch spatial := srcValue.(type) {
+		case *array.Binary:
+			return arrowBinaryToValue(spatial, rowIdx), nil
+		case *array.String:
+			if !srcValue.IsNull(rowIdx) {
+				return spatial.Value(rowIdx), nil
+			}
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unsupported arrow data type for %v: %v", snowflakeType, srcValue.DataType())
 	case types.ArrayType:
 		if len(srcColumnMeta.Fields) == 0 || !structuredTypesEnabled {
 			// semistructured type without schema
@@ -1687,10 +1812,13 @@ func arrowToValue(ctx context.Context, rowIdx int, srcColumnMeta query.FieldMeta
 		return arrowTimeToValue(srcValue, rowIdx, int(srcColumnMeta.Scale)), nil
 	case types.TimestampNtzType, types.TimestampLtzType, types.TimestampTzType:
 		v := arrowSnowflakeTimestampToTime(srcValue, snowflakeType, int(srcColumnMeta.Scale), rowIdx, loc)
-		if v != nil {
-			return *v, nil
+		if v == nil {
+			return nil, nil
 		}
-		return nil, nil
+		if snowflakeType == types.TimestampTzType {
+			return timestampTzInLocation(*v, getTimestampTzLocation(ctx)), nil
+		}
+		return *v, nil
 	}
 
 	return nil, fmt.Errorf("unsupported data type")
@@ -2415,6 +2543,28 @@ func arrowDecimal128ToValue(srcValue *array.Decimal128, rowIdx int, higherPrecis
 	return nil
 }
 
+func arrowToDecimal(srcValue arrow.Array, rowIdx int, srcColumnMeta query.FieldMetadata) (snowflakeValue, error) {
+	if srcValue.IsNull(rowIdx) {
+		return nil, nil
+	}
+	var unscaled *big.Int
+	switch numericValue := srcValue.(type) {
+	case *array.Decimal128:
+		unscaled = numericValue.Value(rowIdx).BigInt()
+	case *array.Int64:
+		unscaled = big.NewInt(numericValue.Value(rowIdx))
+	case *array.Int32:
+		unscaled = big.NewInt(int64(numericValue.Value(rowIdx)))
+	case *array.Int16:
+		unscaled = big.NewInt(int64(numericValue.Value(rowIdx)))
+	case *array.Int8:
+		unscaled = big.NewInt(int64(numericValue.Value(rowIdx)))
+	default:
+		return nil, fmt.Errorf("unsupported data type")
+	}
+	return Decimal{unscaled: unscaled, scale: int32(srcColumnMeta.Scale)}, nil
+}
+
 func arrowInt64ToValue(srcValue *array.Int64, rowIdx int, higherPrecision bool, srcColumnMeta query.FieldMetadata) snowflakeValue {
 	if !srcValue.IsNull(rowIdx) {
 		val := srcValue.Value(rowIdx)
@@ -2546,42 +2696,70 @@ func arrowBinaryToValue(srcValue *array.Binary, rowIdx int) snowflakeValue {
 	return nil
 }
 
+// arrowVectorToValue copies the VECTOR value, as the memory of the arrow record is released with the chunk.
+func arrowVectorToValue(srcValue arrow.Array, rowIdx int, intElements bool) (snowflakeValue, error) {
+	if srcValue.IsNull(rowIdx) {
+		return nil, nil
+	}
+	switch vectors := srcValue.(type) {
+	case *array.FixedSizeList:
+		start, end := vectors.ValueOffsets(rowIdx)
+		switch values := vectors.ListValues().(type) {
+		case *array.Float32:
+			return slices.Clone(values.Float32Values()[start:end]), nil
+		case *array.Int32:
+			return slices.Clone(values.Int32Values()[start:end]), nil
+		}
+	case *array.String:
+		return jsonToVector(vectors.Value(rowIdx), intElements)
+	}
+	return nil, fmt.Errorf("unsupported arrow data type for VECTOR: %v", srcValue.DataType())
+}
+
 func arrowDateToValue(srcValue *array.Date32, rowID int) snowflakeValue {
 	if !srcValue.IsNull(rowID) {
-		return time.Unix(int64(srcValue.Value(rowID))*86400, 0).UTC()
+		return arrowDateToTime(srcValue, rowID)
 	}
 	return nil
 }
 
+func arrowDateToTime(srcValue *array.Date32, rowID int) time.Time {
+	return time.Unix(int64(srcValue.Value(rowID))*86400, 0).UTC()
+}
+
 func arrowTimeToValue(srcValue arrow.Array, rowIdx int, scale int) snowflakeValue {
+	if !srcValue.IsNull(rowIdx) {
+		return arrowTimeToTime(srcValue, rowIdx, scale)
+	}
+	return nil
+}
+
+func arrowTimeToTime(srcValue arrow.Array, rowIdx int, scale int) time.Time {
 	t0 := time.Time{}
 	if srcValue.DataType().ID() == arrow.INT64 {
-		if !srcValue.IsNull(rowIdx) {
-			return t0.Add(time.Duration(srcValue.(*array.Int64).Value(rowIdx) * int64(math.Pow10(9-scale))))
-		}
-	} else {
-		if !srcValue.IsNull(rowIdx) {
-			return t0.Add(time.Duration(int64(srcValue.(*array.Int32).Value(rowIdx)) * int64(math.Pow10(9-scale))))
-		}
+		return t0.Add(time.Duration(srcValue.(*array.Int64).Value(rowIdx) * int64(math.Pow10(9-scale))))
 	}
-	return nil
+	return t0.Add(time.Duration(int64(srcValue.(*array.Int32).Value(rowIdx)) * int64(math.Pow10(9-scale))))
 }
 
 type (
-	intArray          []int
-	int32Array        []int32
-	int64Array        []int64
-	float64Array      []float64
-	float32Array      []float32
-	decfloatArray     []*big.Float
-	boolArray         []bool
-	stringArray       []string
-	byteArray         [][]byte
-	timestampNtzArray []time.Time
-	timestampLtzArray []time.Time
-	timestampTzArray  []time.Time
-	dateArray         []time.Time
-	timeArray         []time.Time
+	intArray           []int
+	int32Array         []int32
+	int64Array         []int64
+	float64Array       []float64
+	float32Array       []float32
+	decfloatArray      []*big.Float
+	boolArray          []bool
+	stringArray        []string
+	byteArray          [][]byte
+	timestampNtzArray  []time.Time
+	timestampLtzArray  []time.Time
+	timestampTzArray   []time.Time
+	dateArray          []time.Time
+	timeArray          []time.Time
+	float32VectorArray [][]float32
+	int32VectorArray   [][]int32
+	variantArray       []any
 )
 
 // Array takes in a column of a row to be inserted via array binding, bulk or
@@ -2612,6 +2790,10 @@ func Array(a any, typ ...any) (any, error) {
 		return (*stringArray)(&t), nil
 	case [][]byte:
 		return (*byteArray)(&t), nil
+	case [][]float32:
+		return (*float32VectorArray)(&t), nil
+	case [][]int32:
+		return (*int32VectorArray)(&t), nil
 	case []time.Time:
 		if len(typ) < 1 {
 			return nil, errUnsupportedTimeArrayBind
@@ -2653,6 +2835,10 @@ func Array(a any, typ ...any) (any, error) {
 		return (*stringArray)(t), nil
 	case *[][]byte:
 		return (*byteArray)(t), nil
+	case *[][]float32:
+		return (*float32VectorArray)(t), nil
+	case *[][]int32:
+		return (*int32VectorArray)(t), nil
 	case *[]time.Time:
 		if len(typ) < 1 {
 			return nil, errUnsupportedTimeArrayBind
@@ -2685,6 +2871,9 @@ func Array(a any, typ ...any) (any, error) {
 			timezoneTypeArray: a,
 		}, nil
 	default:
+		if arr, ok := toVariantArray(a); ok {
+			return arr, nil
+		}
 		return nil, fmt.Errorf("unknown array type for binding: %T", a)
 	}
 }
@@ -2759,6 +2948,22 @@ func snowflakeArrayToString(nv *driver.NamedValue, stream bool) (types.Snowflake
 			v := hex.EncodeToString(x)
 			arr = append(arr, &v)
 		}
+	case reflect.TypeFor[*float32VectorArray]():
+		t = types.VectorType
+		arr = vectorArrayToString(*nv.Value.(*float32VectorArray))
+	case reflect.TypeFor[*int32VectorArray]():
+		t = types.VectorType
+		arr = vectorArrayToString(*nv.Value.(*int32VectorArray))
+	case reflect.TypeFor[*variantArray]():
+		t = types.VariantType
+		a := nv.Value.(*variantArray)
+		for _, x := range *a {
+			v, err := variantToString(x)
+			if err != nil {
+				return types.UnSupportedType, nil, err
+			}
+			arr = append(arr, v)
+		}
 	case reflect.TypeFor[*timestampNtzArray]():
 		t = types.TimestampNtzType
 		a := nv.Value.(*timestampNtzArray)
@@ -2839,6 +3044,18 @@ func snowflakeArrayToString(nv *driver.NamedValue, stream bool) (types.Snowflake
 	return t, arr, nil
 }
 
+func vectorArrayToString[T float32 | int32](vectors [][]T) []*string {
+	arr := make([]*string, len(vectors))
+	for i, vector := range vectors {
+		if vector == nil {
+			continue
+		}
+		bv, _ := vectorToString(vector)
+		arr[i] = bv.value
+	}
+	return arr
+}
+
 func interfaceSliceToString(interfaceSlice reflect.Value, stream bool, tzType ...timezoneType) (types.SnowflakeType, []*string, error) {
 	var t types.SnowflakeType
 	var arr []*string
@@ -2961,6 +3178,11 @@ func higherPrecisionEnabled(ctx context.Context) bool {
 	return ia.HigherPrecisionEnabled(ctx)
 }
 
+func decimalMappingEnabled(ctx context.Context) bool {
+	d, ok := ctx.Value(enableDecimal).(bool)
+	return ok && d
+}
+
 func decfloatMappingEnabled(ctx context.Context) bool {
 	v := ctx.Value(enableDecfloat)
 	if v == nil {
@@ -2995,6 +3217,10 @@ func convertTzTypeToSnowflakeType(tzType timezoneType) types.SnowflakeType {
 
 func getTimestampBindValue(x time.Time, stream bool, t types.SnowflakeType) (string, error) {
 	if stream {
+		if t == types.TimestampTzType || t == types.TimestampLtzType {
+			// the offset is kept, so the values from named locations are not read in the session time zone
+			return x.Format(formatWithOffset), nil
+		}
 		return x.Format(format), nil
 	}
 	return convertTimeToTimeStamp(x, t)
@@ -12,6 +12,7 @@ import (
 	"math/big"
 	"math/cmplx"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -287,6 +288,67 @@ func TestStringToValue(t *testing.T) {
 	}
 }
 
+func TestStringToValueTimestampTzLocation(t *testing.T) {
+	newYork, err := LocationWithName("America/New_York")
+	assertNilF(t, err)
+	rowType := query.ExecResponseRowType{Type: "timestamp_tz"}
+	ctx := WithTimestampTzLocation(context.Background(), newYork)
+	testcases := []struct {
+		source string
+		loc    *time.Location
+	}{
+		{source: "1710050400.000000000 1140", loc: newYork}, // 2024-03-10 01:00 -0500, before the DST change
+		{source: "1710057600.000000000 1200", loc: newYork}, // 2024-03-10 04:00 -0400, after the DST change
+		{source: "1710057600.000000000 1440", loc: Location(0)},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.source, func(t *testing.T) {
+			var dest driver.Value
+			assertNilF(t, stringToValue(ctx, &dest, rowType, &tc.source, nil, nil))
+			ts := dest.(time.Time)
+			assertEqualE(t, ts.Location(), tc.loc)
+
+			assertNilF(t, stringToValue(context.Background(), &dest, rowType, &tc.source, nil, nil))
+			assertTrueE(t, dest.(time.Time).Equal(ts))
+		})
+	}
+}
+
+func TestGetTimestampBindValueStream(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assertNilF(t, err)
+	beforeDST := time.Date(2024, time.March, 10, 1, 30, 0, 123456789, newYork)
+	afterDST := time.Date(2024, time.March, 10, 3, 30, 0, 0, newYork)
+	testcases := []struct {
+		value    time.Time
+		typ      types.SnowflakeType
+		expected string
+	}{
+		{value: beforeDST, typ: types.TimestampTzType, expected: "2024-03-10 01:30:00.123456789 -05:00"},
+		{value: afterDST, typ: types.TimestampTzType, expected: "2024-03-10 03:30:00 -04:00"},
+		{value: beforeDST, typ: types.TimestampLtzType, expected: "2024-03-10 01:30:00.123456789 -05:00"},
+		{value: afterDST, typ: types.TimestampLtzType, expected: "2024-03-10 03:30:00 -04:00"},
+		{value: afterDST.UTC(), typ: types.TimestampLtzType, expected: "2024-03-10 07:30:00 +00:00"},
+		{value: afterDST, typ: types.TimestampNtzType, expected: "2024-03-10 03:30:00"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.typ.String()+" "+tc.expected, func(t *testing.T) {
+			s, err := getTimestampBindValue(tc.value, true, tc.typ)
+			assertNilF(t, err)
+			assertEqualE(t, s, tc.expected)
+			if tc.typ != types.TimestampNtzType {
+				parsed, err := time.Parse(formatWithOffset, s)
+				assertNilF(t, err)
+				assertTrueE(t, parsed.Equal(tc.value), "the stage value should keep the instant")
+			}
+		})
+	}
+
+	s, err := getTimestampBindValue(afterDST, false, types.TimestampLtzType)
+	assertNilF(t, err)
+	assertEqualE(t, s, strconv.FormatInt(afterDST.UnixNano(), 10))
+}
+
 type tcArrayToString struct {
 	in  driver.NamedValue
 	typ types.SnowflakeType
@@ -302,6 +364,8 @@ func TestArrayToString(t *testing.T) {
 		{in: driver.NamedValue{Value: &float32Array{1.5}}, typ: types.RealType, out: []string{"1.5"}},
 		{in: driver.NamedValue{Value: &boolArray{true, false}}, typ: types.BooleanType, out: []string{"true", "false"}},
 		{in: driver.NamedValue{Value: &stringArray{"foo", "bar", "baz"}}, typ: types.TextType, out: []string{"foo", "bar", "baz"}},
+		{in: driver.NamedValue{Value: &float32VectorArray{{1.5, 2}, {-3, 0.25}}}, typ: types.VectorType, out: []string{"[1.5,2]", "[-3,0.25]"}},
+		{in: driver.NamedValue{Value: &int32VectorArray{{1, 2, 3}}}, typ: types.VectorType, out: []string{"[1,2,3]"}},
 	}
 	for _, test := range testcases {
 		t.Run(strings.Join(test.out, "_"), func(t *testing.T) {
@@ -1198,3 +1262,67 @@ func mustArray(v any, typ ...any) driver.Value {
 	}
 	return array
 }
+
+func TestVectorToValue(t *testing.T) {
+	floatVector := query.ExecResponseRowType{Type: "vector", Dimension: 2, Fields: []query.FieldMetadata{{Type: "real"}}}
+	intVector := query.ExecResponseRowType{Type: "vector", Dimension: 2, Fields: []query.FieldMetadata{{Type: "fixed"}}}
+
+	t.Run("JSON", func(t *testing.T) {
+		var dest driver.Value
+		value := "[1.500000e+00,-2.25]"
+		assertNilF(t, stringToValue(context.Background(), &dest, floatVector, &value, nil, nil))
+		assertDeepEqualE(t, dest, driver.Value([]float32{1.5, -2.25}))
+		value = "[1,-2]"
+		assertNilF(t, stringToValue(context.Background(), &dest, intVector, &value, nil, nil))
+		assertDeepEqualE(t, dest, driver.Value([]int32{1, -2}))
+	})
+
+	t.Run("arrow", func(t *testing.T) {
+		pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer pool.AssertSize(t, 0)
+		builder := array.NewFixedSizeListBuilder(pool, 2, arrow.PrimitiveTypes.Float32)
+		defer builder.Release()
+		builder.Append(true)
+		builder.ValueBuilder().(*array.Float32Builder).AppendValues([]float32{1.5, -2.25}, nil)
+		builder.AppendNull()
+		builder.Append(true)
+		builder.ValueBuilder().(*array.Float32Builder).AppendValues([]float32{3, 4}, nil)
+		arr := builder.NewArray()
+		defer arr.Release()
+
+		dest := make([]snowflakeValue, 3)
+		assertNilF(t, arrowToValues(context.Background(), dest, floatVector, arr, nil, false, nil))
+		assertDeepEqualE(t, dest, []snowflakeValue{[]float32{1.5, -2.25}, nil, []float32{3, 4}})
+	})
+
+	t.Run("scan type", func(t *testing.T) {
+		assertEqualE(t, snowflakeTypeToGo(context.Background(), types.VectorType, 0, 0, floatVector.Fields), reflect.TypeFor[[]float32]())
+		assertEqualE(t, snowflakeTypeToGo(context.Background(), types.VectorType, 0, 0, intVector.Fields), reflect.TypeFor[[]int32]())
+	})
+}
+
+func TestVectorBindValues(t *testing.T) {
+	floatVectors, err := Array([][]float32{{1.5, 2}, nil})
+	assertNilF(t, err)
+	bindValues, err := getBindValues([]driver.NamedValue{
+		{Ordinal: 1, Value: DataTypeVector},
+		{Ordinal: 2, Value: []float32{1.5, -2}},
+		{Ordinal: 3, Value: []int32{1, 2}},
+		{Ordinal: 4, Value: floatVectors},
+	}, nil)
+	assertNilF(t, err)
+	assertEqualE(t, bindValues["1"].Type, "VECTOR")
+	assertEqualE(t, *bindValues["1"].Value.(*string), "[1.5,-2]")
+	assertEqualE(t, bindValues["2"].Type, "VECTOR")
+	assertEqualE(t, *bindValues["2"].Value.(*string), "[1,2]")
+	assertEqualE(t, bindValues["3"].Type, "VECTOR")
+	vectors := bindValues["3"].Value.([]*string)
+	assertEqualF(t, len(vectors), 2)
+	assertEqualE(t, *vectors[0], "[1.5,2]")
+	assertNilE(t, vectors[1])
+
+	// without DataTypeVector the slices are bound as ARRAY
+	bindValues, err = getBindValues([]driver.NamedValue{{Ordinal: 1, Value: []float32{1.5}}}, nil)
+	assertNilF(t, err)
+	assertEqualE(t, bindValues["1"].Type, "ARRAY")
+}
@@ -0,0 +1,147 @@
+package gosnowflake
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// additional data authenticated with every encrypted credential cache file, changed when the format changes
+const encryptedCredentialCacheFormat = "snowflake-credential-cache-v1"
+
+type encryptedFileCredentialCache struct {
+	path string
+	aead cipher.AEAD
+	mu   sync.Mutex
+}
+
+// NewEncryptedFileCredentialCache returns a CredentialCache storing the tokens in the file at path,
+// encrypted with AES-256-GCM using key. The key must be 32 bytes long and should come from a secret store,
+// the file is unreadable without it. The file is created with 0600 permissions when the first token is stored
+// and every update replaces it atomically. Processes sharing the file do not lock it, the last update wins.
+func NewEncryptedFileCredentialCache(path string, key []byte) (CredentialCache, error) {
+	if path == "" {
+		return nil, errors.New("credential cache file path is required")
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("credential cache key must be 32 bytes long, got %v", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedFileCredentialCache{path: path, aead: aead}, nil
+}
+
+func (c *encryptedFileCredentialCache) Get(key CredentialCacheKey) (string, error) {
+	credentialsKey, err := buildCredentialsKey(key.Host, key.User, tokenType(key.TokenType))
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tokens, err := c.read()
+	if err != nil {
+		return "", err
+	}
+	return tokens[credentialsKey], nil
+}
+
+func (c *encryptedFileCredentialCache) Set(key CredentialCacheKey, value string) error {
+	credentialsKey, err := buildCredentialsKey(key.Host, key.User, tokenType(key.TokenType))
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tokens, err := c.read()
+	if err != nil {
+		return err
+	}
+	tokens[credentialsKey] = value
+	return c.write(tokens)
+}
+
+func (c *encryptedFileCredentialCache) Delete(key CredentialCacheKey) error {
+	credentialsKey, err := buildCredentialsKey(key.Host, key.User, tokenType(key.TokenType))
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tokens, err := c.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[credentialsKey]; !ok {
+		return nil
+	}
+	delete(tokens, credentialsKey)
+	return c.write(tokens)
+}
+
+func (c *encryptedFileCredentialCache) read() (map[string]string, error) {
+	tokens := map[string]string{}
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read credential cache file %v. %v", c.path, err)
+	}
+	nonceSize := c.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("credential cache file %v is corrupted", c.path)
+	}
+	plaintext, err := c.aead.Open(nil, data[:nonceSize], data[nonceSize:], []byte(encryptedCredentialCacheFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credential cache file %v, the file is corrupted or the key is different. %v", c.path, err)
+	}
+	if err = json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credential cache file %v. %v", c.path, err)
+	}
+	return tokens, nil
+}
+
+func (c *encryptedFileCredentialCache) write(tokens map[string]string) error {
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credential cache. %v", err)
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return err
+	}
+	data := c.aead.Seal(nonce, nonce, plaintext, []byte(encryptedCredentialCacheFormat))
+
+	// os.CreateTemp creates the file with 0600 permissions
+	tmpFile, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create credential cache file. %v", err)
+	}
+	defer func() {
+		if err := os.Remove(tmpFile.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("failed to remove temporary credential cache file %v. %v", tmpFile.Name(), err)
+		}
+	}()
+	if _, err = tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("failed to write credential cache file. %v", err)
+	}
+	if err = tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write credential cache file. %v", err)
+	}
+	if err = os.Rename(tmpFile.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to replace credential cache file %v. %v", c.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package gosnowflake
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestEncryptedFileCredentialCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.enc")
+	key := bytes.Repeat([]byte{0x42}, 32)
+	cache, err := NewEncryptedFileCredentialCache(path, key)
+	assertNilF(t, err)
+	idTokenKey := newIDTokenSpec("host.snowflakecomputing.com", "johndoe").cacheKey()
+	mfaTokenKey := newMfaTokenSpec("host.snowflakecomputing.com", "johndoe").cacheKey()
+
+	t.Run("missing file", func(t *testing.T) {
+		value, err := cache.Get(idTokenKey)
+		assertNilF(t, err)
+		assertEmptyStringE(t, value)
+		assertNilE(t, cache.Delete(idTokenKey))
+	})
+
+	t.Run("set, get and delete", func(t *testing.T) {
+		assertNilF(t, cache.Set(idTokenKey, "id-token-123"))
+		assertNilF(t, cache.Set(mfaTokenKey, "mfa-token-123"))
+		value, err := cache.Get(idTokenKey)
+		assertNilF(t, err)
+		assertEqualE(t, value, "id-token-123")
+
+		assertNilF(t, cache.Delete(idTokenKey))
+		value, err = cache.Get(idTokenKey)
+		assertNilF(t, err)
+		assertEmptyStringE(t, value)
+		value, err = cache.Get(mfaTokenKey)
+		assertNilF(t, err)
+		assertEqualE(t, value, "mfa-token-123")
+	})
+
+	t.Run("file is encrypted", func(t *testing.T) {
+		skipOnWindows(t, "permission model is different")
+		stat, err := os.Stat(path)
+		assertNilF(t, err)
+		assertEqualE(t, stat.Mode().Perm(), os.FileMode(0600))
+		data, err := os.ReadFile(path)
+		assertNilF(t, err)
+		assertFalseE(t, bytes.Contains(data, []byte("mfa-token-123")), "token should not be stored in plain text")
+	})
+
+	t.Run("another instance with the same key", func(t *testing.T) {
+		other, err := NewEncryptedFileCredentialCache(path, key)
+		assertNilF(t, err)
+		value, err := other.Get(mfaTokenKey)
+		assertNilF(t, err)
+		assertEqualE(t, value, "mfa-token-123")
+	})
+
+	t.Run("different key", func(t *testing.T) {
+		other, err := NewEncryptedFileCredentialCache(path, bytes.Repeat([]byte{0x24}, 32))
+		assertNilF(t, err)
+		_, err = other.Get(mfaTokenKey)
+		assertNotNilF(t, err)
+		assertStringContainsE(t, err.Error(), "failed to decrypt credential cache file")
+		assertNotNilE(t, other.Set(mfaTokenKey, "mfa-token-456"))
+	})
+
+	t.Run("missing user", func(t *testing.T) {
+		assertNotNilE(t, cache.Set(newIDTokenSpec("host.snowflakecomputing.com", "").cacheKey(), "token"))
+	})
+
+	t.Run("concurrent updates", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for _, user := range []string{"u1", "u2", "u3", "u4"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assertNilE(t, cache.Set(newIDTokenSpec("host.snowflakecomputing.com", user).cacheKey(), user))
+			}()
+		}
+		wg.Wait()
+		for _, user := range []string{"u1", "u2", "u3", "u4"} {
+			value, err := cache.Get(newIDTokenSpec("host.snowflakecomputing.com", user).cacheKey())
+			assertNilE(t, err)
+			assertEqualE(t, value, user)
+		}
+	})
+}
+
+func TestNewEncryptedFileCredentialCacheValidation(t *testing.T) {
+	_, err := NewEncryptedFileCredentialCache(filepath.Join(t.TempDir(), "credentials.enc"), []byte("too short"))
+	assertNotNilE(t, err)
+	_, err = NewEncryptedFileCredentialCache("", bytes.Repeat([]byte{0x42}, 32))
+	assertNotNilE(t, err)
+}
+
+type failingCredentialCache struct {
+	tokens map[CredentialCacheKey]string
+	err    error
+}
+
+func (c *failingCredentialCache) Get(key CredentialCacheKey) (string, error) {
+	return c.tokens[key], c.err
+}
+
+func (c *failingCredentialCache) Set(key CredentialCacheKey, value string) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.tokens[key] = value
+	return nil
+}
+
+func (c *failingCredentialCache) Delete(key CredentialCacheKey) error {
+	delete(c.tokens, key)
+	return c.err
+}
+
+func TestCredentialsStorageForConfig(t *testing.T) {
+	assertEqualE(t, credentialsStorageFor(&Config{}), credentialsStorage)
+
+	cache := &failingCredentialCache{tokens: map[CredentialCacheKey]string{}}
+	storage := credentialsStorageFor(&Config{CredentialCache: cache})
+	tokenSpec := newOAuthAccessTokenSpec("https://idp.example.com/token", "johndoe")
+	storage.setCredential(tokenSpec, "access-token-123")
+	assertEqualE(t, cache.tokens[CredentialCacheKey{Host: "https://idp.example.com/token", User: "johndoe", TokenType: "OAUTH_ACCESS_TOKEN"}], "access-token-123")
+	assertEqualE(t, storage.getCredential(tokenSpec), "access-token-123")
+	storage.deleteCredential(tokenSpec)
+	assertEmptyStringE(t, storage.getCredential(tokenSpec))
+
+	// errors of the cache are logged and treated like a missing token
+	cache.err = errors.New("secret store unavailable")
+	cache.tokens[tokenSpec.cacheKey()] = "access-token-123"
+	assertEmptyStringE(t, storage.getCredential(tokenSpec))
+	storage.setCredential(tokenSpec, "access-token-456")
+	assertEqualE(t, cache.tokens[tokenSpec.cacheKey()], "access-token-123")
+}
@@ -38,6 +38,12 @@ var (
 	DataTypeTime = []byte{types.TimeType.Byte()}
 	// DataTypeBoolean is a BOOLEAN datatype.
 	DataTypeBoolean = []byte{types.BooleanType.Byte()}
+	// DataTypeVector is a VECTOR datatype. Use it to bind []float32 and []int32 as VECTOR instead of ARRAY.
+	DataTypeVector = []byte{types.VectorType.Byte()}
+	// DataTypeGeography is a GEOGRAPHY datatype. Use it to bind WKB ([]byte) values as GEOGRAPHY.
+	DataTypeGeography = []byte{types.GeographyType.Byte()}
+	// DataTypeGeometry is a GEOMETRY datatype. Use it to bind WKB ([]byte) values as GEOMETRY.
+	DataTypeGeometry = []byte{types.GeometryType.Byte()}
 	// DataTypeNilObject represents a nil structured object.
 	DataTypeNilObject = []byte{types.NilObjectType.Byte()}
 	// DataTypeNilArray represents a nil structured array.
@@ -70,6 +76,12 @@ func dataTypeMode(v driver.Value) (tsmode types.SnowflakeType, err error) {
 			tsmode = types.ArrayType
 		case bytes.Equal(bd, DataTypeVariant):
 			tsmode = types.VariantType
+		case bytes.Equal(bd, DataTypeVector):
+			tsmode = types.VectorType
+		case bytes.Equal(bd, DataTypeGeography):
+			tsmode = types.GeographyType
+		case bytes.Equal(bd, DataTypeGeometry):
+			tsmode = types.GeometryType
 		case bytes.Equal(bd, DataTypeNilObject):
 			tsmode = types.NilObjectType
 		case bytes.Equal(bd, DataTypeNilArray):
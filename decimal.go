@@ -0,0 +1,148 @@
+package gosnowflake
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalScale limits the exponent of the parsed numbers, so that a short string cannot allocate a huge number.
+const maxDecimalScale = 1 << 16
+
+// Decimal is an exact decimal number: the unscaled integer divided by 10 to the power of the scale.
+// Unlike float64 and *big.Float it represents NUMBER(p,s) values, e.g. 0.1, without loss.
+// The zero value is 0. Value returns the string representation, so Decimal can be bound to any numeric column,
+// and Scan accepts all values the driver returns for the numeric columns.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal returns the decimal number unscaled * 10^-scale, e.g. NewDecimal(big.NewInt(12345), 2) is 123.45.
+func NewDecimal(unscaled *big.Int, scale int32) Decimal {
+	u := new(big.Int)
+	if unscaled != nil {
+		u.Set(unscaled)
+	}
+	if scale < 0 {
+		u.Mul(u, pow10(int(-scale)))
+		scale = 0
+	}
+	return Decimal{unscaled: u, scale: scale}
+}
+
+// ParseDecimal parses a decimal number like "-123.45" or "1.2345E2". The scale of the result is the number of
+// fractional digits, so trailing zeros are preserved, e.g. "1.50" has the scale 2.
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exponent := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil || exp > maxDecimalScale || exp < -maxDecimalScale {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		mantissa, exponent = s[:i], exp
+	}
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := strings.TrimLeft(intPart, "+-") + fracPart
+	if digits == "" || strings.ContainsAny(digits, "+-_") || len(fracPart) > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	unscaled, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return NewDecimal(unscaled, int32(len(fracPart)-exponent)), nil
+}
+
+// Unscaled returns a copy of the unscaled integer.
+func (d Decimal) Unscaled() *big.Int {
+	return new(big.Int).Set(d.unscaledOrZero())
+}
+
+// Scale returns the number of fractional digits.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// String returns the exact decimal representation of the number, e.g. "-0.10" for NewDecimal(big.NewInt(-10), 2).
+// ParseDecimal of the result returns the same number and scale.
+func (d Decimal) String() string {
+	unscaled := d.unscaledOrZero()
+	digits := new(big.Int).Abs(unscaled).String()
+	if d.scale > 0 {
+		if missing := int(d.scale) + 1 - len(digits); missing > 0 {
+			digits = strings.Repeat("0", missing) + digits
+		}
+		digits = digits[:len(digits)-int(d.scale)] + "." + digits[len(digits)-int(d.scale):]
+	}
+	if unscaled.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Rat returns the number as *big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.unscaledOrZero(), pow10(int(d.scale)))
+}
+
+// Float64 returns the float64 closest to the number.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Cmp compares the numbers regardless of their scales and returns -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	x, y := d.unscaledOrZero(), other.unscaledOrZero()
+	if d.scale < other.scale {
+		x = new(big.Int).Mul(x, pow10(int(other.scale-d.scale)))
+	} else if d.scale > other.scale {
+		y = new(big.Int).Mul(y, pow10(int(d.scale-other.scale)))
+	}
+	return x.Cmp(y)
+}
+
+// Scan implements sql.Scanner. Use sql.Null[Decimal] to scan nullable columns.
+func (d *Decimal) Scan(src any) error {
+	var err error
+	switch v := src.(type) {
+	case Decimal:
+		*d = NewDecimal(v.unscaled, v.scale)
+	case string:
+		*d, err = ParseDecimal(v)
+	case []byte:
+		*d, err = ParseDecimal(string(v))
+	case int64:
+		*d = NewDecimal(big.NewInt(v), 0)
+	case float64:
+		*d, err = ParseDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+	case *big.Int:
+		*d = NewDecimal(v, 0)
+	case *big.Float:
+		*d, err = ParseDecimal(v.Text('g', -1))
+	case nil:
+		return fmt.Errorf("cannot scan NULL into %T", d)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", src, d)
+	}
+	return err
+}
+
+// Value implements driver.Valuer.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (d Decimal) unscaledOrZero() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,155 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/snowflakedb/gosnowflake/v2/internal/query"
+	"github.com/snowflakedb/gosnowflake/v2/internal/types"
+)
+
+func TestParseDecimal(t *testing.T) {
+	testcases := []struct {
+		in       string
+		unscaled int64
+		scale    int32
+		out      string
+	}{
+		{in: "0", unscaled: 0, scale: 0, out: "0"},
+		{in: "123.45", unscaled: 12345, scale: 2, out: "123.45"},
+		{in: "-0.10", unscaled: -10, scale: 2, out: "-0.10"},
+		{in: "+.5", unscaled: 5, scale: 1, out: "0.5"},
+		{in: "7.", unscaled: 7, scale: 0, out: "7"},
+		{in: "0.000001", unscaled: 1, scale: 6, out: "0.000001"},
+		{in: "1.2345E2", unscaled: 12345, scale: 2, out: "123.45"},
+		{in: "-1e-3", unscaled: -1, scale: 3, out: "-0.001"},
+		{in: "1.5e3", unscaled: 1500, scale: 0, out: "1500"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			d, err := ParseDecimal(tc.in)
+			assertNilF(t, err)
+			assertEqualE(t, d.Unscaled().Int64(), tc.unscaled)
+			assertEqualE(t, d.Scale(), tc.scale)
+			assertEqualE(t, d.String(), tc.out)
+		})
+	}
+
+	for _, in := range []string{"", "-", ".", "1.2.3", "1e", "1e+-2", "--1", "1.-2", "0x10", "1_000", "1e99999999"} {
+		_, err := ParseDecimal(in)
+		assertNotNilE(t, err, in)
+	}
+
+	huge := "-12345678901234567890123456789.012345678"
+	d, err := ParseDecimal(huge)
+	assertNilF(t, err)
+	assertEqualE(t, d.String(), huge)
+	assertEqualE(t, Decimal{}.String(), "0")
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	tenth, err := ParseDecimal("0.1")
+	assertNilF(t, err)
+	sum := new(big.Rat)
+	for range 10 {
+		sum.Add(sum, tenth.Rat())
+	}
+	assertEqualE(t, sum.Cmp(big.NewRat(1, 1)), 0)
+	assertEqualE(t, tenth.Float64(), 0.1)
+
+	assertEqualE(t, NewDecimal(big.NewInt(10), 2).Cmp(tenth), 0)
+	assertEqualE(t, tenth.Cmp(NewDecimal(big.NewInt(11), 2)), -1)
+	assertEqualE(t, NewDecimal(big.NewInt(1), 0).Cmp(tenth), 1)
+	assertEqualE(t, NewDecimal(big.NewInt(12), -2).String(), "1200")
+
+	unscaled := big.NewInt(5)
+	d := NewDecimal(unscaled, 1)
+	unscaled.SetInt64(6)
+	d.Unscaled().SetInt64(7)
+	assertEqualE(t, d.String(), "0.5")
+}
+
+func TestDecimalScanAndValue(t *testing.T) {
+	testcases := []struct {
+		src      any
+		expected string
+	}{
+		{src: "123.45", expected: "123.45"},
+		{src: []byte("-0.01"), expected: "-0.01"},
+		{src: int64(42), expected: "42"},
+		{src: 0.1, expected: "0.1"},
+		{src: big.NewInt(-7), expected: "-7"},
+		{src: big.NewFloat(2.5), expected: "2.5"},
+		{src: NewDecimal(big.NewInt(100), 2), expected: "1.00"},
+	}
+	for _, tc := range testcases {
+		var d Decimal
+		assertNilF(t, d.Scan(tc.src))
+		assertEqualE(t, d.String(), tc.expected)
+		value, err := d.Value()
+		assertNilF(t, err)
+		assertEqualE(t, value, driver.Value(tc.expected))
+	}
+
+	var d Decimal
+	assertNotNilE(t, d.Scan(nil))
+	assertNotNilE(t, d.Scan(true))
+	assertNotNilE(t, d.Scan("abc"))
+}
+
+func TestDecimalMapping(t *testing.T) {
+	ctx := WithDecimalMappingEnabled(context.Background())
+
+	t.Run("json", func(t *testing.T) {
+		var dest driver.Value
+		value := "12345678901234567890.12"
+		assertNilF(t, stringToValue(ctx, &dest, query.ExecResponseRowType{Type: "fixed", Precision: 38, Scale: 2}, &value, nil, nil))
+		assertEqualE(t, dest.(Decimal).String(), value)
+
+		value = "123"
+		assertNilF(t, stringToValue(ctx, &dest, query.ExecResponseRowType{Type: "fixed", Precision: 38, Scale: 0}, &value, nil, nil))
+		assertEqualE(t, dest, driver.Value("123"))
+	})
+
+	t.Run("arrow", func(t *testing.T) {
+		pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer pool.AssertSize(t, 0)
+
+		decimalBuilder := array.NewDecimal128Builder(pool, &arrow.Decimal128Type{Precision: 38, Scale: 2})
+		defer decimalBuilder.Release()
+		decimalBuilder.Append(decimal128.FromI64(-10))
+		decimalBuilder.AppendNull()
+		decimals := decimalBuilder.NewArray()
+		defer decimals.Release()
+
+		dest := make([]snowflakeValue, 2)
+		meta := query.ExecResponseRowType{Type: "fixed", Precision: 38, Scale: 2}
+		assertNilF(t, arrowToValues(ctx, dest, meta, decimals, nil, true, nil))
+		assertEqualE(t, dest[0].(Decimal).String(), "-0.10")
+		assertNilE(t, dest[1])
+
+		intBuilder := array.NewInt32Builder(pool)
+		defer intBuilder.Release()
+		intBuilder.Append(12345)
+		ints := intBuilder.NewArray()
+		defer ints.Release()
+
+		dest = make([]snowflakeValue, 1)
+		meta = query.ExecResponseRowType{Type: "fixed", Precision: 9, Scale: 3}
+		assertNilF(t, arrowToValues(ctx, dest, meta, ints, nil, false, nil))
+		assertEqualE(t, dest[0].(Decimal).String(), "12.345")
+	})
+
+	t.Run("scan type", func(t *testing.T) {
+		assertEqualE(t, snowflakeTypeToGo(ctx, types.FixedType, 38, 2, nil), reflect.TypeFor[Decimal]())
+		assertEqualE(t, snowflakeTypeToGo(ctx, types.FixedType, 38, 0, nil), reflect.TypeFor[string]())
+		assertEqualE(t, snowflakeTypeToGo(context.Background(), types.FixedType, 38, 2, nil), reflect.TypeFor[float64]())
+	})
+}
@@ -176,6 +176,12 @@ The following connection parameters are supported:
 
   - disableSamlURLCheck: disables the SAML URL check. Default value is false.
 
+  - resultMemoryBudget: maximum number of bytes (uncompressed size) of downloaded result chunks kept in memory.
+    When the budget is reached, the chunk downloads wait until the rows are consumed. Default value is 0 (no limit).
+
+  - resultSpillToDisk: when set to true, the chunks downloaded over resultMemoryBudget are written to tmpDirPath
+    instead of waiting, and decoded when the rows reach them. Default value is false.
+
 All other parameters are interpreted as session parameters (https://docs.snowflake.com/en/sql-reference/parameters.html).
 For example, the TIMESTAMP_OUTPUT_FORMAT session parameter can be set by adding:
 
@@ -212,6 +218,12 @@ Alternatively, use OpenWithConfig() function to create a database handle with th
     If oauthScope is not configured, the role is used (giving session:role:<roleName> scope).
     For more information, please reach to official Snowflake documentation.
 
+  - To authenticate via OAuth on a machine without a browser (e.g. over SSH or in a notebook), specify oauth_device_code
+    and fill oauthClientId, oauthDeviceAuthorizationUrl and oauthTokenRequestUrl of the external OAuth2 IdP (oauthClientSecret and oauthScope are optional).
+    The verification URI and the user code are printed to the standard error, or passed to Config.OauthDeviceCodeCallback.
+    Open the URI on any device and enter the code, the driver polls the IdP until the authorization is completed.
+    The tokens are cached like in the authorization code flow.
+
   - To authenticate via workload identity, specify workload_identity.
 
     This option requires workloadIdentityProvider option to be set (AWS, GCP, AZURE, OIDC).
@@ -222,6 +234,34 @@ Alternatively, use OpenWithConfig() function to create a database handle with th
 
     For more details, refer to the usage guide: https://docs.snowflake.com/en/user-guide/workload-identity-federation
 
+# Custom authenticators
+
+A custom authentication method, e.g. a corporate SSO, is set in Config.CustomAuthenticator and used instead of the
+Authenticator type. Its Authenticate method fills the LoginRequest of every login attempt with the authenticator name,
+the user, the token or assertion fields and the session parameters:
+
+	type ssoAuthenticator struct{}
+
+	func (a *ssoAuthenticator) Name() string {
+		return "CORPORATE_SSO"
+	}
+
+	func (a *ssoAuthenticator) Authenticate(ctx context.Context, req *sf.LoginRequest) error {
+		token, err := fetchCorporateToken(ctx)
+		if err != nil {
+			return err
+		}
+		req.Authenticator = "OAUTH"
+		req.Token = token
+		return nil
+	}
+
+	cfg.CustomAuthenticator = &ssoAuthenticator{}
+
+If the login fails, the authenticator implementing AuthenticatorRenewer is asked to renew the invalid or expired token,
+and the authenticator implementing AuthenticatorErrorHandler is asked to handle other errors, e.g. MFA or expired password responses.
+The login is retried if they succeed, at most 3 attempts are made.
+
 # Connection Config
 
 You can also connect to your warehouse using the connection config. The database/sql package is appropriate when you want driver-specific connection features that aren’t
@@ -271,6 +311,22 @@ If you wish to specify a custom transporter (e.g. to provide a custom TLS config
 
 As an alternative, you can use the `RegisterTLSConfig` / `DeregisterTLSConfig` functions as seen in the unit tests: https://github.com/snowflakedb/gosnowflake/blob/v1.16.0/transport_test.go#L127
 
+# Connection pooling
+
+The connections implement driver.SessionResetter and driver.Validator, so database/sql checks them
+before they are reused from the pool:
+
+  - A connection is discarded if it was closed, its session expired and could not be renewed,
+    or the heartbeat reported that the session is gone (see client_session_keep_alive).
+    Network and HTTP errors of the heartbeat do not discard the connection.
+  - The role, warehouse, database and schema changed with USE statements are restored to the ones of the session
+    after the login, e.g. from the Role, Warehouse, Database and Schema of the Config.
+  - The session parameters changed with ALTER SESSION SET or returned by Snowflake with a value different from the
+    one after the login are restored with ALTER SESSION UNSET.
+  - A connection is discarded if the session used an object which was not set after the login (e.g. a warehouse),
+    if a changed session parameter was set in the Params of the Config, as unsetting it would not restore its value,
+    or if ALTER SESSION was run in a statement which cannot be parsed, e.g. a multi-statement query.
+
 # Proxy
 
 The Go Snowflake Driver honors the environment variables HTTP_PROXY, HTTPS_PROXY and NO_PROXY for the forward proxy setting.
@@ -367,6 +423,43 @@ column in the Query History log. For example:
 	ctxWithQueryTag := WithQueryTag(ctx, queryTag)
 	rows, err := db.QueryContext(ctxWithQueryTag, query)
 
+# Query parameters
+
+Session parameters can be set for the queries run with a context, without ALTER SESSION changing the
+pooled connection. The parameter names are case-insensitive. For example:
+
+	ctx := WithQueryParameters(ctx, map[string]any{
+		"TIMEZONE":             "UTC",
+		"BINARY_OUTPUT_FORMAT": "BASE64",
+		"USE_CACHED_RESULT":    false,
+	})
+	rows, err := db.QueryContext(ctx, query)
+
+If the context has a deadline, STATEMENT_TIMEOUT_IN_SECONDS is set to the time left until the deadline,
+so the warehouse stops the statement when the client gives up. The timeout is not set for asynchronous
+queries, if the session has a shorter timeout, or if STATEMENT_TIMEOUT_IN_SECONDS is set with
+WithQueryParameters (0 disables the timeout).
+
+# Query interceptors
+
+Config.QueryInterceptors wrap the statements executed with ExecContext, QueryContext and QueryArrowStream,
+the first interceptor being the outermost one. An interceptor sees the SQL text, the bindings, the query tag and
+the async mode before calling next, and the query ID, the status, the duration and the affected rows after next returns.
+It can rewrite the SQL, change the context passed to next, or short-circuit the statement without calling next:
+by returning an error, or by setting the Result (ExecContext) or Rows (QueryContext) of the query, e.g. from a cache.
+QueryArrowStream and SubmitAsync can only be short-circuited with an error.
+The statements run internally by the driver are not intercepted. For example, a slow query log:
+
+	cfg.QueryInterceptors = []sf.QueryInterceptor{
+		func(ctx context.Context, q *sf.InterceptedQuery, next sf.QueryHandler) error {
+			err := next(ctx, q)
+			if q.Duration > 10*time.Second {
+				log.Printf("slow query %v took %v", q.QueryID, q.Duration)
+			}
+			return err
+		},
+	}
+
 # Query request ID
 
 A specific query request ID can be set in the context and will be passed through
@@ -406,6 +499,27 @@ For execs:
 
 ```
 
+# Prepared statements
+
+PrepareContext sends a describe only request to the server, so SQL compilation errors are returned
+from Prepare instead of the first execution and the statement knows its parameters and result columns.
+NumInput reports the number of bind parameters, which lets database/sql validate the argument count
+before the query is sent. Result and bind metadata is available through the raw statement:
+```
+
+	err := conn.Raw(func(x any) error {
+		stmt, err := x.(driver.ConnPrepareContext).PrepareContext(ctx, "SELECT id, name FROM t WHERE id = ?")
+		for _, column := range stmt.(SnowflakeStmtMetadata).ColumnTypes() {
+			fmt.Println(column.Name, column.Type, column.Precision, column.Scale, column.Nullable)
+		}
+		bindTypes := stmt.(SnowflakeStmtMetadata).BindTypes()
+		return nil
+	}
+
+```
+
+PUT/GET commands and multi-statement queries are not described; for them NumInput returns -1 and the metadata is empty.
+
 # Fetch Results by Query ID
 
 The result of your query can be retrieved by setting the query ID in the WithFetchResultByID context.
@@ -432,6 +546,27 @@ The result of your query can be retrieved by setting the query ID in the WithFet
 
 ```
 
+# Query profile
+
+GetQueryProfile of the raw connection (QueryProfiler) returns the operator tree of a completed query with the statistics of each
+operator (rows, bytes scanned and spilled, pruned partitions and the execution time breakdown) read from
+GET_QUERY_OPERATOR_STATS. SpillingOperators and PoorlyPrunedOperators find the operators to look at, e.g. in
+performance regression tests:
+
+	err := conn.Raw(func(x any) error {
+		profile, err := x.(sf.QueryProfiler).GetQueryProfile(ctx, queryID)
+		if err != nil {
+			return err
+		}
+		for _, op := range profile.PoorlyPrunedOperators(0.8) {
+			log.Printf("%v scanned %v of %v partitions", op.Attributes["table_name"], op.Stats.PartitionsScanned, op.Stats.PartitionsTotal)
+		}
+		if spills := profile.SpillingOperators(); len(spills) > 0 {
+			...
+		}
+		return nil
+	})
+
 # Canceling Query by CtrlC
 
 From 0.5.0, a signal handling responsibility has moved to the applications. If you want to cancel a
@@ -459,6 +594,23 @@ query/command by Ctrl+C, add a os.Interrupt trap in context to execute methods t
 
 See cmd/selectmany.go for the full example.
 
+# Canceling Query by Query ID
+
+A query can also be cancelled by its query ID, e.g. one received with WithQueryIDChan, without the context it was
+executed with. CancelQuery of the raw connection (QueryCanceller) aborts the query submitted by any connection or process of the same
+user and role, and polls the query status to report whether the query was actually aborted:
+
+	err := conn.Raw(func(x any) error {
+		aborted, err := x.(sf.QueryCanceller).CancelQuery(ctx, queryID)
+		if err != nil {
+			return err
+		}
+		if !aborted {
+			// the query completed before or is still running
+		}
+		return nil
+	})
+
 # OpenTelemetry headers
 
 A context containing OpenTelemetry headers for distributed tracing can be
@@ -474,6 +626,31 @@ For example:
 	defer parent_span.End()
 	rows, err := db.QueryContext(ctx, query)
 
+# OpenTelemetry spans and metrics
+
+The driver can create spans and record metrics with the OpenTelemetry providers set in Config.
+Both are optional, nothing is recorded if they are not set:
+
+	config := &sf.Config{
+		...
+		TracerProvider: tracerProvider,
+		MeterProvider:  meterProvider,
+	}
+
+The following spans are created as children of the span in the context of the call:
+
+  - snowflake.authenticate: login request, including its retries
+  - snowflake.query.submit: query submission and polling for the result of a synchronous query
+  - snowflake.query.get_async_result: polling for the result of an asynchronous query
+  - snowflake.chunk.download: download and decoding of a single result chunk
+  - snowflake.file.upload and snowflake.file.download: transfer of a single file of a PUT or GET command
+
+The following metrics are recorded:
+
+  - snowflake.http.retries: retried requests sent within the spans above, by retry reason (HTTP status or 0 for network errors)
+  - snowflake.downloaded_bytes: bytes of result chunks and GET files downloaded, by download type (chunk or file)
+  - snowflake.chunk.download.duration: time in seconds to download and decode a result chunk
+
 # Supported Data Types
 
 The Go Snowflake Driver now supports the Arrow data format for data transfers
@@ -579,6 +756,12 @@ data types. The columns are:
     VARIANT              | string                                      | string
     -------------------------------------------------------------------------------------------------------------------
     MAP                  | map                                         | map
+    -------------------------------------------------------------------------------------------------------------------
+    VECTOR [7]           | []float32 / []int32                         | []float32 / []int32
+    -------------------------------------------------------------------------------------------------------------------
+    GEOGRAPHY [8]        | string / []byte       | Geography           | string                 | Geography
+    -------------------------------------------------------------------------------------------------------------------
+    GEOMETRY [8]         | string / []byte       | Geometry            | string                 | Geometry
 
     [1] Converting from a higher precision data type to a lower precision data type via the snowflakeRows.Scan()
     method can lose low bits (lose precision), lose high bits (completely change the value), or result in error.
@@ -597,6 +780,12 @@ data types. The columns are:
 
     [6] Arrays and objects can be either semistructured or structured, see more info in section below.
 
+    [7] VECTOR(FLOAT, n) columns are returned as []float32 and VECTOR(INT, n) columns as []int32.
+    In Arrow batches the vectors are fixed-size lists of float32 or int32 values.
+
+    [8] The spatial values are returned in the GEOGRAPHY_OUTPUT_FORMAT / GEOMETRY_OUTPUT_FORMAT of the session,
+    []byte for WKB and EWKB in Arrow format. See the Spatial Data section below.
+
 Note: SQL NULL values are converted to Golang nil values, and vice-versa.
 
 # Semistructured and structured types
@@ -624,6 +813,21 @@ When inserting, a marker indicating correct type must be used, for example:
 	db.Exec("CREATE TABLE test_object_binding (obj OBJECT)")
 	db.Exec("INSERT INTO test_object_binding SELECT (?)", DataTypeObject, "{'s': 'some string'}")
 
+Values wrapped in Variant[T], and other values implementing json.Marshaler, e.g. json.RawMessage, are marshaled
+and bound as VARIANT without any marker. Values marshaled to JSON null are bound as NULL.
+Slices of such values can be used in array binding with Array:
+
+	db.Exec("INSERT INTO semistructured (v) SELECT ?", sf.NewVariant(map[string]any{"a": 1}))
+	orders, err := sf.Array([]sf.Variant[Order]{...})
+	// handle error
+	db.Exec("INSERT INTO semistructured (v) VALUES (?)", orders)
+
+Variant[T] decodes the scanned VARIANT, OBJECT and ARRAY values from JSON into T, so json.RawMessage
+can be scanned with Variant[json.RawMessage]. Valid is false for NULL values:
+
+	var v sf.Variant[map[string]string]
+	err := rows.Scan(&v)
+
 Structured types differentiate from semistructured types by having specific schema.
 In all rows of the table, values must conform to this schema.
 Example table definition:
@@ -743,6 +947,25 @@ The only difference is - if you want to insert empty array (not nil but empty),
 
 	db.Exec('INSERT INTO some_table VALUES ?', sf.DataTypeEmptyArray, reflect.TypeOf(simpleObject{}))
 
+# Scanning rows into structs
+
+ScanStructs reads all rows into a slice of structs and QueryStructs runs the query and reads the rows. Columns are
+mapped to exported fields by the sf tag or, without the tag, by the case-insensitive field name. Fields of embedded
+structs are mapped too and fields tagged with `sf:",ignore"` are skipped. Pointer fields are nil for NULL values.
+
+	type Order struct {
+		ID       int64 `sf:"order_id"`
+		Customer string
+		Note     *string
+		Address  Address  // OBJECT column, structured or semi-structured
+		Tags     []string // ARRAY column
+	}
+
+	orders, err := sf.QueryStructs[Order](ctx, db, "SELECT order_id, customer, note, address, tags FROM orders")
+
+Struct, slice and map fields are set from structured OBJECT, ARRAY and MAP values, semi-structured values are
+decoded from JSON. An error is returned if a column matches no field.
+
 # Using higher precision numbers
 
 The following example shows how to retrieve very large values using the math/big
@@ -810,6 +1033,25 @@ of the returned value:
 	    }
 	}
 
+# Using exact decimals
+
+float64 and *big.Float are binary floating-point numbers, so they cannot represent many NUMBER(p,s) values,
+e.g. 0.1, exactly. Decimal keeps the unscaled integer and the scale of the number, so no precision is lost.
+With the WithDecimalMappingEnabled context, FIXED columns with a non-zero scale are returned as Decimal
+values, regardless of the higher precision setting:
+
+	rows, err := db.QueryContext(sf.WithDecimalMappingEnabled(ctx), "SELECT amount FROM payments")
+	...
+	var amount sf.Decimal
+	err = rows.Scan(&amount)
+	fmt.Println(amount.String(), amount.Scale())
+
+Decimal can also scan the string, int64 and float64 values returned without the context, so it can be used
+as a scan target for any numeric column. Use sql.Null[sf.Decimal] for nullable columns.
+Decimal values can be bound as parameters, they are sent as their exact string representation.
+ParseDecimal and String convert decimals from and to strings without loss, and Rat returns the value as *big.Rat
+for exact arithmetic.
+
 # Using decfloats
 
 By default, DECFLOAT values are returned as string values.
@@ -924,6 +1166,79 @@ All values are returned as strings.
 Alternative approach is to rerun a query, but without enabling Arrow batches and use a general Go SQL API instead of driver API.
 It can be optimized by using `WithRequestID`, so backend returns results from cache.
 
+# Columnar results
+
+Reading rows with database/sql converts every value to an interface value, which dominates the CPU time of
+analytical reads of wide tables. With WithColumnarResults the Arrow chunks are kept in columnar form and
+read through the driver connection as typed column vectors, using the same conversion rules as database/sql:
+
+	ctx := sf.WithColumnarResults(context.Background())
+	err = conn.Raw(func(x any) error {
+		rows, err := x.(driver.QueryerContext).QueryContext(ctx, "SELECT id, amount FROM orders", nil)
+		...
+		defer rows.Close()
+		for {
+			chunk, err := rows.(sf.ColumnChunkReader).NextColumnChunk()
+			if err == io.EOF {
+				break
+			}
+			ids, err := chunk.Int64s(0)
+			amounts, err := chunk.Float64s(1)
+			for i := range chunk.NumRows() {
+				if !amounts.IsNull(i) {
+					total[ids.Values[i]] += amounts.Values[i]
+				}
+			}
+		}
+		...
+	})
+
+ColumnChunk provides Int64s, Float64s and Decimals (exact Decimal values) for NUMBER and FLOAT columns, Strings for text and semi-structured
+columns, Bools, Binaries and Times for DATE, TIME and TIMESTAMP columns. The values may share memory with the downloaded chunk
+and are valid until the next NextColumnChunk call. Calling Next on such rows returns an error with code ErrColumnarResultsEnabled,
+and NextColumnChunk returns ErrColumnarResultsNotEnabled if the context is not set or the server returned a JSON result.
+
+# Distributed result fetching
+
+The chunks of a query result can be fetched by other processes, possibly on other hosts, without opening a session.
+The ArrowStreamLoader returned by QueryArrowStream implements ChunkDescriptorExporter, which exports the chunks of
+the current result set as versioned, JSON serializable ChunkDescriptor values. A descriptor contains the chunk URL,
+the decryption key and headers, the row types and the session time zone:
+
+	descriptors, err := loader.(sf.ChunkDescriptorExporter).ChunkDescriptors()
+	message, err := json.Marshal(descriptors[0])
+	...
+	// in a worker process
+	descriptor, err := sf.ParseChunkDescriptor(message)
+	records, err := descriptor.FetchRecords(ctx, httpClient, memory.DefaultAllocator)
+
+Fetch returns the raw (uncompressed) chunk stream, FetchRecords decodes Arrow chunks to arrow.Record values as sent by the server.
+TimeLocation returns the session time zone, which is serialized as its IANA name, or as its offset (e.g. "-07:00") for zones
+without one; it returns an error if the zone cannot be loaded in the worker process.
+The chunk URLs expire after some time (typically 6 hours) and the descriptors contain the chunk decryption key,
+so they should be treated as credentials. ParseChunkDescriptor returns an error with code ErrUnsupportedChunkDescriptorVersion
+for descriptors written in a version this driver does not support. See the cmd/distributedfetch example.
+
+# Arrow bulk ingest
+
+Data that is already held as Arrow can be loaded into a table with the separate `arrowingest` sub-package
+(`github.com/snowflakedb/gosnowflake/v2/arrowingest`), so the Parquet dependencies are only pulled in when you import it.
+The records are written to Parquet files (Snowflake cannot load Arrow IPC files directly), uploaded with streaming PUT
+to a temporary stage (or the stage set in `arrowingest.Options.Stage`) and loaded with COPY INTO, matching the columns by name:
+
+	conn, err := db.Conn(ctx)
+	...
+	res, err := arrowingest.Ingest(ctx, conn, "my_table", recordReader, &arrowingest.Options{CreateTable: true})
+	for _, file := range res.Files {
+		fmt.Println(file.File, file.Status, file.RowsLoaded, file.FirstError)
+	}
+
+The result contains the load result of every file as reported by COPY INTO. With `CreateTable` the table is created if it
+does not exist, mapping the Arrow types to Snowflake types (integers to NUMBER(38, 0), decimals to NUMBER(p, s),
+timestamps to TIMESTAMP_NTZ or TIMESTAMP_TZ depending on the time zone, lists to ARRAY, structs and maps to OBJECT).
+Types that cannot be mapped are reported with the ErrUnsupportedArrowIngestType error code.
+Use `arrowingest.Options.FileSizeThreshold` to control the (uncompressed) size of the records written to a single file.
+
 # Binding Parameters
 
 Binding allows a SQL statement to use a value that is stored in a Golang variable.
@@ -952,6 +1267,18 @@ which are mapped to single Go time.Time type:
 
 	rows, err := db.Query("SELECT * FROM TABLE(SOMEFUNCTION(?))", sf.TypedNullTime{sql.NullTime{}, sf.TimestampLTZType})
 
+Named parameters are bound with sql.Named. Each :name parameter in the statement is replaced with the :N
+placeholder of its value, so a named parameter can be used several times:
+
+	_, err = db.Exec("UPDATE table1 SET integer_column = :value WHERE ID = :id OR PARENT_ID = :id",
+		sql.Named("value", 42), sql.Named("id", 1000))
+
+A colon directly after an identifier, a closing bracket or a quote (e.g. col:name) accesses a semi-structured value and
+is not a parameter. Named and positional values cannot be mixed in one statement. A SnowflakeError with the
+ErrBindNamedParameter code is returned if a parameter has no value or a value does not match any parameter.
+The statement is rewritten only when it is executed with named values, so :name references to Snowflake Scripting
+variables are sent unchanged otherwise. NumInput of a prepared statement containing :name returns -1.
+
 # Binding Parameters to Array Variables
 
 Version 1.3.9 (and later) of the Go Snowflake Driver supports the ability to bind an array variable to a parameter in a SQL
@@ -1038,6 +1365,36 @@ the CREATE TEMPORARY STAGE command executed by the driver can fail with the foll
 For alternative ways to load data into the Snowflake database (including bulk loading using the COPY command),
 see Loading Data into Snowflake (https://docs.snowflake.com/en/user-guide-data-load.html).
 
+# Streaming Array Binding
+
+Array binding with Array() requires all values to be in memory. To insert more rows than fit in memory,
+bind a RowSource with BindRowSource instead. The driver reads the rows one by one, encodes them into
+compressed CSV files and uploads the files one by one to the temporary bind stage while later rows are still being read,
+so the memory usage does not depend on the number of rows. The same privileges as for batch inserts are required.
+
+The row source must be the only argument of the query and every row must have one value per bind parameter.
+Execute the query without Prepare: a prepared statement reports the number of its bind parameters, so database/sql
+rejects the single row source argument of a statement with more than one parameter.
+Rows can be produced by a goroutine sending to a channel or by an iterator:
+
+	rows := make(chan []driver.Value)
+	go func() {
+		defer close(rows)
+		for i := 0; i < 10000000; i++ {
+			select {
+			case rows <- []driver.Value{i, fmt.Sprintf("row %v", i)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	_, err = db.ExecContext(ctx, "insert into my_table values (?, ?)", sf.BindRowSource(sf.RowSourceFromChannel(rows)))
+
+	_, err = db.ExecContext(ctx, "insert into my_table values (?, ?)", sf.BindRowSource(sf.RowSourceFromSeq(seq)))
+
+Values are converted like regular bind values. time.Time values are bound as TIMESTAMP_NTZ unless they are wrapped in
+TypedNullTime. Reading the row source stops at the first error returned by it and the query is not executed.
+
 # Binding a Parameter to a Time Type
 
 Go's database/sql package supports the ability to bind a parameter in a SQL statement to a time.Time variable.
@@ -1076,7 +1433,20 @@ use in a geographical area, such as CET (Central European Time) or UTC
 cached when a Go Snowflake Driver application starts, and if the given offset
 is not in the cache, it is generated dynamically.
 
-Currently, Snowflake does not support the name-based Location types (e.g. "America/Los_Angeles").
+TIMESTAMP_LTZ values are returned in the session time zone (the TIMEZONE parameter). It is a name-based Location
+(e.g. "America/Los_Angeles"), so the values follow the daylight saving time changes of the zone. Name-based Locations are
+cached and available with LocationWithName.
+
+TIMESTAMP_TZ values keep only the offset, so by default they are returned with the offset-based Location. To get them in a
+name-based Location, use WithTimestampTzLocation. The values are returned in the given Location if it had the same offset at
+that time, otherwise they keep the offset-based Location:
+
+	loc, err := sf.LocationWithName("Europe/Warsaw")
+	// ...
+	rows, err := db.QueryContext(sf.WithTimestampTzLocation(ctx, loc), "SELECT ts_tz FROM events")
+
+When time.Time values from name-based Locations are bound as TIMESTAMP_TZ, the offset of the zone at that time is sent.
+The same applies to TIMESTAMP_TZ and TIMESTAMP_LTZ values in bulk array bindings, so the values are not read in the session time zone.
 
 For more information about Location types, see the Go documentation for https://golang.org/pkg/time/#Location.
 
@@ -1089,6 +1459,39 @@ example, sf is an alias for the gosnowflake package:
 	var b = []byte{0x01, 0x02, 0x03}
 	_, err = stmt.Exec(sf.DataTypeBinary, b)
 
+# Vector Data
+
+Without the binding parameter flag, []float32 and []int32 values are bound as ARRAY.
+Use sf.DataTypeVector to bind them as VECTOR, e.g. to insert embeddings or to search by similarity:
+
+	embedding := []float32{0.1, 0.2, 0.3}
+	rows, err := db.Query("SELECT id FROM docs ORDER BY VECTOR_COSINE_SIMILARITY(embedding, ?::VECTOR(FLOAT, 3)) DESC LIMIT 10",
+		sf.DataTypeVector, embedding)
+
+Multiple rows of vectors are bound with sf.Array([][]float32{...}) or sf.Array([][]int32{...}).
+
+# Spatial Data
+
+GEOGRAPHY and GEOMETRY values can be scanned into sf.Geography and sf.Geometry, which decode all output formats
+(GeoJSON, WKT, EWKT, WKB and EWKB) to a Shape with the points, lines or polygons of the value. The SRID of
+Geography is always 4326. The SRID of Geometry is set when the value is read in the EWKT or EWKB format.
+NULL values are scanned with Valid set to false.
+
+	var area sf.Geography
+	err = db.QueryRow("SELECT area FROM geofences WHERE id = ?", id).Scan(&area)
+	for _, ring := range area.Shape.Lines {
+		...
+	}
+
+Geography and Geometry values are bound as WKT (EWKT if Geometry.SRID is set), Shape.WKT and Shape.WKB return
+other representations. To bind WKB directly, use sf.DataTypeGeography or sf.DataTypeGeometry:
+
+	_, err = db.Exec("INSERT INTO geofences(id, area) VALUES (?, ?)", id, sf.DataTypeGeography, wkb)
+
+In Arrow batches, GEOGRAPHY and GEOMETRY columns are WKB binary columns with the GeoArrow extension type
+geoarrow.wkb set in the field metadata. Values in all output formats, including EWKT and EWKB, are converted to ISO WKB.
+The CRS of GEOGRAPHY columns is EPSG:4326; the CRS of GEOMETRY columns is set from the SRID of the values if all values
+of the batch have the same SRID (e.g. in the EWKT or EWKB output format).
 # JWT authentication
 
 The Go Snowflake Driver supports JWT (JSON Web Token) authentication.
@@ -1125,9 +1528,20 @@ To generate the valid key pair, you can execute the following commands in the sh
 	    	-in rsa-2048-private-key.p8 \
 	    	-out rsa-2048-public-key.spki
 
-Note: As of February 2020, Golang's official library does not support passcode-encrypted PKCS8 private key.
-For security purposes, Snowflake highly recommends that you store the passcode-encrypted private key on the disk and
-decrypt the key in your application using a library you trust.
+For security purposes, Snowflake highly recommends that you store the passphrase-encrypted private key on the disk.
+The driver reads the PEM encoded PKCS8 private key from a file set with the "privateKeyFile" and "privateKeyFilePwd"
+DSN parameters (or the PrivateKeyFile and PrivateKeyFilePwd Config fields, or "private_key_file" and "private_key_file_pwd"
+in connections.toml), and decrypts the PBES2 encrypted keys (AES-CBC with PBKDF2 or scrypt) itself:
+
+		# generate 2048-bit pkcs8 encoded RSA private key encrypted with a passphrase
+		openssl genpkey -algorithm RSA -pkeyopt rsa_keygen_bits:2048 | \
+			openssl pkcs8 -topk8 -v2 aes-256-cbc -out rsa_key.p8
+
+	authenticator=SNOWFLAKE_JWT&privateKeyFile=%2Fpath%2Fto%2Frsa_key.p8&privateKeyFilePwd=<your_passphrase>
+
+If the private key cannot be exported, e.g. it is kept in an HSM or a KMS, set the PrivateKeySigner Config field to a
+crypto.Signer of the RSA key instead. The driver signs the JWT with it using RS256 (PKCS #1 v1.5 with SHA-256).
+Only one of PrivateKey, PrivateKeySigner and PrivateKeyFile can be set.
 
 JWT tokens are recreated on each retry and they are valid (`exp` claim) for `jwtTimeout` seconds.
 Each retry timeout is configured by `jwtClientTimeout`.
@@ -1157,6 +1571,32 @@ or using a Config structure with following ExternalBrowserTimeout specified:
 		ExternalBrowserTimeout: 240 * time.Second, // Requires time.Duration
 	}
 
+# Credential cache
+
+When clientStoreTemporaryCredential (ID tokens of external browser authentication and OAuth tokens) or
+clientRequestMfaToken (MFA tokens) is enabled, the driver caches the tokens, so the user is not prompted on every login.
+By default the tokens are stored in the OS keyring on Windows and macOS and in a JSON file under ~/.cache/snowflake on Linux.
+
+To use a different store, set the CredentialCache field of Config. The tokens are keyed by host, user and token type:
+
+	type vaultCache struct{ ... }
+
+	func (c *vaultCache) Get(key sf.CredentialCacheKey) (string, error) { ... }
+	func (c *vaultCache) Set(key sf.CredentialCacheKey, value string) error { ... }
+	func (c *vaultCache) Delete(key sf.CredentialCacheKey) error { ... }
+
+	config := &sf.Config{
+		...
+		ClientStoreTemporaryCredential: sf.ConfigBoolTrue,
+		CredentialCache:                &vaultCache{},
+	}
+
+The driver provides an encrypted file implementation. The 32 bytes long key should come from a secret store:
+
+	cache, err := sf.NewEncryptedFileCredentialCache("/var/lib/myapp/snowflake_tokens", key)
+
+Errors returned by the cache are logged and the token is treated as missing.
+
 # Executing Multiple Statements in One Call
 
 This feature is available in version 1.3.8 or later of the driver.
@@ -1376,6 +1816,38 @@ and before retrieving the results. For a more elaborative example please see cmd
 			...
 		}
 
+The raw connection also offers a handle of the asynchronous query. SubmitAsync submits the query
+and returns an AsyncQuery with the query ID, its status, the result and Cancel. Wait polls the status
+with backoff until the query completes, calling the callback when the status changes, e.g. to QUEUED,
+RESUMING_WAREHOUSE or BLOCKED. The ID can be stored and passed to ResumeAsync on another connection
+or in another process to get the handle of the same query:
+
+	conn, _ := db.Conn(ctx)
+	var asyncQuery *sf.AsyncQuery
+	err := conn.Raw(func(x any) (err error) {
+		asyncQuery, err = x.(sf.AsyncQuerySubmitter).SubmitAsync(ctx, "SELECT ...", nil)
+		return err
+	})
+	...
+	// later, possibly in another process
+	err = conn.Raw(func(x any) error {
+		asyncQuery, err := x.(sf.AsyncQuerySubmitter).ResumeAsync(queryID)
+		if err != nil {
+			return err
+		}
+		err = asyncQuery.Wait(ctx, func(status *sf.SnowflakeQueryStatus) {
+			log.Printf("query %v is %v", asyncQuery.ID(), status.Status)
+		})
+		if err != nil {
+			return err
+		}
+		rows, err := asyncQuery.Rows(ctx)
+		...
+	})
+
+ArrowBatches returns the result as an ArrowStreamLoader. To read it with arrowbatches.GetArrowBatches,
+call Rows with the context returned by arrowbatches.WithArrowBatches.
+
 ==> Some considerations related to the ServerSessionKeepAlive configuration option in context of asynchronous query execution
 
 When SQL Go connection is being closed, it performs the following actions:
@@ -1481,6 +1953,25 @@ Using custom configuration for PUT/GET:
 If you want to override some default configuration options, you can use `WithFileTransferOptions` context.
 There are multiple config parameters including progress bars or compression.
 
+Resuming interrupted PUT and GET:
+
+Large transfers to and from S3, Azure, GCS and local stages can be resumed after a network failure or a crash.
+Set Resumable in the file transfer options and the driver saves a checkpoint of every file
+in CheckpointDir (snowflake_transfer_checkpoints in the temporary directory by default):
+
+	ctx := WithFileTransferOptions(context.Background(), &SnowflakeFileTransferOptions{
+		Resumable:     true,
+		CheckpointDir: "/var/lib/myapp/checkpoints",
+	})
+	_, err = db.ExecContext(ctx, "PUT file:///data/big.csv @mystage")
+
+Running the same PUT or GET again continues where the failed one stopped: PUT keeps the compressed and encrypted
+file and the parts uploaded with an S3 multipart upload, as Azure blocks or in a GCS resumable upload session,
+GET continues the download after the bytes already written. A checkpoint is discarded when the local file of a PUT or
+the staged file of a GET changed since (for GCS, when the object generation changed), and it is deleted when the transfer
+completes. Interrupted downloads are also resumed by the retries of the same GET. PUT to GCS stages with presigned URLs
+and streaming PUT and GET are transferred in full.
+
 # Minicore (Native Library)
 
 The Go Snowflake Driver includes an embedded native library called "minicore" that verifies loading of native Rust extensions on various platforms. By default, minicore is enabled and loaded dynamically at runtime.
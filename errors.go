@@ -76,6 +76,7 @@ const (
 	queryNotExecutingCode       = "000605"
 	queryInProgressCode         = "333333"
 	queryInProgressAsyncCode    = "333334"
+	sessionGoneCode             = "390111"
 	sessionExpiredCode          = "390112"
 	invalidOAuthAccessTokenCode = "390303"
 	expiredOAuthAccessTokenCode = "390318"
@@ -161,6 +162,12 @@ const (
 	ErrFailedToGetChunk = sferrors.ErrFailedToGetChunk
 	// ErrNonArrowResponseInArrowBatches is an error code for case where ArrowBatches mode is enabled, but response is not Arrow-based
 	ErrNonArrowResponseInArrowBatches = sferrors.ErrNonArrowResponseInArrowBatches
+	// ErrUnsupportedChunkDescriptorVersion is an error code for a chunk descriptor with an unknown version
+	ErrUnsupportedChunkDescriptorVersion = sferrors.ErrUnsupportedChunkDescriptorVersion
+	// ErrColumnarResultsEnabled is an error code for reading rows one by one when columnar results are enabled
+	ErrColumnarResultsEnabled = sferrors.ErrColumnarResultsEnabled
+	// ErrColumnarResultsNotEnabled is an error code for reading column chunks when columnar results are not enabled or not available
+	ErrColumnarResultsNotEnabled = sferrors.ErrColumnarResultsNotEnabled
 
 	/* transaction*/
 
@@ -202,6 +209,8 @@ const (
 	ErrBindSerialization = sferrors.ErrBindSerialization
 	// ErrBindUpload is an error code for the uploading process of bind elements to the stage
 	ErrBindUpload = sferrors.ErrBindUpload
+	// ErrBindNamedParameter is an error code for the named bind values not matching the named parameters of the query
+	ErrBindNamedParameter = sferrors.ErrBindNamedParameter
 
 	/* async */
 
@@ -228,6 +237,8 @@ const (
 	ErrNullValueInArray = sferrors.ErrNullValueInArray
 	// ErrNullValueInMap is an error code for the case where there are null values in a map without mapValuesNullable set to true
 	ErrNullValueInMap = sferrors.ErrNullValueInMap
+	// ErrInvalidTimezoneName is an error code for the case where a time zone name is not a valid IANA time zone name
+	ErrInvalidTimezoneName = sferrors.ErrInvalidTimezoneName
 
 	/* OCSP */
 
@@ -240,6 +251,13 @@ const (
 	// ErrOCSPNoOCSPResponderURL is an error code for the case where the OCSP responder URL is not attached.
 	ErrOCSPNoOCSPResponderURL = sferrors.ErrOCSPNoOCSPResponderURL
 
+	/* arrow ingest */
+
+	// ErrArrowIngest is an error code for a failed serialization or load of arrow records
+	ErrArrowIngest = sferrors.ErrArrowIngest
+	// ErrUnsupportedArrowIngestType is an error code for an arrow type that cannot be mapped to a Snowflake column type
+	ErrUnsupportedArrowIngestType = sferrors.ErrUnsupportedArrowIngestType
+
 	/* query Status*/
 
 	// ErrQueryStatus when check the status of a query, receive error or no status
@@ -87,6 +87,15 @@ type SnowflakeFileTransferOptions struct {
 	showProgressBar    bool
 	MultiPartThreshold int64
 
+	/* resumable PUT and GET */
+	// Resumable saves a checkpoint of every file transferred to or from S3, Azure, GCS and local stages,
+	// so running a failed PUT or GET of the same files again continues where it stopped.
+	// PUT to GCS stages with presigned URLs and streaming transfers are not resumable.
+	Resumable bool
+	// CheckpointDir is the directory of the checkpoints. If it is empty,
+	// snowflake_transfer_checkpoints in Config.TmpDirPath or the system temporary directory is used.
+	CheckpointDir string
+
 	/* streaming PUT */
 	compressSourceFromStream bool
 
@@ -918,10 +927,21 @@ func (sfa *snowflakeFileTransferAgent) uploadFilesSequential(fileMetas []*fileMe
 	return nil
 }
 
-func (sfa *snowflakeFileTransferAgent) uploadOneFile(meta *fileMetadata) (*fileMetadata, error) {
+func (sfa *snowflakeFileTransferAgent) uploadOneFile(meta *fileMetadata) (_ *fileMetadata, err error) {
+	ctx, span := sfa.sc.instrumentation().startSpan(sfa.ctx, spanFileUpload, attrFileName.String(meta.name))
+	defer func() {
+		span.SetAttributes(attrFileSize.Int64(meta.uploadSize), attrFileStatus.String(meta.resStatus.String()))
+		endSpan(span, err)
+	}()
 	meta.realSrcFileName = meta.srcFileName
 	tmpDir := ""
-	if meta.fileStream == nil {
+	prepared := false
+	if isResumableUpload(meta) {
+		// the prepared file is kept in the checkpoint directory until the upload completes
+		if prepared, err = startResumableUpload(meta); err != nil {
+			return meta, err
+		}
+	} else if meta.fileStream == nil {
 		var err error
 		tmpDir, err = os.MkdirTemp(sfa.sc.cfg.TmpDirPath, "")
 		if err != nil {
@@ -935,27 +955,38 @@ func (sfa *snowflakeFileTransferAgent) uploadOneFile(meta *fileMetadata) (*fileM
 		}
 	}()
 
-	fileUtil := new(snowflakeFileUtil)
+	if !prepared {
+		fileUtil := new(snowflakeFileUtil)
 
-	err := compressDataIfRequired(meta, fileUtil, tmpDir)
-	if err != nil {
-		return meta, err
-	}
+		err = compressDataIfRequired(meta, fileUtil, meta.tmpDir)
+		if err != nil {
+			return meta, err
+		}
 
-	err = updateUploadSize(meta, fileUtil)
-	if err != nil {
-		return meta, err
-	}
+		err = updateUploadSize(meta, fileUtil)
+		if err != nil {
+			return meta, err
+		}
 
-	err = encryptDataIfRequired(meta, sfa.stageLocationType)
-	if err != nil {
-		return meta, err
+		err = encryptDataIfRequired(meta, sfa.stageLocationType)
+		if err != nil {
+			return meta, err
+		}
+
+		if meta.resumable != nil {
+			if err = savePreparedFile(meta); err != nil {
+				return meta, err
+			}
+		}
 	}
 
 	client := sfa.getStorageClient(sfa.stageLocationType)
-	if err = client.uploadOneFileWithRetry(sfa.ctx, meta); err != nil {
+	if err = client.uploadOneFileWithRetry(ctx, meta); err != nil {
 		return meta, err
 	}
+	if meta.resumable != nil && (meta.resStatus == uploaded || meta.resStatus == skipped) {
+		meta.resumable.remove()
+	}
 	return meta, nil
 }
 
@@ -1042,7 +1073,16 @@ func (sfa *snowflakeFileTransferAgent) downloadFilesParallel(fileMetas []*fileMe
 	return err
 }
 
-func (sfa *snowflakeFileTransferAgent) downloadOneFile(ctx context.Context, meta *fileMetadata) (*fileMetadata, error) {
+func (sfa *snowflakeFileTransferAgent) downloadOneFile(ctx context.Context, meta *fileMetadata) (_ *fileMetadata, err error) {
+	inst := sfa.sc.instrumentation()
+	ctx, span := inst.startSpan(ctx, spanFileDownload, attrFileName.String(meta.name))
+	defer func() {
+		if err == nil {
+			inst.recordDownloadedBytes(ctx, "file", meta.dstFileSize)
+		}
+		span.SetAttributes(attrFileSize.Int64(meta.dstFileSize), attrFileStatus.String(meta.resStatus.String()))
+		endSpan(span, err)
+	}()
 	if !isFileGetStream(ctx) {
 		tmpDir, err := os.MkdirTemp(sfa.sc.cfg.TmpDirPath, "")
 		if err != nil {
@@ -161,6 +161,9 @@ type fileMetadata struct {
 	/* streaming GET */
 	dstStream *bytes.Buffer
 
+	/* resumable PUT and GET */
+	resumable *resumableTransfer
+
 	/* GCS */
 	presignedURL                *url.URL
 	gcsFileHeaderDigest         string
@@ -171,6 +174,7 @@ type fileMetadata struct {
 	mockUploader    s3UploadAPI
 	mockDownloader  s3DownloadAPI
 	mockHeader      s3HeaderAPI
+	mockObjectAPI   s3ObjectAPI
 	mockGcsClient   gcsAPI
 	mockAzureClient azureAPI
 }
@@ -4,8 +4,10 @@ import (
 	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"os"
@@ -21,6 +23,9 @@ const (
 	gcsFileHeaderDigest           = "gcs-file-header-digest"
 	gcsRegionMeCentral2           = "me-central2"
 	minimumDownloadPartSize       = 1024 * 1024 * 5 // 5MB
+	gcsChunkGranularity           = 256 * 1024
+	// status of the requests of resumable uploads which did not complete the upload
+	gcsResumeIncomplete = 308
 )
 
 type snowflakeGcsClient struct {
@@ -216,43 +221,30 @@ func (util *snowflakeGcsClient) uploadFile(
 		gcsHeaders[gcsMetadataMatdescKey] = meta.encryptMeta.matdesc
 	}
 
-	var uploadSrc io.Reader
-	if meta.srcStream != nil {
-		uploadSrc = meta.srcStream
-		if meta.realSrcStream != nil {
-			uploadSrc = meta.realSrcStream
-		}
+	var resp *http.Response
+	if meta.resumable != nil && accessToken != "" && meta.srcStream == nil {
+		resp, err = util.uploadFileInSession(ctx, dataFile, meta, uploadURL, gcsHeaders, multiPartThreshold)
 	} else {
-		var err error
-		uploadSrc, err = os.Open(dataFile)
-		if err != nil {
-			return err
-		}
-		defer func(src io.Closer) {
-			if err := src.Close(); err != nil {
-				logger.Warnf("failed to close %v file: %v", dataFile, err)
+		var uploadSrc io.Reader
+		if meta.srcStream != nil {
+			uploadSrc = meta.srcStream
+			if meta.realSrcStream != nil {
+				uploadSrc = meta.realSrcStream
 			}
-		}(uploadSrc.(io.Closer))
-	}
-
-	resp, err := withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (*http.Response, error) {
-		req, err := http.NewRequestWithContext(ctx, "PUT", uploadURL.String(), uploadSrc)
-		if err != nil {
-			return nil, err
-		}
-		for k, v := range gcsHeaders {
-			req.Header.Add(k, v)
-		}
-		client, err := newGcsClient(util.cfg, util.telemetry)
-		if err != nil {
-			return nil, err
-		}
-		// for testing only
-		if meta.mockGcsClient != nil {
-			client = meta.mockGcsClient
+		} else {
+			var err error
+			uploadSrc, err = os.Open(dataFile)
+			if err != nil {
+				return err
+			}
+			defer func(src io.Closer) {
+				if err := src.Close(); err != nil {
+					logger.Warnf("failed to close %v file: %v", dataFile, err)
+				}
+			}(uploadSrc.(io.Closer))
 		}
-		return client.Do(req)
-	})
+		resp, err = util.sendUploadRequest(ctx, meta, http.MethodPut, uploadURL.String(), uploadSrc, gcsHeaders)
+	}
 
 	if err != nil {
 		return err
@@ -264,10 +256,14 @@ func (util *snowflakeGcsClient) uploadFile(
 			}
 		}
 	}()
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		if resp.StatusCode == 403 || resp.StatusCode == 408 || resp.StatusCode == 429 || resp.StatusCode == 500 || resp.StatusCode == 503 {
 			meta.lastError = fmt.Errorf("%v", resp.Status)
 			meta.resStatus = needRetry
+		} else if meta.resumable != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone) {
+			// the upload session expired, the next attempt starts a new one
+			meta.lastError = fmt.Errorf("%v", resp.Status)
+			meta.resStatus = needRetry
 		} else if accessToken == "" && resp.StatusCode == 400 && meta.lastError == nil {
 			meta.lastError = fmt.Errorf("%v", resp.Status)
 			meta.resStatus = renewPresignedURL
@@ -301,6 +297,157 @@ func (util *snowflakeGcsClient) uploadFile(
 	return nil
 }
 
+// sendUploadRequest sends the upload request with the headers to GCS.
+func (util *snowflakeGcsClient) sendUploadRequest(ctx context.Context, meta *fileMetadata, method, uploadURL string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	return withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, method, uploadURL, body)
+		if err != nil {
+			return nil, err
+		}
+		if section, ok := body.(*io.SectionReader); ok {
+			req.ContentLength = section.Size()
+		}
+		for k, v := range headers {
+			req.Header.Add(k, v)
+		}
+		client, err := newGcsClient(util.cfg, util.telemetry)
+		if err != nil {
+			return nil, err
+		}
+		// for testing only
+		if meta.mockGcsClient != nil {
+			client = meta.mockGcsClient
+		}
+		return client.Do(req)
+	})
+}
+
+// uploadFileInSession uploads dataFile in chunks with a GCS resumable upload session recorded in the checkpoint of meta,
+// so an interrupted upload continues after the last byte persisted by GCS. It returns the response of the request
+// completing the upload, or of the first failed one. The session is started with the access token,
+// see https://cloud.google.com/storage/docs/performing-resumable-uploads.
+func (util *snowflakeGcsClient) uploadFileInSession(
+	ctx context.Context,
+	dataFile string,
+	meta *fileMetadata,
+	uploadURL *url.URL,
+	gcsHeaders map[string]string,
+	multiPartThreshold int64) (*http.Response, error) {
+	file, err := os.Open(dataFile)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = file.Close(); err != nil {
+			logger.Warnf("failed to close %v file: %v", dataFile, err)
+		}
+	}()
+
+	rt := meta.resumable
+	size := meta.uploadSize
+	sessionURL := rt.checkpoint().UploadID
+	var offset int64
+	if sessionURL != "" {
+		// GCS may have persisted fewer bytes than the checkpoint recorded
+		resp, err := util.sendUploadRequest(ctx, meta, http.MethodPut, sessionURL, nil, map[string]string{"Content-Range": fmt.Sprintf("bytes */%v", size)})
+		if err != nil {
+			return nil, err
+		}
+		switch resp.StatusCode {
+		case http.StatusOK, http.StatusCreated:
+			return resp, nil
+		case gcsResumeIncomplete:
+			closeGcsResponse(resp)
+			offset = gcsPersistedOffset(resp)
+			logger.Infof("resuming upload of %v at byte %v of %v", rt.checkpoint().Source, offset, size)
+		case http.StatusNotFound, http.StatusGone:
+			closeGcsResponse(resp)
+			logger.Infof("upload session of %v expired, starting the upload over", rt.checkpoint().Source)
+			rt.resetParts()
+			sessionURL = ""
+		default:
+			return resp, nil
+		}
+	}
+	if sessionURL == "" {
+		headers := maps.Clone(gcsHeaders)
+		headers["x-goog-resumable"] = "start"
+		resp, err := util.sendUploadRequest(ctx, meta, http.MethodPost, uploadURL.String(), nil, headers)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusCreated {
+			return resp, nil
+		}
+		closeGcsResponse(resp)
+		if sessionURL = resp.Header.Get("Location"); sessionURL == "" {
+			return nil, errors.New("GCS did not return the URL of the upload session")
+		}
+		if err = rt.update(func(cp *transferCheckpoint) {
+			cp.UploadID = sessionURL
+			cp.Offset = 0
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// all chunks except the last one must be multiples of 256KiB
+	chunkSize := rt.partSize(size, multiPartThreshold)
+	chunkSize = (chunkSize + gcsChunkGranularity - 1) / gcsChunkGranularity * gcsChunkGranularity
+	for {
+		length := min(chunkSize, size-offset)
+		contentRange := fmt.Sprintf("bytes */%v", size)
+		if length > 0 {
+			total := "*"
+			if offset+length == size {
+				total = strconv.FormatInt(size, 10)
+			}
+			contentRange = fmt.Sprintf("bytes %v-%v/%v", offset, offset+length-1, total)
+		}
+		resp, err := util.sendUploadRequest(ctx, meta, http.MethodPut, sessionURL, io.NewSectionReader(file, offset, length), map[string]string{"Content-Range": contentRange})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != gcsResumeIncomplete {
+			if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+				rt.resetParts()
+			}
+			return resp, nil
+		}
+		closeGcsResponse(resp)
+		persisted := gcsPersistedOffset(resp)
+		if length > 0 && persisted <= offset {
+			return nil, fmt.Errorf("GCS did not persist the bytes of %v after byte %v", dataFile, offset)
+		}
+		offset = persisted
+		if err = rt.update(func(cp *transferCheckpoint) { cp.Offset = offset }); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// gcsPersistedOffset returns the number of bytes persisted by GCS from the Range header of the 308 response, e.g. bytes=0-1023.
+func gcsPersistedOffset(resp *http.Response) int64 {
+	_, last, found := strings.Cut(resp.Header.Get("Range"), "-")
+	if !found {
+		return 0
+	}
+	n, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n + 1
+}
+
+func closeGcsResponse(resp *http.Response) {
+	if resp.Body == nil {
+		return
+	}
+	if err := resp.Body.Close(); err != nil {
+		logger.Warnf("failed to close response body: %v", err)
+	}
+}
+
 // cloudUtil implementation
 func (util *snowflakeGcsClient) nativeDownloadFile(
 	ctx context.Context,
@@ -338,8 +485,10 @@ func (util *snowflakeGcsClient) nativeDownloadFile(
 	}
 	fileSize := fileHeader.ContentLength
 
-	// Use multi-part download for files larger than partSize or when maxConcurrency > 1
-	if fileSize > partSize && maxConcurrency > 1 {
+	if isResumable(meta) && !isFileGetStream(ctx) {
+		err = util.downloadFileResumable(ctx, downloadURL, gcsHeaders, accessToken, meta, fullDstFileName, fileHeader)
+	} else if fileSize > partSize && maxConcurrency > 1 {
+		// Use multi-part download for files larger than partSize or when maxConcurrency > 1
 		err = util.downloadFileInParts(ctx, downloadURL, gcsHeaders, accessToken, meta, fullDstFileName, fileSize, maxConcurrency, partSize)
 	} else {
 		// Fall back to single-part download for smaller files
@@ -373,6 +522,70 @@ func (util *snowflakeGcsClient) nativeDownloadFile(
 	return nil
 }
 
+// downloadFileResumable downloads the file with ranged GET requests, continuing after the bytes downloaded
+// by an earlier attempt. The object generation is saved in the checkpoint, so a replaced object is downloaded from the start.
+func (util *snowflakeGcsClient) downloadFileResumable(
+	ctx context.Context,
+	downloadURL *url.URL,
+	gcsHeaders map[string]string,
+	accessToken string,
+	meta *fileMetadata,
+	fullDstFileName string,
+	fileHeader *http.Response) error {
+	generation := gcsObjectGeneration(fileHeader.Header)
+	if err := startResumableDownload(meta, fullDstFileName, fileHeader.ContentLength, fileHeader.Header.Get(gcsMetadataSfcDigest), generation, 0, ""); err != nil {
+		return err
+	}
+	var httpErr error
+	err := meta.resumable.writeFile(fullDstFileName, fileHeader.ContentLength, func(w io.Writer, offset int64) error {
+		resp, err := withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (*http.Response, error) {
+			req, err := http.NewRequestWithContext(ctx, "GET", downloadURL.String(), nil)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range gcsHeaders {
+				req.Header.Add(k, v)
+			}
+			req.Header.Set("Range", fmt.Sprintf("bytes=%v-", offset))
+			client, err := newGcsClient(util.cfg, util.telemetry)
+			if err != nil {
+				return nil, err
+			}
+			// for testing only
+			if meta.mockGcsClient != nil {
+				client = meta.mockGcsClient
+			}
+			return client.Do(req)
+		})
+		if err != nil {
+			return err
+		}
+		defer closeGcsResponse(resp)
+		// the whole object is returned if the range is ignored, which can only be used at the start
+		if resp.StatusCode != http.StatusPartialContent && (offset > 0 || resp.StatusCode != http.StatusOK) {
+			httpErr = util.handleHTTPError(resp, meta, accessToken)
+			return httpErr
+		}
+		if g := gcsObjectGeneration(resp.Header); g != generation {
+			return fmt.Errorf("object %v changed during the download, generation %v instead of %v", meta.srcFileName, g, generation)
+		}
+		_, err = io.Copy(w, resp.Body)
+		return err
+	})
+	if err != nil && httpErr == nil {
+		// the next attempt continues from the checkpoint
+		meta.lastError = err
+		meta.resStatus = needRetry
+	}
+	meta.srcFileSize = fileHeader.ContentLength
+	return err
+}
+
+// gcsObjectGeneration returns the generation of the object, or its ETag if the generation is not returned.
+func gcsObjectGeneration(header http.Header) string {
+	return cmp.Or(header.Get("x-goog-generation"), header.Get("ETag"))
+}
+
 // getFileHeaderForDownload gets the file header using a HEAD request
 func (util *snowflakeGcsClient) getFileHeaderForDownload(ctx context.Context, downloadURL *url.URL, gcsHeaders map[string]string, accessToken string, meta *fileMetadata) (*http.Response, error) {
 	resp, err := withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (*http.Response, error) {
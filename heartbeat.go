@@ -107,11 +107,25 @@ func (hc *heartbeat) heartbeatMain() error {
 			logger.WithContext(ctx).Errorf("failed to decode heartbeat response JSON. err: %v", err)
 			return err
 		}
-		if respd.Code == sessionExpiredCode {
+		switch {
+		case respd.Code == sessionExpiredCode:
 			logger.WithContext(ctx).Info("Snowflake returned 'session expired', trying to renew expired token.")
-			err = hc.restful.renewExpiredSessionToken(context.Background(), timeout, token)
-			if err != nil {
-				return err
+			// renewExpiredSessionToken marks the session invalid if Snowflake refuses to renew it
+			return hc.restful.renewExpiredSessionToken(context.Background(), timeout, token)
+		case !respd.Success && respd.Code == sessionGoneCode:
+			// the session does not exist anymore, so pooled connections using it are discarded
+			hc.restful.sessionInvalid.Store(true)
+			return &SnowflakeError{
+				Number:   ErrSessionGone,
+				SQLState: SQLStateConnectionFailure,
+				Message:  respd.Message,
+			}
+		case !respd.Success:
+			logger.WithContext(ctx).Warnf("heartbeat failed. code: %v, message: %v", respd.Code, respd.Message)
+			return &SnowflakeError{
+				Number:   ErrFailedToHeartbeat,
+				SQLState: SQLStateConnectionFailure,
+				Message:  "Failed to heartbeat.",
 			}
 		}
 		return nil
@@ -0,0 +1,140 @@
+package gosnowflake
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// name of the tracer and meter created from the providers configured in Config
+const instrumentationName = "github.com/snowflakedb/gosnowflake/v2"
+
+// span names
+const (
+	spanAuthenticate = "snowflake.authenticate"
+	spanPostQuery    = "snowflake.query.submit"
+	spanGetAsync     = "snowflake.query.get_async_result"
+	spanChunk        = "snowflake.chunk.download"
+	spanFileUpload   = "snowflake.file.upload"
+	spanFileDownload = "snowflake.file.download"
+)
+
+// attribute keys of the spans and metrics
+const (
+	attrAuthenticator = attribute.Key("snowflake.authenticator")
+	attrRequestID     = attribute.Key("snowflake.request_id")
+	attrQueryID       = attribute.Key("snowflake.query_id")
+	attrChunkIndex    = attribute.Key("snowflake.chunk.index")
+	attrChunkRows     = attribute.Key("snowflake.chunk.rows")
+	attrFileName      = attribute.Key("snowflake.file.name")
+	attrFileSize      = attribute.Key("snowflake.file.size")
+	attrFileStatus    = attribute.Key("snowflake.file.status")
+	attrDownloadType  = attribute.Key("snowflake.download.type")
+	attrRetryReason   = attribute.Key("snowflake.retry.reason")
+)
+
+type instrumentationKey struct{}
+
+// instrumentation creates the OpenTelemetry spans and records the metrics of a connection.
+// The methods can be called on a nil instrumentation, they do nothing then.
+type instrumentation struct {
+	tracer          trace.Tracer
+	httpRetries     metric.Int64Counter
+	downloadedBytes metric.Int64Counter
+	chunkDuration   metric.Float64Histogram
+}
+
+// newInstrumentation returns the instrumentation for the providers set in cfg or nil if none is set.
+func newInstrumentation(cfg *Config) *instrumentation {
+	if cfg == nil || (cfg.TracerProvider == nil && cfg.MeterProvider == nil) {
+		return nil
+	}
+	inst := &instrumentation{}
+	if cfg.TracerProvider != nil {
+		inst.tracer = cfg.TracerProvider.Tracer(instrumentationName, trace.WithInstrumentationVersion(SnowflakeGoDriverVersion))
+	} else {
+		inst.tracer = tracenoop.NewTracerProvider().Tracer(instrumentationName)
+	}
+	if cfg.MeterProvider != nil {
+		meter := cfg.MeterProvider.Meter(instrumentationName, metric.WithInstrumentationVersion(SnowflakeGoDriverVersion))
+		var err error
+		if inst.httpRetries, err = meter.Int64Counter("snowflake.http.retries",
+			metric.WithDescription("Number of retried HTTP requests to Snowflake and cloud storage"),
+			metric.WithUnit("{retry}")); err != nil {
+			logger.Warnf("failed to create http retries counter. %v", err)
+		}
+		if inst.downloadedBytes, err = meter.Int64Counter("snowflake.downloaded_bytes",
+			metric.WithDescription("Number of bytes of result chunks and files downloaded"),
+			metric.WithUnit("By")); err != nil {
+			logger.Warnf("failed to create downloaded bytes counter. %v", err)
+		}
+		if inst.chunkDuration, err = meter.Float64Histogram("snowflake.chunk.download.duration",
+			metric.WithDescription("Time to download and decode a result chunk"),
+			metric.WithUnit("s")); err != nil {
+			logger.Warnf("failed to create chunk duration histogram. %v", err)
+		}
+	}
+	return inst
+}
+
+// startSpan starts a span and returns a context containing it and the instrumentation,
+// so the retries of the requests sent with the context are recorded.
+func (inst *instrumentation) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if inst == nil {
+		return ctx, tracenoop.Span{}
+	}
+	ctx, span := inst.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return context.WithValue(ctx, instrumentationKey{}, inst), span
+}
+
+// endSpan ends the span and marks it as failed if err is not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func instrumentationFromContext(ctx context.Context) *instrumentation {
+	if ctx == nil {
+		return nil
+	}
+	inst, _ := ctx.Value(instrumentationKey{}).(*instrumentation)
+	return inst
+}
+
+// recordRetry records a retried request. The reason is the HTTP status or 0 for network errors.
+func (inst *instrumentation) recordRetry(ctx context.Context, reason int) {
+	if inst == nil || inst.httpRetries == nil {
+		return
+	}
+	inst.httpRetries.Add(ctx, 1, metric.WithAttributes(attrRetryReason.Int(reason)))
+}
+
+func (inst *instrumentation) recordDownloadedBytes(ctx context.Context, downloadType string, bytes int64) {
+	if inst == nil || inst.downloadedBytes == nil || bytes <= 0 {
+		return
+	}
+	inst.downloadedBytes.Add(ctx, bytes, metric.WithAttributes(attrDownloadType.String(downloadType)))
+}
+
+func (inst *instrumentation) recordChunkDuration(ctx context.Context, duration time.Duration) {
+	if inst == nil || inst.chunkDuration == nil {
+		return
+	}
+	inst.chunkDuration.Record(ctx, duration.Seconds())
+}
+
+// instrumentation returns the instrumentation of the connection, nil if it is not configured.
+func (sc *snowflakeConn) instrumentation() *instrumentation {
+	if sc == nil || sc.rest == nil {
+		return nil
+	}
+	return sc.rest.instrumentation
+}
@@ -0,0 +1,174 @@
+package gosnowflake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/snowflakedb/gosnowflake/v2/internal/query"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type testInstrumentation struct {
+	spans  *tracetest.SpanRecorder
+	reader *sdkmetric.ManualReader
+	inst   *instrumentation
+}
+
+func newTestInstrumentation() *testInstrumentation {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	return &testInstrumentation{
+		spans:  spans,
+		reader: reader,
+		inst: newInstrumentation(&Config{
+			TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+			MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		}),
+	}
+}
+
+func (ti *testInstrumentation) span(t *testing.T, name string) sdktrace.ReadOnlySpan {
+	for _, span := range ti.spans.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	t.Fatalf("span %v not found", name)
+	return nil
+}
+
+func (ti *testInstrumentation) metric(t *testing.T, name string) metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	assertNilF(t, ti.reader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m.Data
+			}
+		}
+	}
+	t.Fatalf("metric %v not found", name)
+	return nil
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestInstrumentationNotConfigured(t *testing.T) {
+	inst := newInstrumentation(&Config{})
+	assertTrueF(t, inst == nil, "instrumentation should not be created without providers")
+	ctx, span := inst.startSpan(context.Background(), spanAuthenticate)
+	endSpan(span, errors.New("failure"))
+	assertTrueE(t, instrumentationFromContext(ctx) == nil, "context should not contain instrumentation")
+	inst.recordRetry(ctx, 503)
+	inst.recordDownloadedBytes(ctx, "chunk", 10)
+	inst.recordChunkDuration(ctx, time.Second)
+	var sc *snowflakeConn
+	assertTrueE(t, sc.instrumentation() == nil, "nil connection should not have instrumentation")
+}
+
+func TestInstrumentationRetries(t *testing.T) {
+	ti := newTestInstrumentation()
+	ctx, span := ti.inst.startSpan(context.Background(), spanPostQuery)
+	client := &fakeHTTPClient{cnt: 2, success: true, statusCode: 503, t: t}
+	urlPtr, err := url.Parse("https://fakeaccountretrysuccess.snowflakecomputing.com:443/queries/v1/query-request?" + requestIDKey + "=testid")
+	assertNilF(t, err)
+	_, err = newRetryHTTP(ctx, client, emptyRequest, urlPtr, make(map[string]string), 60*time.Second, 3, constTimeProvider(123456), nil).doPost().setBody([]byte{0}).execute()
+	assertNilF(t, err)
+	endSpan(span, nil)
+
+	sum, ok := ti.metric(t, "snowflake.http.retries").(metricdata.Sum[int64])
+	assertTrueF(t, ok, "retries should be a sum")
+	assertEqualF(t, len(sum.DataPoints), 1)
+	assertEqualE(t, sum.DataPoints[0].Value, int64(1))
+	reason, _ := sum.DataPoints[0].Attributes.Value(attrRetryReason)
+	assertEqualE(t, reason.AsInt64(), int64(503))
+}
+
+func TestInstrumentationPostQuery(t *testing.T) {
+	ti := newTestInstrumentation()
+	sr := &snowflakeRestful{
+		TokenAccessor:   getSimpleTokenAccessor(),
+		instrumentation: ti.inst,
+		FuncPost: func(context.Context, *snowflakeRestful, *url.URL, map[string]string, []byte, time.Duration, currentTimeProvider, *Config) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       &fakeResponseBody{body: []byte(`{"success": true, "data": {"queryId": "01aa-query-id"}}`)},
+			}, nil
+		},
+	}
+	requestID := NewUUID()
+	_, err := postRestfulQueryHelper(context.Background(), sr, &url.Values{}, map[string]string{}, []byte{}, time.Minute, requestID, &Config{})
+	assertNilF(t, err)
+	span := ti.span(t, spanPostQuery)
+	assertEqualE(t, spanAttribute(span, attrRequestID).AsString(), requestID.String())
+	assertEqualE(t, spanAttribute(span, attrQueryID).AsString(), "01aa-query-id")
+	assertEqualE(t, span.Status().Code, codes.Unset)
+
+	sr.FuncPost = func(context.Context, *snowflakeRestful, *url.URL, map[string]string, []byte, time.Duration, currentTimeProvider, *Config) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}
+	_, err = postRestfulQueryHelper(context.Background(), sr, &url.Values{}, map[string]string{}, []byte{}, time.Minute, NewUUID(), &Config{})
+	assertNotNilF(t, err)
+	ended := ti.spans.Ended()
+	assertEqualE(t, ended[len(ended)-1].Status().Code, codes.Error)
+}
+
+func TestInstrumentationDownloadChunk(t *testing.T) {
+	ti := newTestInstrumentation()
+	body := []byte(`["1","a"],["2","b"]`)
+	scd := &snowflakeChunkDownloader{
+		sc: &snowflakeConn{
+			cfg:  &Config{},
+			rest: &snowflakeRestful{RequestTimeout: time.Minute, instrumentation: ti.inst},
+		},
+		ctx:                context.Background(),
+		ChunkMetas:         []query.ExecResponseChunk{{URL: "dummyURL1", RowCount: 2}},
+		TotalRowIndex:      int64(-1),
+		FuncDownload:       downloadChunk,
+		FuncDownloadHelper: downloadChunkHelper,
+		FuncGet: func(context.Context, *snowflakeConn, string, map[string]string, time.Duration) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: &fakeResponseBody{body: body}}, nil
+		},
+	}
+	scd.ChunksMutex = &sync.Mutex{}
+	scd.DoneDownloadCond = sync.NewCond(scd.ChunksMutex)
+	scd.Chunks = make(map[int][]chunkRowType)
+	scd.ChunksError = make(chan *chunkError, 1)
+	scd.FuncDownload(scd.ctx, scd, 0)
+	select {
+	case errc := <-scd.ChunksError:
+		t.Fatalf("unexpected chunk error: %v", errc.Error)
+	default:
+	}
+
+	span := ti.span(t, spanChunk)
+	assertEqualE(t, spanAttribute(span, attrChunkIndex).AsInt64(), int64(0))
+	assertEqualE(t, spanAttribute(span, attrChunkRows).AsInt64(), int64(2))
+
+	bytes, ok := ti.metric(t, "snowflake.downloaded_bytes").(metricdata.Sum[int64])
+	assertTrueF(t, ok, "downloaded bytes should be a sum")
+	assertEqualE(t, bytes.DataPoints[0].Value, int64(len(body)))
+	downloadType, _ := bytes.DataPoints[0].Attributes.Value(attrDownloadType)
+	assertEqualE(t, downloadType.AsString(), "chunk")
+
+	duration, ok := ti.metric(t, "snowflake.chunk.download.duration").(metricdata.Histogram[float64])
+	assertTrueF(t, ok, "chunk duration should be a histogram")
+	assertEqualE(t, duration.DataPoints[0].Count, uint64(1))
+}
@@ -33,6 +33,8 @@ const (
 	AuthTypeOAuthClientCredentials
 	// AuthTypeWorkloadIdentityFederation is to use CSP identity for authentication
 	AuthTypeWorkloadIdentityFederation
+	// AuthTypeOAuthDeviceCode is to use OAuth2 device authorization flow for devices without a browser
+	AuthTypeOAuthDeviceCode
 )
 
 func (authType AuthType) String() string {
@@ -59,6 +61,8 @@ func (authType AuthType) String() string {
 		return "OAUTH_CLIENT_CREDENTIALS"
 	case AuthTypeWorkloadIdentityFederation:
 		return "WORKLOAD_IDENTITY"
+	case AuthTypeOAuthDeviceCode:
+		return "OAUTH_DEVICE_CODE"
 	default:
 		return "UNKNOWN"
 	}
@@ -98,6 +102,9 @@ func DetermineAuthenticatorType(cfg *Config, value string) error {
 	} else if upperCaseValue == AuthTypeWorkloadIdentityFederation.String() {
 		cfg.Authenticator = AuthTypeWorkloadIdentityFederation
 		return nil
+	} else if upperCaseValue == AuthTypeOAuthDeviceCode.String() {
+		cfg.Authenticator = AuthTypeOAuthDeviceCode
+		return nil
 	} else {
 		// possibly Okta case
 		oktaURLString, err := url.QueryUnescape(lowerCaseValue)
@@ -0,0 +1,45 @@
+package config
+
+import "context"
+
+// Authenticator is a custom authentication method set in Config.CustomAuthenticator.
+// It is used instead of the built-in authenticator selected with Config.Authenticator.
+type Authenticator interface {
+	// Name returns the name of the authenticator used in logs and traces.
+	Name() string
+	// Authenticate fills the login request with the credentials. It is called for every login attempt.
+	Authenticate(ctx context.Context, req *LoginRequest) error
+}
+
+// AuthenticatorRenewer is implemented by the Authenticator able to renew its credentials,
+// e.g. with a refresh token.
+type AuthenticatorRenewer interface {
+	// Renew is called when Snowflake rejects the login because the token is invalid or expired.
+	// If it succeeds, the login is retried.
+	Renew(ctx context.Context) error
+}
+
+// AuthenticatorErrorHandler is implemented by the Authenticator handling the login errors,
+// e.g. asking for the MFA passcode or changing the expired password.
+type AuthenticatorErrorHandler interface {
+	// HandleLoginError is called when the login failed. If it returns nil the login is retried,
+	// otherwise the returned error is returned from the connection.
+	HandleLoginError(ctx context.Context, err error) error
+}
+
+// LoginRequest is the login request sent to Snowflake, filled by the custom Authenticator.
+type LoginRequest struct {
+	Account           string // Account name set by the driver
+	LoginName         string // User name, initially Config.User
+	Authenticator     string // Authenticator name, e.g. OAUTH or SNOWFLAKE_JWT. Empty for password authentication.
+	Password          string // Password
+	Passcode          string // MFA passcode
+	ExtAuthnDuoMethod string // MFA method, e.g. passcode or push
+	Token             string // Token or assertion, e.g. OAuth access token or JWT
+	RawSAMLResponse   string // SAML response of the IdP
+	Provider          string // Identity provider, e.g. for workload identity federation
+
+	// SessionParameters are the session parameters set with the login, initially the parameters set by the driver.
+	// They can be added or overridden.
+	SessionParameters map[string]any
+}
@@ -2,6 +2,7 @@
 package config
 
 import (
+	"crypto"
 	"crypto/rsa"
 	"errors"
 	"net/http"
@@ -9,6 +10,9 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Config is a set of configuration parameters
@@ -30,6 +34,11 @@ type Config struct {
 	OauthScope                   string // Comma separated list of scopes. If empty it is derived from role.
 	EnableSingleUseRefreshTokens bool   // Enables single use refresh tokens for Snowflake IdP
 
+	OauthDeviceAuthorizationURL string // Device authorization URL of OAuth2 external IdP
+	// OauthDeviceCodeCallback is called with the verification URI and the user code of the device authorization flow.
+	// By default they are printed to the standard error.
+	OauthDeviceCodeCallback func(verificationURI string, userCode string)
+
 	// ValidateDefaultParameters disable the validation checks for Database, Schema, Warehouse and Role
 	// at the time a connection is established
 	ValidateDefaultParameters Bool
@@ -71,12 +80,23 @@ type Config struct {
 	Token                  string        // Token to use for OAuth other forms of token based auth
 	TokenFilePath          string        // TokenFilePath defines a file where to read token from
 	TokenAccessor          TokenAccessor // TokenAccessor Optional token accessor to use
+	CustomAuthenticator    Authenticator // CustomAuthenticator Optional authenticator used instead of the built-in Authenticator type
 	ServerSessionKeepAlive bool          // ServerSessionKeepAlive enables the session to persist even after the driver connection is closed
 
-	PrivateKey *rsa.PrivateKey // Private key used to sign JWT
+	PrivateKey        *rsa.PrivateKey // Private key used to sign JWT
+	PrivateKeySigner  crypto.Signer   // Signer of JWT used instead of PrivateKey, e.g. for RSA keys kept in an HSM or a KMS
+	PrivateKeyFile    string          // Path of a PEM file with the PKCS8 private key used to sign JWT, read when connecting
+	PrivateKeyFilePwd string          // Passphrase of the encrypted private key in PrivateKeyFile
 
 	Transporter http.RoundTripper // RoundTripper to intercept HTTP requests and responses
 
+	// QueryInterceptors wrap the statements executed with ExecContext, QueryContext and QueryArrowStream.
+	// The first interceptor is the outermost one.
+	QueryInterceptors []QueryInterceptor
+
+	TracerProvider trace.TracerProvider // TracerProvider creates spans for logins, queries, chunk downloads and file transfers (optional)
+	MeterProvider  metric.MeterProvider // MeterProvider records request retries, downloaded bytes and chunk latency (optional)
+
 	TLSConfigName string // Name of the TLS config to use
 
 	// Deprecated: may be removed in a future release with logging reorganization.
@@ -86,9 +106,14 @@ type Config struct {
 
 	TmpDirPath string // sets temporary directory used by a driver for operations like encrypting, compressing etc
 
+	ResultMemoryBudget int64 // Maximum number of bytes of downloaded result chunks kept in memory. 0 means no limit.
+	ResultSpillToDisk  bool  // When the memory budget is exceeded, downloaded chunks are written to TmpDirPath instead of waiting for the reader
+
 	ClientRequestMfaToken          Bool // When true the MFA token is cached in the credential manager. True by default in Windows/OSX. False for Linux.
 	ClientStoreTemporaryCredential Bool // When true the ID token is cached in the credential manager. True by default in Windows/OSX. False for Linux.
 
+	CredentialCache CredentialCache // CredentialCache replaces the OS keyring or the file based cache used to store tokens
+
 	DisableQueryContextCache bool // Should HTAP query context cache be disabled
 
 	IncludeRetryReason Bool // Should retried request contain retry reason
@@ -183,6 +208,8 @@ func SetInputShape(c *Config, s *ConnectionIdentifierShape) {
 
 var errTokenConfigConflict = errors.New("token and tokenFilePath cannot be specified at the same time")
 
+var errPrivateKeyConfigConflict = errors.New("only one of privateKey, privateKeySigner and privateKeyFile can be specified")
+
 // Validate enables testing if config is correct.
 // A driver client may call it manually, but it is also called during opening first connection.
 func (c *Config) Validate() error {
@@ -197,6 +224,18 @@ func (c *Config) Validate() error {
 	if c.Token != "" && c.TokenFilePath != "" {
 		return errTokenConfigConflict
 	}
+	privateKeys := 0
+	for _, set := range []bool{c.PrivateKey != nil, c.PrivateKeySigner != nil, c.PrivateKeyFile != ""} {
+		if set {
+			privateKeys++
+		}
+	}
+	if privateKeys > 1 {
+		return errPrivateKeyConfigConflict
+	}
+	if c.ResultMemoryBudget < 0 {
+		return errors.New("ResultMemoryBudget cannot be negative")
+	}
 	return nil
 }
 
@@ -183,6 +183,10 @@ func HandleSingleParam(cfg *Config, key string, value any) error {
 			}
 		}
 		cfg.PrivateKey, err = ParsePKCS8PrivateKey(block)
+	case "privatekeyfile", "privatekeypath":
+		cfg.PrivateKeyFile, err = parseString(value)
+	case "privatekeyfilepwd":
+		cfg.PrivateKeyFilePwd, err = parseString(value)
 	case "validatedefaultparameters":
 		cfg.ValidateDefaultParameters, err = parseConfigBool(value)
 	case "clientrequestmfatoken":
@@ -197,6 +201,12 @@ func HandleSingleParam(cfg *Config, key string, value any) error {
 		cfg.LogQueryParameters, err = ParseBool(value)
 	case "tmpdirpath":
 		cfg.TmpDirPath, err = parseString(value)
+	case "resultmemorybudget":
+		var v int
+		v, err = ParseInt(value)
+		cfg.ResultMemoryBudget = int64(v)
+	case "resultspilltodisk":
+		cfg.ResultSpillToDisk, err = ParseBool(value)
 	case "disablequerycontextcache":
 		cfg.DisableQueryContextCache, err = ParseBool(value)
 	case "includeretryreason":
@@ -219,6 +229,8 @@ func HandleSingleParam(cfg *Config, key string, value any) error {
 		cfg.OauthRedirectURI, err = parseString(value)
 	case "oauthscope":
 		cfg.OauthScope, err = parseString(value)
+	case "oauthdeviceauthorizationurl":
+		cfg.OauthDeviceAuthorizationURL, err = parseString(value)
 	case "workloadidentityprovider":
 		cfg.WorkloadIdentityProvider, err = parseString(value)
 	case "workloadidentityentraresource":
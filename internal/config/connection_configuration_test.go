@@ -476,8 +476,9 @@ func TestParseToml(t *testing.T) {
 			testParams: []string{"user", "password", "host", "account", "warehouse", "database",
 				"schema", "role", "region", "protocol", "passcode", "application", "token",
 				"tracing", "tmpDirPath", "tmp_dir_path", "clientConfigFile", "client_config_file", "oauth_authorization_url", "oauth_client_id",
-				"oauth_client_secret", "oauth_token_request_url", "oauth_redirect_uri", "oauth_scope",
-				"workload_identity_provider", "workload_identity_entra_resource", "proxyHost", "noProxy", "proxyUser", "proxyPassword", "proxyProtocol"},
+				"oauth_client_secret", "oauth_token_request_url", "oauth_redirect_uri", "oauth_scope", "oauth_device_authorization_url",
+				"workload_identity_provider", "workload_identity_entra_resource", "proxyHost", "noProxy", "proxyUser", "proxyPassword", "proxyProtocol",
+				"private_key_file", "private_key_path", "private_key_file_pwd"},
 			values: []any{"value"},
 		},
 		{
@@ -0,0 +1,21 @@
+package config
+
+// CredentialCacheKey identifies a cached token.
+type CredentialCacheKey struct {
+	Host string // Snowflake host, or the token request URL for OAuth tokens
+	User string // Snowflake user
+	// TokenType is one of ID_TOKEN, MFA_TOKEN, OAUTH_ACCESS_TOKEN or OAUTH_REFRESH_TOKEN
+	TokenType string
+}
+
+// CredentialCache stores the ID, MFA and OAuth tokens used to skip authentication prompts.
+// Tokens are only cached when ClientStoreTemporaryCredential (ID and OAuth tokens) or
+// ClientRequestMfaToken (MFA tokens) is enabled. Implementations must be safe for concurrent use.
+type CredentialCache interface {
+	// Get returns the token stored for key or an empty string if there is none.
+	Get(key CredentialCacheKey) (string, error)
+	// Set stores the token for key, replacing the previous one.
+	Set(key CredentialCacheKey, value string) error
+	// Delete removes the token stored for key. It does not fail if there is none.
+	Delete(key CredentialCacheKey) error
+}
@@ -2,9 +2,7 @@ package config
 
 import (
 	"crypto/rsa"
-	"crypto/x509"
 	"encoding/base64"
-	"encoding/pem"
 	"errors"
 	"fmt"
 	"net/url"
@@ -128,6 +126,9 @@ func DSN(cfg *Config) (dsn string, err error) {
 	if cfg.OauthScope != "" {
 		params.Add("oauthScope", cfg.OauthScope)
 	}
+	if cfg.OauthDeviceAuthorizationURL != "" {
+		params.Add("oauthDeviceAuthorizationUrl", cfg.OauthDeviceAuthorizationURL)
+	}
 	if cfg.EnableSingleUseRefreshTokens {
 		params.Add("enableSingleUseRefreshTokens", strconv.FormatBool(cfg.EnableSingleUseRefreshTokens))
 	}
@@ -219,6 +220,12 @@ func DSN(cfg *Config) (dsn string, err error) {
 			params.Add(k, *v)
 		}
 	}
+	if cfg.PrivateKeyFile != "" {
+		params.Add("privateKeyFile", cfg.PrivateKeyFile)
+	}
+	if cfg.PrivateKeyFilePwd != "" {
+		params.Add("privateKeyFilePwd", cfg.PrivateKeyFilePwd)
+	}
 	if cfg.PrivateKey != nil {
 		privateKeyInBytes, err := MarshalPKCS8PrivateKey(cfg.PrivateKey)
 		if err != nil {
@@ -242,6 +249,12 @@ func DSN(cfg *Config) (dsn string, err error) {
 	if cfg.TmpDirPath != "" {
 		params.Add("tmpDirPath", cfg.TmpDirPath)
 	}
+	if cfg.ResultMemoryBudget != 0 {
+		params.Add("resultMemoryBudget", strconv.FormatInt(cfg.ResultMemoryBudget, 10))
+	}
+	if cfg.ResultSpillToDisk {
+		params.Add("resultSpillToDisk", "true")
+	}
 	if cfg.DisableQueryContextCache {
 		params.Add("disableQueryContextCache", "true")
 	}
@@ -695,23 +708,27 @@ func buildHostFromAccountAndRegion(account, region string) string {
 }
 
 func authRequiresUser(cfg *Config) bool {
-	return cfg.Authenticator != AuthTypeOAuth &&
+	return cfg.CustomAuthenticator == nil &&
+		cfg.Authenticator != AuthTypeOAuth &&
 		cfg.Authenticator != AuthTypeTokenAccessor &&
 		cfg.Authenticator != AuthTypeExternalBrowser &&
 		cfg.Authenticator != AuthTypePat &&
 		cfg.Authenticator != AuthTypeOAuthAuthorizationCode &&
 		cfg.Authenticator != AuthTypeOAuthClientCredentials &&
+		cfg.Authenticator != AuthTypeOAuthDeviceCode &&
 		cfg.Authenticator != AuthTypeWorkloadIdentityFederation
 }
 
 func authRequiresPassword(cfg *Config) bool {
-	return cfg.Authenticator != AuthTypeOAuth &&
+	return cfg.CustomAuthenticator == nil &&
+		cfg.Authenticator != AuthTypeOAuth &&
 		cfg.Authenticator != AuthTypeTokenAccessor &&
 		cfg.Authenticator != AuthTypeExternalBrowser &&
 		cfg.Authenticator != AuthTypeJwt &&
 		cfg.Authenticator != AuthTypePat &&
 		cfg.Authenticator != AuthTypeOAuthAuthorizationCode &&
 		cfg.Authenticator != AuthTypeOAuthClientCredentials &&
+		cfg.Authenticator != AuthTypeOAuthDeviceCode &&
 		cfg.Authenticator != AuthTypeWorkloadIdentityFederation
 }
 
@@ -871,6 +888,8 @@ func parseDSNParams(cfg *Config, params string) (err error) {
 			cfg.OauthRedirectURI = value
 		case "oauthScope":
 			cfg.OauthScope = value
+		case "oauthDeviceAuthorizationUrl":
+			cfg.OauthDeviceAuthorizationURL = value
 		case "enableSingleUseRefreshTokens":
 			var vv bool
 			vv, err = strconv.ParseBool(value)
@@ -984,6 +1003,10 @@ func parseDSNParams(cfg *Config, params string) (err error) {
 			if err != nil {
 				return err
 			}
+		case "privateKeyFile":
+			cfg.PrivateKeyFile = value
+		case "privateKeyFilePwd":
+			cfg.PrivateKeyFilePwd = value
 		case "validateDefaultParameters":
 			var vv bool
 			vv, err = strconv.ParseBool(value)
@@ -1035,6 +1058,18 @@ func parseDSNParams(cfg *Config, params string) (err error) {
 			cfg.LogQueryParameters = vv
 		case "tmpDirPath":
 			cfg.TmpDirPath = value
+		case "resultMemoryBudget":
+			cfg.ResultMemoryBudget, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return
+			}
+		case "resultSpillToDisk":
+			var vv bool
+			vv, err = strconv.ParseBool(value)
+			if err != nil {
+				return
+			}
+			cfg.ResultSpillToDisk = vv
 		case "disableQueryContextCache":
 			var b bool
 			b, err = strconv.ParseBool(value)
@@ -1272,23 +1307,7 @@ func GetConfigFromEnv(properties []*Param) (*Config, error) {
 }
 
 func parsePrivateKeyFromFile(path string) (*rsa.PrivateKey, error) {
-	bytes, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-	block, _ := pem.Decode(bytes)
-	if block == nil {
-		return nil, errors.New("failed to parse PEM block containing the private key")
-	}
-	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
-	if err != nil {
-		return nil, err
-	}
-	pk, ok := privateKey.(*rsa.PrivateKey)
-	if !ok {
-		return nil, fmt.Errorf("interface convertion. expected type *rsa.PrivateKey, but got %T", privateKey)
-	}
-	return pk, nil
+	return ReadPrivateKeyFile(path, "")
 }
 
 // ExtractAccountName extract an account name from a raw account.
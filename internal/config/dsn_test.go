@@ -589,6 +589,25 @@ func TestParseDSN(t *testing.T) {
 			ocspMode: ocspModeFailOpen,
 			err:      nil,
 		},
+		{
+			dsn: "u@snowflake.local:9876?account=a&protocol=http&authenticator=OAUTH_DEVICE_CODE&oauthClientId=testClientId&oauthDeviceAuthorizationUrl=https:%2F%2Fsomehost.com%2Fdevice&oauthTokenRequestUrl=https:%2F%2Fsomehost.com%2Ftoken",
+			config: &Config{
+				Account: "a", User: "u", Authenticator: AuthTypeOAuthDeviceCode,
+				Protocol: "http", Host: "snowflake.local", Port: 9876,
+				OCSPFailOpen:                OCSPFailOpenTrue,
+				ValidateDefaultParameters:   BoolTrue,
+				ClientTimeout:               time.Duration(DefaultClientTimeout),
+				JWTClientTimeout:            time.Duration(DefaultJWTClientTimeout),
+				ExternalBrowserTimeout:      time.Duration(DefaultExternalBrowserTimeout),
+				CloudStorageTimeout:         defaultCloudStorageTimeout,
+				IncludeRetryReason:          BoolTrue,
+				OauthClientID:               "testClientId",
+				OauthDeviceAuthorizationURL: "https://somehost.com/device",
+				OauthTokenRequestURL:        "https://somehost.com/token",
+			},
+			ocspMode: ocspModeFailOpen,
+			err:      nil,
+		},
 		{
 			dsn: "u:@a.snowflake.local:9876?account=a&protocol=http&authenticator=SNOWFLAKE_JWT",
 			config: &Config{
@@ -1036,6 +1055,40 @@ func TestParseDSN(t *testing.T) {
 			ocspMode: ocspModeFailOpen,
 			err:      nil,
 		},
+		{
+			dsn: "u:p@a.r.c.snowflakecomputing.com/db/s?account=a.r.c&resultMemoryBudget=104857600&resultSpillToDisk=true",
+			config: &Config{
+				Account: "a", User: "u", Password: "p",
+				Protocol: "https", Host: "a.r.c.snowflakecomputing.com", Port: 443,
+				Database: "db", Schema: "s", ValidateDefaultParameters: BoolTrue, OCSPFailOpen: OCSPFailOpenTrue,
+				ClientTimeout:          time.Duration(DefaultClientTimeout),
+				JWTClientTimeout:       time.Duration(DefaultJWTClientTimeout),
+				ExternalBrowserTimeout: time.Duration(DefaultExternalBrowserTimeout),
+				CloudStorageTimeout:    defaultCloudStorageTimeout,
+				IncludeRetryReason:     BoolTrue,
+				ResultMemoryBudget:     100 * 1024 * 1024,
+				ResultSpillToDisk:      true,
+			},
+			ocspMode: ocspModeFailOpen,
+			err:      nil,
+		},
+		{
+			dsn: "u:p@a.r.c.snowflakecomputing.com/db/s?account=a.r.c&privateKeyFile=%2Fhome%2Fuser%2Frsa_key.p8&privateKeyFilePwd=test_passphrase",
+			config: &Config{
+				Account: "a", User: "u", Password: "p",
+				Protocol: "https", Host: "a.r.c.snowflakecomputing.com", Port: 443,
+				Database: "db", Schema: "s", ValidateDefaultParameters: BoolTrue, OCSPFailOpen: OCSPFailOpenTrue,
+				ClientTimeout:          time.Duration(DefaultClientTimeout),
+				JWTClientTimeout:       time.Duration(DefaultJWTClientTimeout),
+				ExternalBrowserTimeout: time.Duration(DefaultExternalBrowserTimeout),
+				CloudStorageTimeout:    defaultCloudStorageTimeout,
+				IncludeRetryReason:     BoolTrue,
+				PrivateKeyFile:         "/home/user/rsa_key.p8",
+				PrivateKeyFilePwd:      "test_passphrase",
+			},
+			ocspMode: ocspModeFailOpen,
+			err:      nil,
+		},
 		{
 			dsn: "u:p@a.r.c.snowflakecomputing.com/db/s?account=a.r.c&includeRetryReason=true",
 			config: &Config{
@@ -1378,6 +1431,10 @@ func TestParseDSN(t *testing.T) {
 				assertEqualE(t, cfg.CloudStorageTimeout, test.config.CloudStorageTimeout, fmt.Sprintf("Test %d: CloudStorageTimeout mismatch", i))
 				assertEqualE(t, cfg.TmpDirPath, test.config.TmpDirPath, fmt.Sprintf("Test %d: TmpDirPath mismatch", i))
 				assertEqualE(t, cfg.DisableQueryContextCache, test.config.DisableQueryContextCache, fmt.Sprintf("Test %d: DisableQueryContextCache mismatch", i))
+				assertEqualE(t, cfg.ResultMemoryBudget, test.config.ResultMemoryBudget, fmt.Sprintf("Test %d: ResultMemoryBudget mismatch", i))
+				assertEqualE(t, cfg.PrivateKeyFile, test.config.PrivateKeyFile, fmt.Sprintf("Test %d: PrivateKeyFile mismatch", i))
+				assertEqualE(t, cfg.PrivateKeyFilePwd, test.config.PrivateKeyFilePwd, fmt.Sprintf("Test %d: PrivateKeyFilePwd mismatch", i))
+				assertEqualE(t, cfg.ResultSpillToDisk, test.config.ResultSpillToDisk, fmt.Sprintf("Test %d: ResultSpillToDisk mismatch", i))
 				assertEqualE(t, cfg.IncludeRetryReason, test.config.IncludeRetryReason, fmt.Sprintf("Test %d: IncludeRetryReason mismatch", i))
 				assertEqualE(t, cfg.ServerSessionKeepAlive, test.config.ServerSessionKeepAlive, fmt.Sprintf("Test %d: ServerSessionKeepAlive mismatch", i))
 				assertEqualE(t, cfg.DisableConsoleLogin, test.config.DisableConsoleLogin, fmt.Sprintf("Test %d: DisableConsoleLogin mismatch", i))
@@ -1389,6 +1446,7 @@ func TestParseDSN(t *testing.T) {
 				assertEqualE(t, cfg.OauthRedirectURI, test.config.OauthRedirectURI, fmt.Sprintf("Test %d: OauthRedirectURI mismatch", i))
 				assertEqualE(t, cfg.OauthScope, test.config.OauthScope, fmt.Sprintf("Test %d: OauthScope mismatch", i))
 				assertEqualE(t, cfg.EnableSingleUseRefreshTokens, test.config.EnableSingleUseRefreshTokens, fmt.Sprintf("Test %d: EnableSingleUseRefreshTokens mismatch", i))
+				assertEqualE(t, cfg.OauthDeviceAuthorizationURL, test.config.OauthDeviceAuthorizationURL, fmt.Sprintf("Test %d: OauthDeviceAuthorizationURL mismatch", i))
 				assertEqualE(t, cfg.Token, test.config.Token, "token")
 				assertEqualE(t, cfg.ClientConfigFile, test.config.ClientConfigFile, "client config file")
 				assertEqualE(t, cfg.CertRevocationCheckMode, test.config.CertRevocationCheckMode, "cert revocation check mode")
@@ -1579,6 +1637,18 @@ func TestDSN(t *testing.T) {
 			},
 			dsn: "u:p@a.r.snowflakecomputing.com:443?enableSingleUseRefreshTokens=true&oauthAuthorizationUrl=http%3A%2F%2Fsomehost.com&oauthClientId=testClientId&oauthClientSecret=testClientSecret&oauthRedirectUri=http%3A%2F%2Flocalhost%3A8001%2Fsome-path&oauthScope=test+scope&oauthTokenRequestUrl=https%3A%2F%2Fsomehost2.com%2Fsomepath&ocspFailOpen=true&region=r&validateDefaultParameters=true",
 		},
+		{
+			cfg: &Config{
+				User:                        "u",
+				Account:                     "a",
+				Region:                      "r",
+				Authenticator:               AuthTypeOAuthDeviceCode,
+				OauthClientID:               "testClientId",
+				OauthDeviceAuthorizationURL: "https://somehost.com/device",
+				OauthTokenRequestURL:        "https://somehost.com/token",
+			},
+			dsn: "u:@a.r.snowflakecomputing.com:443?authenticator=oauth_device_code&oauthClientId=testClientId&oauthDeviceAuthorizationUrl=https%3A%2F%2Fsomehost.com%2Fdevice&oauthTokenRequestUrl=https%3A%2F%2Fsomehost.com%2Ftoken&ocspFailOpen=true&region=r&validateDefaultParameters=true",
+		},
 		{
 			cfg: &Config{
 				User:                   "u",
@@ -2081,6 +2151,26 @@ func TestDSN(t *testing.T) {
 			},
 			dsn: "u:p@a.b.c.snowflakecomputing.com:443?authenticator=externalbrowser&disableSamlURLCheck=false&ocspFailOpen=true&region=b.c&validateDefaultParameters=true",
 		},
+		{
+			cfg: &Config{
+				User:               "u",
+				Password:           "p",
+				Account:            "a.b.c",
+				ResultMemoryBudget: 1048576,
+				ResultSpillToDisk:  true,
+			},
+			dsn: "u:p@a.b.c.snowflakecomputing.com:443?ocspFailOpen=true&region=b.c&resultMemoryBudget=1048576&resultSpillToDisk=true&validateDefaultParameters=true",
+		},
+		{
+			cfg: &Config{
+				User:              "u",
+				Password:          "p",
+				Account:           "a.b.c",
+				PrivateKeyFile:    "/home/user/rsa_key.p8",
+				PrivateKeyFilePwd: "test_passphrase",
+			},
+			dsn: "u:p@a.b.c.snowflakecomputing.com:443?ocspFailOpen=true&privateKeyFile=%2Fhome%2Fuser%2Frsa_key.p8&privateKeyFilePwd=test_passphrase&region=b.c&validateDefaultParameters=true",
+		},
 		{
 			cfg: &Config{
 				User:                              "u",
@@ -1,10 +1,25 @@
 package config
 
 import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
 	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
 
 	sferrors "github.com/snowflakedb/gosnowflake/v2/internal/errors"
+	"golang.org/x/crypto/scrypt"
 )
 
 // ParsePKCS8PrivateKey parses a PKCS8 encoded private key.
@@ -16,7 +31,14 @@ func ParsePKCS8PrivateKey(block []byte) (*rsa.PrivateKey, error) {
 			Message: "Error decoding private key using PKCS8.",
 		}
 	}
-	return privKey.(*rsa.PrivateKey), nil
+	rsaKey, ok := privKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, &sferrors.SnowflakeError{
+			Number:  sferrors.ErrCodePrivateKeyParseError,
+			Message: fmt.Sprintf("Unsupported private key type %T, only RSA keys are supported.", privKey),
+		}
+	}
+	return rsaKey, nil
 }
 
 // MarshalPKCS8PrivateKey marshals a private key to PKCS8 format.
@@ -29,3 +51,198 @@ func MarshalPKCS8PrivateKey(key *rsa.PrivateKey) ([]byte, error) {
 	}
 	return keyInBytes, nil
 }
+
+// ParsePrivateKeyPEM parses a PEM encoded PKCS8 private key. Keys in "ENCRYPTED PRIVATE KEY" blocks are
+// decrypted with the passphrase, as written by e.g. "openssl pkcs8 -topk8 -v2 aes-256-cbc".
+func ParsePrivateKeyPEM(data []byte, passphrase string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, &sferrors.SnowflakeError{
+			Number:  sferrors.ErrCodePrivateKeyParseError,
+			Message: "Failed to parse PEM block containing the private key.",
+		}
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		if passphrase == "" {
+			return nil, &sferrors.SnowflakeError{
+				Number:  sferrors.ErrCodePrivateKeyParseError,
+				Message: "The private key is encrypted, but the passphrase was not provided.",
+			}
+		}
+		der, err := decryptPKCS8PrivateKey(block.Bytes, []byte(passphrase))
+		if err != nil {
+			return nil, &sferrors.SnowflakeError{
+				Number:  sferrors.ErrCodePrivateKeyParseError,
+				Message: fmt.Sprintf("Error decrypting private key: %v.", err),
+			}
+		}
+		return ParsePKCS8PrivateKey(der)
+	}
+	return ParsePKCS8PrivateKey(block.Bytes)
+}
+
+// ReadPrivateKeyFile reads a PEM encoded PKCS8 private key, encrypted if the passphrase is provided.
+func ReadPrivateKeyFile(path string, passphrase string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key file: %w", err)
+	}
+	return ParsePrivateKeyPEM(data, passphrase)
+}
+
+// GetPrivateKeySigner returns the signer of the JWT for key-pair authentication:
+// PrivateKeySigner, PrivateKey or the key read from PrivateKeyFile.
+func GetPrivateKeySigner(c *Config) (crypto.Signer, error) {
+	switch {
+	case c.PrivateKeySigner != nil:
+		return c.PrivateKeySigner, nil
+	case c.PrivateKey != nil:
+		return c.PrivateKey, nil
+	case c.PrivateKeyFile != "":
+		return ReadPrivateKeyFile(c.PrivateKeyFile, c.PrivateKeyFilePwd)
+	}
+	return nil, errors.New("trying to use keypair authentication, but PrivateKey was not provided in the driver config")
+}
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidScrypt = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// encryptedPrivateKeyInfo is defined in RFC 5208.
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// pbes2Params is defined in RFC 8018.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// scryptParams is defined in RFC 7914.
+type scryptParams struct {
+	Salt                     []byte
+	CostParameter            int
+	BlockSize                int
+	ParallelizationParameter int
+	KeyLength                int `asn1:"optional"`
+}
+
+// decryptPKCS8PrivateKey decrypts the PBES2 encrypted private key with AES-CBC and the key derived with PBKDF2 or scrypt.
+func decryptPKCS8PrivateKey(der []byte, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("parsing encrypted private key: %w", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported encryption algorithm %v, only PBES2 is supported", info.Algorithm.Algorithm)
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("parsing PBES2 parameters: %w", err)
+	}
+
+	var keyLength int
+	switch scheme := params.EncryptionScheme.Algorithm; {
+	case scheme.Equal(oidAES128CBC):
+		keyLength = 16
+	case scheme.Equal(oidAES192CBC):
+		keyLength = 24
+	case scheme.Equal(oidAES256CBC):
+		keyLength = 32
+	default:
+		return nil, fmt.Errorf("unsupported encryption scheme %v, only AES-CBC is supported", scheme)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("parsing IV: %w", err)
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("invalid IV length %v", len(iv))
+	}
+
+	key, err := deriveKey(params.KeyDerivationFunc, passphrase, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	data := info.EncryptedData
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid encrypted data length")
+	}
+	decrypted := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(decrypted, data)
+	// a wrong passphrase is usually detected by the invalid padding
+	padding := int(decrypted[len(decrypted)-1])
+	if padding == 0 || padding > aes.BlockSize {
+		return nil, errors.New("invalid padding, the passphrase may be incorrect")
+	}
+	for _, b := range decrypted[len(decrypted)-padding:] {
+		if int(b) != padding {
+			return nil, errors.New("invalid padding, the passphrase may be incorrect")
+		}
+	}
+	return decrypted[:len(decrypted)-padding], nil
+}
+
+func deriveKey(kdf pkix.AlgorithmIdentifier, passphrase []byte, keyLength int) ([]byte, error) {
+	switch {
+	case kdf.Algorithm.Equal(oidPBKDF2):
+		var params pbkdf2Params
+		if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, fmt.Errorf("parsing PBKDF2 parameters: %w", err)
+		}
+		if params.KeyLength != 0 && params.KeyLength != keyLength {
+			return nil, fmt.Errorf("invalid PBKDF2 key length %v", params.KeyLength)
+		}
+		var h func() hash.Hash
+		switch prf := params.PRF.Algorithm; {
+		case len(prf) == 0, prf.Equal(oidHMACWithSHA1):
+			h = sha1.New
+		case prf.Equal(oidHMACWithSHA224):
+			h = sha256.New224
+		case prf.Equal(oidHMACWithSHA256):
+			h = sha256.New
+		case prf.Equal(oidHMACWithSHA384):
+			h = sha512.New384
+		case prf.Equal(oidHMACWithSHA512):
+			h = sha512.New
+		default:
+			return nil, fmt.Errorf("unsupported PBKDF2 pseudorandom function %v", prf)
+		}
+		return pbkdf2.Key(h, string(passphrase), params.Salt, params.IterationCount, keyLength)
+	case kdf.Algorithm.Equal(oidScrypt):
+		var params scryptParams
+		if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, fmt.Errorf("parsing scrypt parameters: %w", err)
+		}
+		if params.KeyLength != 0 && params.KeyLength != keyLength {
+			return nil, fmt.Errorf("invalid scrypt key length %v", params.KeyLength)
+		}
+		return scrypt.Key(passphrase, params.Salt, params.CostParameter, params.BlockSize, params.ParallelizationParameter, keyLength)
+	}
+	return nil, fmt.Errorf("unsupported key derivation function %v, only PBKDF2 and scrypt are supported", kdf.Algorithm)
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sferrors "github.com/snowflakedb/gosnowflake/v2/internal/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+// encryptTestPrivateKey encrypts the key like "openssl pkcs8 -topk8 -v2 aes-256-cbc [-scrypt]".
+func encryptTestPrivateKey(t *testing.T, key *rsa.PrivateKey, passphrase string, useScrypt bool) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assertNilF(t, err)
+	salt := make([]byte, 16)
+	iv := make([]byte, aes.BlockSize)
+	_, err = rand.Read(salt)
+	assertNilF(t, err)
+	_, err = rand.Read(iv)
+	assertNilF(t, err)
+
+	var encKey []byte
+	var kdf pkix.AlgorithmIdentifier
+	if useScrypt {
+		encKey, err = scrypt.Key([]byte(passphrase), salt, 1<<10, 8, 1, 32)
+		assertNilF(t, err)
+		params, err := asn1.Marshal(scryptParams{Salt: salt, CostParameter: 1 << 10, BlockSize: 8, ParallelizationParameter: 1})
+		assertNilF(t, err)
+		kdf = pkix.AlgorithmIdentifier{Algorithm: oidScrypt, Parameters: asn1.RawValue{FullBytes: params}}
+	} else {
+		encKey, err = pbkdf2.Key(sha256.New, passphrase, salt, 2048, 32)
+		assertNilF(t, err)
+		params, err := asn1.Marshal(pbkdf2Params{Salt: salt, IterationCount: 2048, PRF: pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256, Parameters: asn1.NullRawValue}})
+		assertNilF(t, err)
+		kdf = pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: params}}
+	}
+
+	padding := aes.BlockSize - len(der)%aes.BlockSize
+	for range padding {
+		der = append(der, byte(padding))
+	}
+	block, err := aes.NewCipher(encKey)
+	assertNilF(t, err)
+	encrypted := make([]byte, len(der))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, der)
+
+	ivParam, err := asn1.Marshal(iv)
+	assertNilF(t, err)
+	pbes2, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: kdf,
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivParam}},
+	})
+	assertNilF(t, err)
+	info, err := asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: pbes2}},
+		EncryptedData: encrypted,
+	})
+	assertNilF(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: info})
+}
+
+func TestParseEncryptedPrivateKeyPEM(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assertNilF(t, err)
+	for _, useScrypt := range []bool{false, true} {
+		data := encryptTestPrivateKey(t, key, "test_passphrase", useScrypt)
+
+		parsed, err := ParsePrivateKeyPEM(data, "test_passphrase")
+		assertNilF(t, err)
+		assertTrueE(t, parsed.Equal(key), "decrypted key should be equal to the original key")
+
+		var se *sferrors.SnowflakeError
+		_, err = ParsePrivateKeyPEM(data, "wrong_passphrase")
+		assertTrueF(t, errors.As(err, &se), "expected SnowflakeError")
+		assertEqualE(t, se.Number, sferrors.ErrCodePrivateKeyParseError)
+
+		_, err = ParsePrivateKeyPEM(data, "")
+		assertTrueF(t, errors.As(err, &se), "expected SnowflakeError")
+		assertEqualE(t, se.Number, sferrors.ErrCodePrivateKeyParseError)
+	}
+}
+
+func TestGetPrivateKeySigner(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assertNilF(t, err)
+	keyFile := filepath.Join(t.TempDir(), "rsa_key.p8")
+	assertNilF(t, os.WriteFile(keyFile, encryptTestPrivateKey(t, key, "test_passphrase", false), 0600))
+
+	signer, err := GetPrivateKeySigner(&Config{PrivateKeyFile: keyFile, PrivateKeyFilePwd: "test_passphrase"})
+	assertNilF(t, err)
+	assertTrueE(t, key.Equal(signer), "key from the file should be used")
+
+	signer, err = GetPrivateKeySigner(&Config{PrivateKey: key})
+	assertNilF(t, err)
+	assertTrueE(t, key.Equal(signer), "private key should be used")
+
+	_, err = GetPrivateKeySigner(&Config{})
+	assertTrueE(t, err != nil, "missing private key should be reported")
+
+	err = (&Config{PrivateKey: key, PrivateKeyFile: keyFile}).Validate()
+	assertEqualE(t, err, errPrivateKeyConfigConflict)
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+)
+
+// QueryKind is the method executing the intercepted statement.
+type QueryKind int
+
+const (
+	// QueryKindExec is the statement executed with ExecContext
+	QueryKindExec QueryKind = iota
+	// QueryKindQuery is the statement executed with QueryContext
+	QueryKindQuery
+	// QueryKindArrowStream is the statement executed with QueryArrowStream
+	QueryKindArrowStream
+)
+
+func (kind QueryKind) String() string {
+	switch kind {
+	case QueryKindExec:
+		return "EXEC"
+	case QueryKindQuery:
+		return "QUERY"
+	case QueryKindArrowStream:
+		return "ARROW_STREAM"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// InterceptedQuery is the statement passed through the QueryInterceptor chain.
+// SQL and Bindings can be changed before the next handler is called, the other fields describe
+// the execution after it returns. An interceptor short-circuiting the statement without calling next
+// can set Result or Rows, which are returned instead of the executed ones.
+type InterceptedQuery struct {
+	Kind     QueryKind
+	SQL      string
+	Bindings []driver.NamedValue
+	QueryTag string // query tag set in the context with WithQueryTag
+	Async    bool   // the query is submitted in the async mode
+
+	QueryID      string        // ID of the executed query, also of the failed one if it was submitted
+	Status       string        // SUCCESS, FAILED_WITH_ERROR or RUNNING for the async queries
+	Duration     time.Duration // execution time measured by the driver
+	RowsAffected int64         // rows affected by the DML statement, -1 if not known
+
+	Result driver.Result // result of the statement executed with ExecContext
+	Rows   driver.Rows   // rows of the statement executed with QueryContext
+}
+
+// QueryHandler executes the intercepted statement.
+type QueryHandler func(ctx context.Context, query *InterceptedQuery) error
+
+// QueryInterceptor wraps the execution of the statements. It calls next to execute the statement,
+// possibly with a changed context or query. It can short-circuit the statement without calling next
+// by returning an error, or by setting Result or Rows of the query.
+type QueryInterceptor func(ctx context.Context, query *InterceptedQuery, next QueryHandler) error
@@ -124,6 +124,12 @@ const (
 	ErrFailedToGetChunk = 262000
 	// ErrNonArrowResponseInArrowBatches is an error code for case where ArrowBatches mode is enabled, but response is not Arrow-based
 	ErrNonArrowResponseInArrowBatches = 262001
+	// ErrUnsupportedChunkDescriptorVersion is an error code for a chunk descriptor with an unknown version
+	ErrUnsupportedChunkDescriptorVersion = 262002
+	// ErrColumnarResultsEnabled is an error code for reading rows one by one when columnar results are enabled
+	ErrColumnarResultsEnabled = 262003
+	// ErrColumnarResultsNotEnabled is an error code for reading column chunks when columnar results are not enabled or not available
+	ErrColumnarResultsNotEnabled = 262004
 
 	/* transaction*/
 
@@ -165,6 +171,8 @@ const (
 	ErrBindSerialization = 265001
 	// ErrBindUpload is an error code for the uploading process of bind elements to the stage
 	ErrBindUpload = 265002
+	// ErrBindNamedParameter is an error code for the named bind values not matching the named parameters of the query
+	ErrBindNamedParameter = 265003
 
 	/* async */
 
@@ -191,6 +199,8 @@ const (
 	ErrNullValueInArray = 268004
 	// ErrNullValueInMap is an error code for the case where there are null values in a map without mapValuesNullable set to true
 	ErrNullValueInMap = 268005
+	// ErrInvalidTimezoneName is an error code for the case where a time zone name is not a valid IANA time zone name
+	ErrInvalidTimezoneName = 268006
 
 	/* OCSP */
 
@@ -203,6 +213,13 @@ const (
 	// ErrOCSPNoOCSPResponderURL is an error code for the case where the OCSP responder URL is not attached.
 	ErrOCSPNoOCSPResponderURL = 269004
 
+	/* arrow ingest */
+
+	// ErrArrowIngest is an error code for a failed serialization or load of arrow records
+	ErrArrowIngest = 270001
+	// ErrUnsupportedArrowIngestType is an error code for an arrow type that cannot be mapped to a Snowflake column type
+	ErrUnsupportedArrowIngestType = 270002
+
 	/* query Status*/
 
 	// ErrQueryStatus when check the status of a query, receive error or no status
@@ -230,6 +247,7 @@ const (
 	ErrMsgFailedToParsePort                  = "failed to parse a port number. port: %v"
 	ErrMsgFailedToParseAuthenticator         = "failed to parse an authenticator: %v"
 	ErrMsgInvalidOffsetStr                   = "offset must be a string consist of sHHMI where one sign character '+'/'-' followed by zero filled hours and minutes: %v"
+	ErrMsgInvalidTimezoneName                = "invalid time zone name %v: %v"
 	ErrMsgInvalidByteArray                   = "invalid byte array: %v"
 	ErrMsgIdpConnectionError                 = "failed to verify URLs. authenticator: %v, token URL:%v, SSO URL:%v"
 	ErrMsgSSOURLNotMatch                     = "SSO URL didn't match. expected: %v, got: %v"
@@ -253,6 +271,10 @@ const (
 	ErrMsgOCSPInvalidValidity                = "invalid validity: producedAt: %v, thisUpdate: %v, nextUpdate: %v"
 	ErrMsgOCSPNoOCSPResponderURL             = "no OCSP server is attached to the certificate. %v"
 	ErrMsgBindColumnMismatch                 = "column %v has a different number of binds (%v) than column 1 (%v)"
+	ErrMsgBindRowMismatch                    = "row %v has a different number of values (%v) than row 1 (%v)"
+	ErrMsgNamedBindMissing                   = "no value is bound to the named parameter :%v"
+	ErrMsgNamedBindUnused                    = "the named bind value %v does not match any named parameter of the query"
+	ErrMsgNamedBindMixed                     = "named and positional bind values cannot be mixed. positional value: %v"
 	ErrMsgNotImplemented                     = "not implemented"
 	ErrMsgFeatureNotSupported                = "feature is not supported: %v"
 	ErrMsgCommandNotRecognized               = "%v command not recognized"
@@ -273,7 +295,11 @@ const (
 	ErrMsgInvalidWritablePermissionToFile    = "file '%v' is writable by group or others — this poses a security risk because it allows unauthorized users to modify sensitive settings. Your Permission: %v"
 	ErrMsgInvalidExecutablePermissionToFile  = "file '%v' is executable — this poses a security risk because the file could be misused as a script or executed unintentionally. Your Permission: %v"
 	ErrMsgNonArrowResponseInArrowBatches     = "arrow batches enabled, but the response is not Arrow based"
+	ErrMsgUnsupportedChunkDescriptorVersion  = "unsupported chunk descriptor version: %v, supported version: %v"
+	ErrMsgColumnarResultsEnabled             = "columnar results are enabled, read the rows with NextColumnChunk"
+	ErrMsgColumnarResultsNotEnabled          = "columnar results are not available. they require WithColumnarResults and an Arrow response"
 	ErrMsgMissingTLSConfig                   = "TLS config not found: %v"
+	ErrMsgUnsupportedArrowIngestType         = "unsupported arrow type for ingest. column: %v, type: %v"
 	ErrMsgHostWithScheme                     = "host includes a URL scheme (e.g. \"https://\"). Specify the hostname only, without a scheme prefix. Use \"myorg-myaccount.snowflakecomputing.com\" instead of \"https://myorg-myaccount.snowflakecomputing.com\". Got: %v"
 )
 
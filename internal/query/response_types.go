@@ -10,6 +10,7 @@ type ExecResponseRowType struct {
 	Precision  int64           `json:"precision"`
 	Scale      int64           `json:"scale"`
 	Nullable   bool            `json:"nullable"`
+	Dimension  int64           `json:"vectorDimension"`
 }
 
 // FieldMetadata describes metadata for a field, including nested fields for complex types.
@@ -21,6 +22,7 @@ type FieldMetadata struct {
 	Scale     int             `json:"scale"`
 	Precision int             `json:"precision"`
 	Fields    []FieldMetadata `json:"fields,omitempty"`
+	Dimension int             `json:"vectorDimension,omitempty"`
 }
 
 // ExecResponseChunk describes metadata for a chunk of query results, including URL and size information.
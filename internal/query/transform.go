@@ -10,5 +10,6 @@ func (ex *ExecResponseRowType) ToFieldMetadata() FieldMetadata {
 		int(ex.Scale),
 		int(ex.Precision),
 		ex.Fields,
+		int(ex.Dimension),
 	}
 }
@@ -53,6 +53,12 @@ const (
 	NilArrayType
 	// NilMapType represents a nil map type, used internally to represent null maps in Snowflake.
 	NilMapType
+	// VectorType represents the VECTOR data type in Snowflake, which is a fixed-length list of FLOAT or INT elements.
+	VectorType
+	// GeographyType represents the GEOGRAPHY data type in Snowflake, which stores spatial objects on the WGS 84 spheroid.
+	GeographyType
+	// GeometryType represents the GEOMETRY data type in Snowflake, which stores spatial objects in a planar coordinate system.
+	GeometryType
 )
 
 // SnowflakeToDriverType maps Snowflake data type names (as strings) to their corresponding SnowflakeType constants used internally by the driver.
@@ -73,6 +79,9 @@ var SnowflakeToDriverType = map[string]SnowflakeType{
 	"BINARY":        BinaryType,
 	"TIME":          TimeType,
 	"BOOLEAN":       BooleanType,
+	"VECTOR":        VectorType,
+	"GEOGRAPHY":     GeographyType,
+	"GEOMETRY":      GeometryType,
 	"NULL":          NullType,
 	"SLICE":         SliceType,
 	"CHANGE_TYPE":   ChangeType,
@@ -20,6 +20,9 @@ func (util *localUtil) createClient(_ *execResponseStageInfo, _ bool, _ *Config,
 }
 
 func (util *localUtil) uploadOneFileWithRetry(_ context.Context, meta *fileMetadata) error {
+	if meta.resumable != nil {
+		return util.uploadOneFileResumable(meta)
+	}
 	var frd *bufio.Reader
 	if meta.srcStream != nil {
 		b := cmp.Or(meta.realSrcStream, meta.srcStream)
@@ -76,6 +79,48 @@ func (util *localUtil) uploadOneFileWithRetry(_ context.Context, meta *fileMetad
 	return nil
 }
 
+// uploadOneFileResumable copies the file to the stage, continuing after the bytes copied by an interrupted upload.
+func (util *localUtil) uploadOneFileResumable(meta *fileMetadata) error {
+	user, err := expandUser(meta.stageInfo.Location)
+	if err != nil {
+		return err
+	}
+	fullDstFileName := filepath.Join(user, meta.dstFileName)
+	// a partial file left by the interrupted upload does not count as an existing one
+	if !meta.overwrite && !meta.resumable.resumed {
+		if _, err := os.Stat(fullDstFileName); err == nil {
+			meta.dstFileSize = 0
+			meta.resStatus = skipped
+			return nil
+		}
+	}
+	if err = meta.resumable.writeFile(fullDstFileName, meta.uploadSize, func(w io.Writer, offset int64) error {
+		return copyFileFrom(w, meta.realSrcFileName, offset)
+	}); err != nil {
+		return err
+	}
+	meta.dstFileSize = meta.uploadSize
+	meta.resStatus = uploaded
+	return nil
+}
+
+func copyFileFrom(w io.Writer, fileName string, offset int64) error {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = f.Close(); err != nil {
+			logger.Warnf("failed to close the file %v: %v", fileName, err)
+		}
+	}()
+	if _, err = f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
 func (util *localUtil) downloadOneFile(_ context.Context, meta *fileMetadata) error {
 	srcFileName := meta.srcFileName
 	if strings.HasPrefix(meta.srcFileName, fmt.Sprintf("%b", os.PathSeparator)) {
@@ -101,12 +146,18 @@ func (util *localUtil) downloadOneFile(_ context.Context, meta *fileMetadata) er
 		}
 	}
 
-	data, err := os.ReadFile(fullSrcFileName)
-	if err != nil {
-		return err
-	}
-	if err = os.WriteFile(fullDstFileName, data, readWriteFileMode); err != nil {
-		return err
+	if isResumable(meta) {
+		if err = util.downloadOneFileResumable(meta, fullSrcFileName, fullDstFileName); err != nil {
+			return err
+		}
+	} else {
+		data, err := os.ReadFile(fullSrcFileName)
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(fullDstFileName, data, readWriteFileMode); err != nil {
+			return err
+		}
 	}
 	fi, err := os.Stat(fullDstFileName)
 	if err != nil {
@@ -116,3 +167,26 @@ func (util *localUtil) downloadOneFile(_ context.Context, meta *fileMetadata) er
 	meta.resStatus = downloaded
 	return nil
 }
+
+// downloadOneFileResumable copies the staged file to a temporary file next to the destination, continuing after
+// the bytes copied by an interrupted download, and renames it when it is complete.
+func (util *localUtil) downloadOneFileResumable(meta *fileMetadata, fullSrcFileName, fullDstFileName string) error {
+	fi, err := os.Stat(fullSrcFileName)
+	if err != nil {
+		return err
+	}
+	if err = startResumableDownload(meta, fullDstFileName, fi.Size(), "", "", fi.ModTime().UnixNano(), ""); err != nil {
+		return err
+	}
+	tempDownloadFile := fullDstFileName + ".tmp"
+	if err = meta.resumable.writeFile(tempDownloadFile, fi.Size(), func(w io.Writer, offset int64) error {
+		return copyFileFrom(w, fullSrcFileName, offset)
+	}); err != nil {
+		return err
+	}
+	if err = os.Rename(tempDownloadFile, fullDstFileName); err != nil {
+		return err
+	}
+	meta.resumable.remove()
+	return nil
+}
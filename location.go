@@ -10,6 +10,7 @@ import (
 
 var (
 	timezones           map[int]*time.Location
+	namedTimezones      map[string]*time.Location
 	updateTimezoneMutex *sync.Mutex
 )
 
@@ -63,6 +64,27 @@ func LocationWithOffsetString(offsets string) (loc *time.Location, err error) {
 	return
 }
 
+// LocationWithName returns a name-based Location object for an IANA time zone name (e.g. "America/Los_Angeles").
+// Unlike the offset-based Locations, it follows the daylight saving time changes of the zone.
+func LocationWithName(name string) (*time.Location, error) {
+	updateTimezoneMutex.Lock()
+	defer updateTimezoneMutex.Unlock()
+	if loc := namedTimezones[name]; loc != nil {
+		return loc, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, &SnowflakeError{
+			Number:      ErrInvalidTimezoneName,
+			SQLState:    SQLStateInvalidDataTimeFormat,
+			Message:     errors.ErrMsgInvalidTimezoneName,
+			MessageArgs: []any{name, err},
+		}
+	}
+	namedTimezones[name] = loc
+	return loc, nil
+}
+
 func genTimezone(offset int) *time.Location {
 	var offsetSign string
 	var toffset int
@@ -82,6 +104,7 @@ func genTimezone(offset int) *time.Location {
 func init() {
 	updateTimezoneMutex = &sync.Mutex{}
 	timezones = make(map[int]*time.Location, 48)
+	namedTimezones = make(map[string]*time.Location)
 	// pre-generate all common timezones
 	for i := -720; i <= 720; i += 30 {
 		logger.Debugf("offset: %v", i)
@@ -95,12 +118,27 @@ func getCurrentLocation(sp *syncParams) *time.Location {
 	if sp == nil {
 		return loc
 	}
-	var err error
 	if tz, ok := sp.get("timezone"); ok && tz != nil {
-		loc, err = time.LoadLocation(*tz)
+		namedLoc, err := LocationWithName(*tz)
 		if err != nil {
-			loc = time.Now().Location()
+			logger.Warnf("failed to load the session time zone, using the local time zone. %v", err)
+			return loc
 		}
+		loc = namedLoc
 	}
 	return loc
 }
+
+// timestampTzInLocation returns the TIMESTAMP_TZ value in the given location if the location had the same offset
+// at that time, so the value keeps the offset returned by Snowflake, otherwise the value is returned unchanged.
+func timestampTzInLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	_, offset := t.Zone()
+	inLoc := t.In(loc)
+	if _, locOffset := inLoc.Zone(); locOffset != offset {
+		return t
+	}
+	return inLoc
+}
@@ -146,3 +146,39 @@ func TestGetCurrentLocation(t *testing.T) {
 		})
 	}
 }
+
+func TestLocationWithName(t *testing.T) {
+	loc, err := LocationWithName("America/Los_Angeles")
+	assertNilF(t, err)
+	assertEqualE(t, loc.String(), "America/Los_Angeles")
+	cached, err := LocationWithName("America/Los_Angeles")
+	assertNilF(t, err)
+	assertTrueE(t, loc == cached, "location should be cached")
+
+	// the named location follows the daylight saving time changes
+	_, winterOffset := time.Date(2024, time.January, 1, 12, 0, 0, 0, loc).Zone()
+	_, summerOffset := time.Date(2024, time.July, 1, 12, 0, 0, 0, loc).Zone()
+	assertEqualE(t, winterOffset, -8*3600)
+	assertEqualE(t, summerOffset, -7*3600)
+
+	_, err = LocationWithName("Not/exists")
+	var se *SnowflakeError
+	assertErrorsAsF(t, err, &se)
+	assertEqualE(t, se.Number, ErrInvalidTimezoneName)
+}
+
+func TestTimestampTzInLocation(t *testing.T) {
+	warsaw, err := LocationWithName("Europe/Warsaw")
+	assertNilF(t, err)
+	winter := time.Date(2024, time.January, 1, 12, 0, 0, 0, Location(60))
+	summer := time.Date(2024, time.July, 1, 12, 0, 0, 0, Location(120))
+
+	assertEqualE(t, timestampTzInLocation(winter, warsaw).Location(), warsaw)
+	assertEqualE(t, timestampTzInLocation(summer, warsaw).Location(), warsaw)
+	assertTrueE(t, timestampTzInLocation(summer, warsaw).Equal(summer))
+
+	// the offset of the value doesn't match the location at that time
+	otherOffset := time.Date(2024, time.July, 1, 12, 0, 0, 0, Location(60))
+	assertEqualE(t, timestampTzInLocation(otherOffset, warsaw).Location(), Location(60))
+	assertEqualE(t, timestampTzInLocation(winter, nil).Location(), Location(60))
+}
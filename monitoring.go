@@ -4,8 +4,9 @@ import (
 	"context"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/snowflakedb/gosnowflake/v2/internal/errors"
+	sferrors "github.com/snowflakedb/gosnowflake/v2/internal/errors"
 	"net/url"
 	"strconv"
 	"time"
@@ -13,6 +14,9 @@ import (
 
 const urlQueriesResultFmt = "/queries/%s/result"
 
+// queryCancelledCode is the error code of the query cancelled by SYSTEM$CANCEL_QUERY
+const queryCancelledCode = "604"
+
 // queryResultStatus is status returned from server
 type queryResultStatus int
 
@@ -123,6 +127,20 @@ type SnowflakeQueryStatus struct {
 	ErrorMessage string
 	ScanBytes    int64
 	ProducedRows int64
+	Status       string // status reported by the server, e.g. RUNNING, QUEUED, BLOCKED or SUCCESS
+}
+
+func newSnowflakeQueryStatus(queryRet *retStatus) *SnowflakeQueryStatus {
+	return &SnowflakeQueryStatus{
+		SQLText:      queryRet.SQLText,
+		StartTime:    queryRet.StartTime,
+		EndTime:      queryRet.EndTime,
+		ErrorCode:    queryRet.ErrorCode,
+		ErrorMessage: queryRet.ErrorMessage,
+		ScanBytes:    queryRet.Stats.ScanBytes,
+		ProducedRows: queryRet.Stats.ProducedRows,
+		Status:       queryRet.Status,
+	}
 }
 
 // SnowflakeConnection is a wrapper to snowflakeConn that exposes API functions
@@ -141,6 +159,56 @@ type SnowflakeConnection interface {
 // 3, ErrQueryIsRunning, if the requested query is still running and might have
 // a complete result later, these statuses were listed in query. SFQueryRunning
 func (sc *snowflakeConn) checkQueryStatus(
+	ctx context.Context,
+	qid string) (
+	*retStatus, error) {
+	queryRet, err := sc.fetchQueryStatus(ctx, qid)
+	if err != nil {
+		return nil, err
+	}
+	return queryRet, sc.queryStatusError(qid, queryRet)
+}
+
+// queryStatusError returns the error described in checkQueryStatus for the status of the query.
+func (sc *snowflakeConn) queryStatusError(qid string, queryRet *retStatus) error {
+	if queryRet.ErrorCode != "" {
+		return exceptionTelemetry(&SnowflakeError{
+			Number:         ErrQueryStatus,
+			Message:        sferrors.ErrMsgQueryStatus,
+			MessageArgs:    []any{queryRet.ErrorCode, queryRet.ErrorMessage},
+			IncludeQueryID: true,
+			QueryID:        qid,
+		}, sc)
+	}
+
+	// returned errorCode is 0. Now check what is the returned status of the query.
+	qStatus := strToQueryStatus(queryRet.Status)
+	if qStatus.isError() {
+		return exceptionTelemetry(&SnowflakeError{
+			Number: ErrQueryReportedError,
+			Message: fmt.Sprintf("%s: status from server: [%s]",
+				queryRet.ErrorMessage, queryRet.Status),
+			IncludeQueryID: true,
+			QueryID:        qid,
+		}, sc)
+	}
+
+	if qStatus.isRunning() {
+		return exceptionTelemetry(&SnowflakeError{
+			Number: ErrQueryIsRunning,
+			Message: fmt.Sprintf("%s: status from server: [%s]",
+				queryRet.ErrorMessage, queryRet.Status),
+			IncludeQueryID: true,
+			QueryID:        qid,
+		}, sc)
+	}
+	//success
+	return nil
+}
+
+// fetchQueryStatus returns the status of the query given the query ID without checking it.
+// ErrQueryStatus is returned if GS cannot return any status, e.g. if the query was just submitted.
+func (sc *snowflakeConn) fetchQueryStatus(
 	ctx context.Context,
 	qid string) (
 	*retStatus, error) {
@@ -177,40 +245,54 @@ func (sc *snowflakeConn) checkQueryStatus(
 		}, sc)
 	}
 
-	queryRet := statusResp.Data.Queries[0]
-	if queryRet.ErrorCode != "" {
-		return &queryRet, exceptionTelemetry(&SnowflakeError{
-			Number:         ErrQueryStatus,
-			Message:        errors.ErrMsgQueryStatus,
-			MessageArgs:    []any{queryRet.ErrorCode, queryRet.ErrorMessage},
-			IncludeQueryID: true,
-			QueryID:        qid,
-		}, sc)
-	}
+	return &statusResp.Data.Queries[0], nil
+}
 
-	// returned errorCode is 0. Now check what is the returned status of the query.
-	qStatus := strToQueryStatus(queryRet.Status)
-	if qStatus.isError() {
-		return &queryRet, exceptionTelemetry(&SnowflakeError{
-			Number: ErrQueryReportedError,
-			Message: fmt.Sprintf("%s: status from server: [%s]",
-				queryRet.ErrorMessage, queryRet.Status),
-			IncludeQueryID: true,
-			QueryID:        qid,
-		}, sc)
+func validateQueryID(qid string) error {
+	if !queryIDRegexp.MatchString(qid) {
+		return &SnowflakeError{
+			Number:  ErrQueryIDFormat,
+			Message: "Invalid QID",
+			QueryID: qid,
+		}
 	}
+	return nil
+}
 
-	if qStatus.isRunning() {
-		return &queryRet, exceptionTelemetry(&SnowflakeError{
-			Number: ErrQueryIsRunning,
-			Message: fmt.Sprintf("%s: status from server: [%s]",
-				queryRet.ErrorMessage, queryRet.Status),
-			IncludeQueryID: true,
-			QueryID:        qid,
-		}, sc)
+// abortQuery requests the server to abort the query given the query ID.
+func (sc *snowflakeConn) abortQuery(ctx context.Context, qid string) error {
+	ctx = setResultType(context.WithValue(WithInternal(ctx), asyncMode, false), execResultType)
+	_, err := sc.exec(ctx, "SELECT SYSTEM$CANCEL_QUERY(?)", false, true, false,
+		[]driver.NamedValue{{Ordinal: 1, Value: qid}})
+	return err
+}
+
+// waitForQueryAbort polls the status of the aborted query until it stops running and
+// reports whether it was aborted. The query still running after the polls is reported as not aborted.
+func (sc *snowflakeConn) waitForQueryAbort(ctx context.Context, qid string) (bool, error) {
+	for _, multiplier := range asyncRetryPattern {
+		queryRet, err := sc.fetchQueryStatus(ctx, qid)
+		var se *SnowflakeError
+		if err != nil && (!errors.As(err, &se) || se.Number != ErrQueryStatus) {
+			return false, err
+		}
+		if err == nil {
+			switch qStatus := strToQueryStatus(queryRet.Status); {
+			case qStatus == SFQueryAborted || queryRet.ErrorCode == queryCancelledCode:
+				return true, nil
+			case !qStatus.isRunning() && qStatus != SFQueryAborting && qStatus != SFQueryBlocked:
+				logger.WithContext(ctx).Debugf("query %v was not aborted, status: %v", qid, queryRet.Status)
+				return false, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(defaultAsyncQueryPollInterval * time.Duration(multiplier)):
+		}
 	}
-	//success
-	return &queryRet, nil
+	logger.WithContext(ctx).Warnf("query %v is still running after it was aborted", qid)
+	return false, nil
 }
 
 func (sc *snowflakeConn) getQueryResultResp(
@@ -0,0 +1,138 @@
+package gosnowflake
+
+import (
+	"database/sql/driver"
+	"strconv"
+	"strings"
+
+	sferrors "github.com/snowflakedb/gosnowflake/v2/internal/errors"
+)
+
+func hasNamedBindings(bindings []driver.NamedValue) bool {
+	for _, binding := range bindings {
+		if binding.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// bindNamedParameters binds the named values, e.g. sql.Named("id", 1), to the :name parameters of the query.
+// The parameters are replaced with the :N placeholders numbered in the order of the first occurrence,
+// so one named parameter can be used several times in the query.
+func (sc *snowflakeConn) bindNamedParameters(query string, bindings []driver.NamedValue) (string, []driver.NamedValue, error) {
+	values := make(map[string]driver.NamedValue, len(bindings))
+	for _, binding := range bindings {
+		if binding.Name == "" {
+			return "", nil, exceptionTelemetry(&SnowflakeError{
+				Number:      ErrBindNamedParameter,
+				Message:     sferrors.ErrMsgNamedBindMixed,
+				MessageArgs: []any{binding.Ordinal},
+			}, sc)
+		}
+		values[binding.Name] = binding
+	}
+	query, names := replaceNamedParameters(query)
+	positional := make([]driver.NamedValue, len(names))
+	for i, name := range names {
+		binding, ok := values[name]
+		if !ok {
+			return "", nil, exceptionTelemetry(&SnowflakeError{
+				Number:      ErrBindNamedParameter,
+				Message:     sferrors.ErrMsgNamedBindMissing,
+				MessageArgs: []any{name},
+			}, sc)
+		}
+		delete(values, name)
+		positional[i] = driver.NamedValue{Ordinal: i + 1, Value: binding.Value}
+	}
+	for _, binding := range bindings {
+		if _, ok := values[binding.Name]; ok {
+			return "", nil, exceptionTelemetry(&SnowflakeError{
+				Number:      ErrBindNamedParameter,
+				Message:     sferrors.ErrMsgNamedBindUnused,
+				MessageArgs: []any{binding.Name},
+			}, sc)
+		}
+	}
+	return query, positional, nil
+}
+
+// replaceNamedParameters replaces the :name parameters of the query with the :N placeholders and returns
+// the distinct names in the order of the placeholders. String literals, quoted identifiers, comments,
+// casts (::) and paths of semi-structured values (col:name) are not parameters.
+func replaceNamedParameters(query string) (string, []string) {
+	var b strings.Builder
+	var names []string
+	ordinals := make(map[string]int)
+	for i := 0; i < len(query); {
+		end := i + 1
+		switch c := query[i]; {
+		case c == '\'' || c == '"':
+			end = skipQuoted(query, i+1, c)
+		case strings.HasPrefix(query[i:], "$$"):
+			end = skipUntil(query, i+2, "$$")
+		case strings.HasPrefix(query[i:], "--") || strings.HasPrefix(query[i:], "//"):
+			end = skipUntil(query, i+2, "\n")
+		case strings.HasPrefix(query[i:], "/*"):
+			end = skipUntil(query, i+2, "*/")
+		case strings.HasPrefix(query[i:], "::"):
+			end = i + 2
+		case c == ':' && (i == 0 || !isPathAccessPrefix(query[i-1])) && i+1 < len(query) && isNamedParameterStart(query[i+1]):
+			end = i + 2
+			for end < len(query) && isNamedParameterChar(query[end]) {
+				end++
+			}
+			name := query[i+1 : end]
+			ordinal, ok := ordinals[name]
+			if !ok {
+				names = append(names, name)
+				ordinal = len(names)
+				ordinals[name] = ordinal
+			}
+			b.WriteString(":" + strconv.Itoa(ordinal))
+			i = end
+			continue
+		}
+		b.WriteString(query[i:end])
+		i = end
+	}
+	return b.String(), names
+}
+
+// skipQuoted returns the index after the closing quote. Doubled quotes and backslash escapes in strings are skipped.
+func skipQuoted(query string, i int, quote byte) int {
+	for ; i < len(query); i++ {
+		switch {
+		case query[i] == '\\' && quote == '\'':
+			i++
+		case query[i] == quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+func skipUntil(query string, i int, terminator string) int {
+	if idx := strings.Index(query[i:], terminator); idx >= 0 {
+		return i + idx + len(terminator)
+	}
+	return len(query)
+}
+
+func isNamedParameterStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNamedParameterChar(c byte) bool {
+	return isNamedParameterStart(c) || (c >= '0' && c <= '9')
+}
+
+// isPathAccessPrefix reports whether the colon after c accesses a semi-structured value, e.g. col:name.
+func isPathAccessPrefix(c byte) bool {
+	return isNamedParameterChar(c) || c == '$' || c == '"' || c == ']' || c == ')'
+}
@@ -0,0 +1,99 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestReplaceNamedParameters(t *testing.T) {
+	testcases := []struct {
+		query    string
+		expected string
+		names    []string
+	}{
+		{query: "SELECT * FROM t WHERE id = :id", expected: "SELECT * FROM t WHERE id = :1", names: []string{"id"}},
+		{query: "SELECT :b, :a, :b", expected: "SELECT :1, :2, :1", names: []string{"b", "a"}},
+		{query: "INSERT INTO t VALUES (:id,:name_2)", expected: "INSERT INTO t VALUES (:1,:2)", names: []string{"id", "name_2"}},
+		{query: "SELECT :1, ?", expected: "SELECT :1, ?"},
+		{query: "SELECT ':id', \"a:b\", 'it''s :x', 'a\\' :y' FROM t", expected: "SELECT ':id', \"a:b\", 'it''s :x', 'a\\' :y' FROM t"},
+		{query: "SELECT $$ :id $$ -- :id\n/* :id */ // :id", expected: "SELECT $$ :id $$ -- :id\n/* :id */ // :id"},
+		{query: "SELECT v:name, v[0]:name, \"V\":name, x::int FROM t WHERE v:id = :id", expected: "SELECT v:name, v[0]:name, \"V\":name, x::int FROM t WHERE v:id = :1", names: []string{"id"}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.query, func(t *testing.T) {
+			query, names := replaceNamedParameters(tc.query)
+			assertEqualE(t, query, tc.expected)
+			assertDeepEqualE(t, names, tc.names)
+		})
+	}
+}
+
+func TestBindNamedParameters(t *testing.T) {
+	sc := &snowflakeConn{cfg: &Config{}}
+	query, bindings, err := sc.bindNamedParameters("SELECT * FROM t WHERE a = :a OR b = :b OR c = :a",
+		[]driver.NamedValue{{Name: "b", Ordinal: 1, Value: "B"}, {Name: "a", Ordinal: 2, Value: int64(1)}})
+	assertNilF(t, err)
+	assertEqualE(t, query, "SELECT * FROM t WHERE a = :1 OR b = :2 OR c = :1")
+	assertDeepEqualE(t, bindings, []driver.NamedValue{{Ordinal: 1, Value: int64(1)}, {Ordinal: 2, Value: "B"}})
+
+	testcases := []struct {
+		name     string
+		query    string
+		bindings []driver.NamedValue
+	}{
+		{name: "missing", query: "SELECT :a, :b", bindings: []driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1}}},
+		{name: "unused", query: "SELECT :a", bindings: []driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1}, {Name: "c", Ordinal: 2, Value: 1}}},
+		{name: "mixed", query: "SELECT :a, :2", bindings: []driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1}, {Ordinal: 2, Value: 1}}},
+		{name: "case sensitive", query: "SELECT :ID", bindings: []driver.NamedValue{{Name: "id", Ordinal: 1, Value: 1}}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := sc.bindNamedParameters(tc.query, tc.bindings)
+			var se *SnowflakeError
+			assertErrorsAsF(t, err, &se)
+			assertEqualE(t, se.Number, ErrBindNamedParameter)
+		})
+	}
+}
+
+func TestExecWithNamedBindings(t *testing.T) {
+	var req execRequest
+	postQueryMock := func(_ context.Context, _ *snowflakeRestful, _ *url.Values, _ map[string]string, body []byte, _ time.Duration, _ UUID, _ *Config) (*execResponse, error) {
+		assertNilF(t, json.Unmarshal(body, &req))
+		return &execResponse{Data: execResponseData{QueryID: "query-id"}, Success: true}, nil
+	}
+	sc := &snowflakeConn{cfg: &Config{}, rest: &snowflakeRestful{FuncPostQuery: postQueryMock}}
+
+	named := sql.Named("id", int64(42))
+	_, err := sc.ExecContext(context.Background(), "DELETE FROM t WHERE id = :id OR parent_id = :id",
+		[]driver.NamedValue{{Name: named.Name, Ordinal: 1, Value: named.Value}})
+	assertNilF(t, err)
+	assertEqualE(t, req.SQLText, "DELETE FROM t WHERE id = :1 OR parent_id = :1")
+	assertEqualF(t, len(req.Bindings), 1)
+	assertEqualE(t, req.Bindings["1"].Value, any("42"))
+}
+
+func TestPrepareScriptingVariablesWithoutArgs(t *testing.T) {
+	block := "DECLARE x INTEGER DEFAULT 1; BEGIN LET y := :x + 1; RETURN :y; END;"
+	var sqlTexts []string
+	postQueryMock := func(_ context.Context, _ *snowflakeRestful, _ *url.Values, _ map[string]string, body []byte, _ time.Duration, _ UUID, _ *Config) (*execResponse, error) {
+		var req execRequest
+		assertNilF(t, json.Unmarshal(body, &req))
+		assertEqualE(t, len(req.Bindings), 0)
+		sqlTexts = append(sqlTexts, req.SQLText)
+		return &execResponse{Data: execResponseData{QueryID: "query-id"}, Code: "0", Success: true}, nil
+	}
+	sc := &snowflakeConn{cfg: &Config{}, rest: &snowflakeRestful{FuncPostQuery: postQueryMock, TokenAccessor: getSimpleTokenAccessor()}}
+
+	stmt, err := sc.PrepareContext(context.Background(), block)
+	assertNilF(t, err)
+	assertEqualE(t, stmt.NumInput(), -1)
+	_, err = stmt.(driver.StmtExecContext).ExecContext(context.Background(), nil)
+	assertNilF(t, err)
+	assertDeepEqualE(t, sqlTexts, []string{block, block})
+}
@@ -88,7 +88,8 @@ type execResponseData struct {
 	FinalSchemaName    string                      `json:"finalSchemaName,omitempty"`
 	FinalWarehouseName string                      `json:"finalWarehouseName,omitempty"`
 	FinalRoleName      string                      `json:"finalRoleName,omitempty"`
-	NumberOfBinds      int                         `json:"numberOfBinds,omitempty"`   // java:int
+	NumberOfBinds      int                         `json:"numberOfBinds,omitempty"` // java:int
+	MetaDataOfBinds    []query.ExecResponseRowType `json:"metaDataOfBinds,omitempty"`
 	StatementTypeID    int64                       `json:"statementTypeId,omitempty"` // java:long
 	Version            int64                       `json:"version,omitempty"`         // java:long
 	Chunks             []query.ExecResponseChunk   `json:"chunks,omitempty"`
@@ -0,0 +1,104 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"time"
+
+	sfconfig "github.com/snowflakedb/gosnowflake/v2/internal/config"
+)
+
+// QueryKind is the method executing the intercepted statement.
+type QueryKind = sfconfig.QueryKind
+
+const (
+	// QueryKindExec is the statement executed with ExecContext
+	QueryKindExec = sfconfig.QueryKindExec
+	// QueryKindQuery is the statement executed with QueryContext
+	QueryKindQuery = sfconfig.QueryKindQuery
+	// QueryKindArrowStream is the statement executed with QueryArrowStream
+	QueryKindArrowStream = sfconfig.QueryKindArrowStream
+)
+
+// InterceptedQuery is the statement passed through the QueryInterceptor chain.
+// SQL and Bindings can be changed before the next handler is called, the other fields describe
+// the execution after it returns.
+type InterceptedQuery = sfconfig.InterceptedQuery
+
+// QueryHandler executes the intercepted statement.
+type QueryHandler = sfconfig.QueryHandler
+
+// QueryInterceptor wraps the execution of the statements set in Config.QueryInterceptors.
+// It calls next to execute the statement, possibly with a changed context or query.
+// It can short-circuit the statement without calling next by returning an error,
+// or by setting Result (ExecContext) or Rows (QueryContext) of the query.
+// QueryArrowStream and SubmitAsync can only be short-circuited with an error.
+type QueryInterceptor = sfconfig.QueryInterceptor
+
+const interceptedQueryKey execKey = "interceptedQuery"
+
+var errQueryNotExecutedByInterceptor = errors.New("query interceptor returned without executing the query or setting its result")
+
+func (sc *snowflakeConn) shouldIntercept(ctx context.Context) bool {
+	return len(sc.cfg.QueryInterceptors) > 0 && !isInternal(ctx)
+}
+
+// intercept passes the statement through the interceptor chain ending with execute, which sets the result of the query.
+// It returns the query with the result set by execute or by the interceptor short-circuiting the statement.
+func (sc *snowflakeConn) intercept(ctx context.Context, kind QueryKind, query string, bindings []driver.NamedValue,
+	execute func(ctx context.Context, query *InterceptedQuery) error) (*InterceptedQuery, error) {
+	intercepted := &InterceptedQuery{
+		Kind:         kind,
+		SQL:          query,
+		Bindings:     bindings,
+		Async:        kind != QueryKindArrowStream && isAsyncMode(ctx),
+		RowsAffected: -1,
+	}
+	if tag, ok := ctx.Value(queryTag).(string); ok {
+		intercepted.QueryTag = tag
+	}
+
+	executed := false
+	handler := func(ctx context.Context, q *InterceptedQuery) error {
+		executed = true
+		start := time.Now()
+		err := execute(context.WithValue(ctx, interceptedQueryKey, q), q)
+		q.Duration = time.Since(start)
+		var se *SnowflakeError
+		switch {
+		case err != nil:
+			q.Status = SFQueryFailedWithError.String()
+			if errors.As(err, &se) && se.QueryID != "" {
+				q.QueryID = se.QueryID
+			}
+		case q.Async:
+			q.Status = SFQueryRunning.String()
+		default:
+			q.Status = SFQuerySuccess.String()
+		}
+		return err
+	}
+	for _, interceptor := range slices.Backward(sc.cfg.QueryInterceptors) {
+		next := handler
+		handler = func(ctx context.Context, q *InterceptedQuery) error {
+			return interceptor(ctx, q, next)
+		}
+	}
+	if err := handler(ctx, intercepted); err != nil {
+		return nil, err
+	}
+	if !executed && (kind != QueryKindExec || intercepted.Result == nil) && (kind != QueryKindQuery || intercepted.Rows == nil) {
+		return nil, errQueryNotExecutedByInterceptor
+	}
+	return intercepted, nil
+}
+
+// recordInterceptedQueryID saves the ID of the statement sent to Snowflake in the intercepted query.
+// The statement executed last is the intercepted one, the earlier ones create e.g. the bind stage.
+func recordInterceptedQueryID(ctx context.Context, data *execResponse) {
+	if q, ok := ctx.Value(interceptedQueryKey).(*InterceptedQuery); ok && data != nil {
+		q.QueryID = data.Data.QueryID
+	}
+}
@@ -0,0 +1,116 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/snowflakedb/gosnowflake/v2/internal/query"
+)
+
+func newInterceptorTestConn(t *testing.T, requests *[]execRequest, interceptors ...QueryInterceptor) *snowflakeConn {
+	return &snowflakeConn{
+		cfg: &Config{QueryInterceptors: interceptors},
+		rest: &snowflakeRestful{FuncPostQuery: recordingPostQuery(t, requests, func(req execRequest) *execResponse {
+			if strings.Contains(req.SQLText, "FAIL") {
+				return &execResponse{Data: execResponseData{QueryID: "failed-query-id"}, Code: "1003", Message: "syntax error", Success: false}
+			}
+			count := "3"
+			return &execResponse{Data: execResponseData{
+				QueryID:         "query-id",
+				StatementTypeID: statementTypeIDDml,
+				RowType:         []query.ExecResponseRowType{{Name: "number of rows inserted", Type: "fixed"}},
+				RowSet:          [][]*string{{&count}},
+			}, Success: true}
+		})},
+	}
+}
+
+func TestQueryInterceptorChain(t *testing.T) {
+	var requests []execRequest
+	var calls []string
+	var intercepted InterceptedQuery
+	outer := func(ctx context.Context, q *InterceptedQuery, next QueryHandler) error {
+		calls = append(calls, "outer:"+q.QueryTag)
+		err := next(ctx, q)
+		intercepted = *q
+		return err
+	}
+	inner := func(ctx context.Context, q *InterceptedQuery, next QueryHandler) error {
+		calls = append(calls, "inner:"+q.Kind.String())
+		q.SQL = "/* tenant=acme */ " + q.SQL
+		return next(ctx, q)
+	}
+	sc := newInterceptorTestConn(t, &requests, outer, inner)
+
+	result, err := sc.ExecContext(WithQueryTag(context.Background(), "tag"), "INSERT INTO t VALUES (?)", []driver.NamedValue{{Ordinal: 1, Value: int64(1)}})
+	assertNilF(t, err)
+	rowsAffected, err := result.RowsAffected()
+	assertNilF(t, err)
+	assertEqualE(t, rowsAffected, int64(3))
+	assertDeepEqualE(t, calls, []string{"outer:tag", "inner:EXEC"})
+	assertDeepEqualE(t, sqlTexts(requests), []string{"/* tenant=acme */ INSERT INTO t VALUES (?)"})
+	assertEqualE(t, intercepted.QueryID, "query-id")
+	assertEqualE(t, intercepted.Status, "SUCCESS")
+	assertEqualE(t, intercepted.RowsAffected, int64(3))
+	assertEqualE(t, len(intercepted.Bindings), 1)
+	assertTrueE(t, intercepted.Duration > 0)
+
+	requests = nil
+	_, err = sc.ExecContext(context.Background(), "FAIL", nil)
+	var se *SnowflakeError
+	assertErrorsAsF(t, err, &se)
+	assertEqualE(t, intercepted.QueryID, "failed-query-id")
+	assertEqualE(t, intercepted.Status, "FAILED_WITH_ERROR")
+	assertEqualE(t, intercepted.RowsAffected, int64(-1))
+
+	requests = nil
+	_, err = sc.ExecContext(WithInternal(context.Background()), "INSERT INTO t VALUES (1)", nil)
+	assertNilF(t, err)
+	assertDeepEqualE(t, sqlTexts(requests), []string{"INSERT INTO t VALUES (1)"}, "internal statements should not be intercepted")
+}
+
+func TestQueryInterceptorShortCircuit(t *testing.T) {
+	var requests []execRequest
+	denied := errors.New("DROP is not allowed")
+	cachedRows := &snowflakeRows{queryID: "cached"}
+	sc := newInterceptorTestConn(t, &requests, func(ctx context.Context, q *InterceptedQuery, next QueryHandler) error {
+		if strings.HasPrefix(q.SQL, "DROP") {
+			return denied
+		}
+		if strings.HasPrefix(q.SQL, "SKIP") {
+			return nil
+		}
+		if strings.HasPrefix(q.SQL, "CACHED") {
+			q.Result = driver.RowsAffected(7)
+			q.Rows = cachedRows
+			return nil
+		}
+		return next(ctx, q)
+	})
+
+	_, err := sc.ExecContext(context.Background(), "DROP TABLE t", nil)
+	assertErrIsE(t, err, denied)
+	_, err = sc.QueryContext(context.Background(), "DROP TABLE t", nil)
+	assertErrIsE(t, err, denied)
+	_, err = sc.QueryArrowStream(context.Background(), "DROP TABLE t")
+	assertErrIsE(t, err, denied)
+	_, err = sc.ExecContext(context.Background(), "SKIP", nil)
+	assertErrIsE(t, err, errQueryNotExecutedByInterceptor)
+
+	result, err := sc.ExecContext(context.Background(), "CACHED", nil)
+	assertNilF(t, err)
+	rowsAffected, err := result.RowsAffected()
+	assertNilF(t, err)
+	assertEqualE(t, rowsAffected, int64(7))
+	rows, err := sc.QueryContext(context.Background(), "CACHED", nil)
+	assertNilF(t, err)
+	assertEqualE(t, rows, driver.Rows(cachedRows))
+	_, err = sc.QueryArrowStream(context.Background(), "CACHED")
+	assertErrIsE(t, err, errQueryNotExecutedByInterceptor)
+	_, err = sc.SubmitAsync(context.Background(), "CACHED", nil)
+	assertErrIsE(t, err, errQueryNotExecutedByInterceptor)
+	assertEqualE(t, len(requests), 0)
+}
@@ -0,0 +1,213 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+const queryOperatorStatsQuery = "SELECT TO_JSON(OBJECT_CONSTRUCT(*)) FROM TABLE(GET_QUERY_OPERATOR_STATS(?)) ORDER BY STEP_ID, OPERATOR_ID"
+
+// QueryProfile is the profile of a completed query, built from GET_QUERY_OPERATOR_STATS.
+type QueryProfile struct {
+	QueryID string
+	// Operators are all operators of the query ordered by the step and the operator ID.
+	Operators []*QueryOperator
+	// Roots are the operators without a parent, e.g. the Result operator of each step.
+	Roots []*QueryOperator
+}
+
+// QueryOperator is a node of the operator tree of the query.
+type QueryOperator struct {
+	StepID            int64
+	OperatorID        int64
+	ParentOperatorIDs []int64
+	Type              string         // e.g. TableScan, Join, Aggregate or Result
+	Attributes        map[string]any // attributes specific to the operator type, e.g. table_name or join condition
+	Stats             QueryOperatorStats
+	ExecutionTime     QueryOperatorExecutionTime
+	Children          []*QueryOperator
+}
+
+// QueryOperatorStats are the statistics of the operator reported in OPERATOR_STATISTICS.
+type QueryOperatorStats struct {
+	InputRows                  int64
+	OutputRows                 int64
+	BytesScanned               int64
+	BytesWritten               int64
+	PercentageScannedFromCache float64
+	PartitionsScanned          int64
+	PartitionsTotal            int64
+	BytesSpilledLocalStorage   int64
+	BytesSpilledRemoteStorage  int64
+	NetworkBytes               int64
+}
+
+// QueryOperatorExecutionTime is the share of the query execution time spent by the operator,
+// as reported in EXECUTION_TIME_BREAKDOWN.
+type QueryOperatorExecutionTime struct {
+	OverallPercentage    float64
+	Initialization       float64
+	Processing           float64
+	Synchronization      float64
+	LocalDiskIO          float64
+	RemoteDiskIO         float64
+	NetworkCommunication float64
+}
+
+type queryOperatorStatsRow struct {
+	StepID          int64   `json:"STEP_ID"`
+	OperatorID      int64   `json:"OPERATOR_ID"`
+	ParentOperators []int64 `json:"PARENT_OPERATORS"`
+	OperatorType    string  `json:"OPERATOR_TYPE"`
+	Statistics      struct {
+		InputRows  int64 `json:"input_rows"`
+		OutputRows int64 `json:"output_rows"`
+		IO         struct {
+			BytesScanned               int64   `json:"bytes_scanned"`
+			BytesWritten               int64   `json:"bytes_written"`
+			PercentageScannedFromCache float64 `json:"percentage_scanned_from_cache"`
+		} `json:"io"`
+		Pruning struct {
+			PartitionsScanned int64 `json:"partitions_scanned"`
+			PartitionsTotal   int64 `json:"partitions_total"`
+		} `json:"pruning"`
+		Spilling struct {
+			BytesSpilledLocalStorage  int64 `json:"bytes_spilled_local_storage"`
+			BytesSpilledRemoteStorage int64 `json:"bytes_spilled_remote_storage"`
+		} `json:"spilling"`
+		Network struct {
+			NetworkBytes int64 `json:"network_bytes"`
+		} `json:"network"`
+	} `json:"OPERATOR_STATISTICS"`
+	ExecutionTime struct {
+		OverallPercentage    float64 `json:"overall_percentage"`
+		Initialization       float64 `json:"initialization"`
+		Processing           float64 `json:"processing"`
+		Synchronization      float64 `json:"synchronization"`
+		LocalDiskIO          float64 `json:"local_disk_io"`
+		RemoteDiskIO         float64 `json:"remote_disk_io"`
+		NetworkCommunication float64 `json:"network_communication"`
+	} `json:"EXECUTION_TIME_BREAKDOWN"`
+	Attributes map[string]any `json:"OPERATOR_ATTRIBUTES"`
+}
+
+// QueryProfiler is implemented by the connections of the driver and returns the profiles of completed queries.
+type QueryProfiler interface {
+	GetQueryProfile(ctx context.Context, queryID string) (*QueryProfile, error)
+}
+
+// GetQueryProfile returns the operator tree and statistics of the completed query given the query ID.
+func (sc *snowflakeConn) GetQueryProfile(ctx context.Context, queryID string) (*QueryProfile, error) {
+	if err := validateQueryID(queryID); err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(WithInternal(ctx), asyncMode, false)
+	rows, err := sc.queryContextInternal(ctx, queryOperatorStatsQuery, []driver.NamedValue{{Ordinal: 1, Value: queryID}})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.WithContext(ctx).Warnf("failed to close rows. err: %v", err)
+		}
+	}()
+
+	profile := &QueryProfile{QueryID: queryID}
+	dest := make([]driver.Value, 1)
+	for {
+		if err = rows.Next(dest); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		text, ok := dest[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected operator statistics of query %v: %v", queryID, dest[0])
+		}
+		var row queryOperatorStatsRow
+		if err = json.Unmarshal([]byte(text), &row); err != nil {
+			return nil, err
+		}
+		profile.Operators = append(profile.Operators, newQueryOperator(row))
+	}
+	profile.buildTree()
+	return profile, nil
+}
+
+func newQueryOperator(row queryOperatorStatsRow) *QueryOperator {
+	stats := row.Statistics
+	return &QueryOperator{
+		StepID:            row.StepID,
+		OperatorID:        row.OperatorID,
+		ParentOperatorIDs: row.ParentOperators,
+		Type:              row.OperatorType,
+		Attributes:        row.Attributes,
+		Stats: QueryOperatorStats{
+			InputRows:                  stats.InputRows,
+			OutputRows:                 stats.OutputRows,
+			BytesScanned:               stats.IO.BytesScanned,
+			BytesWritten:               stats.IO.BytesWritten,
+			PercentageScannedFromCache: stats.IO.PercentageScannedFromCache,
+			PartitionsScanned:          stats.Pruning.PartitionsScanned,
+			PartitionsTotal:            stats.Pruning.PartitionsTotal,
+			BytesSpilledLocalStorage:   stats.Spilling.BytesSpilledLocalStorage,
+			BytesSpilledRemoteStorage:  stats.Spilling.BytesSpilledRemoteStorage,
+			NetworkBytes:               stats.Network.NetworkBytes,
+		},
+		ExecutionTime: QueryOperatorExecutionTime(row.ExecutionTime),
+	}
+}
+
+// buildTree links the operators to their parents within the same step.
+func (qp *QueryProfile) buildTree() {
+	type operatorKey struct{ stepID, operatorID int64 }
+	operators := make(map[operatorKey]*QueryOperator, len(qp.Operators))
+	for _, op := range qp.Operators {
+		operators[operatorKey{op.StepID, op.OperatorID}] = op
+	}
+	for _, op := range qp.Operators {
+		isRoot := true
+		for _, parentID := range op.ParentOperatorIDs {
+			if parent, ok := operators[operatorKey{op.StepID, parentID}]; ok {
+				parent.Children = append(parent.Children, op)
+				isRoot = false
+			}
+		}
+		if isRoot {
+			qp.Roots = append(qp.Roots, op)
+		}
+	}
+}
+
+// SpillingOperators returns the operators spilling data to the local or remote storage.
+func (qp *QueryProfile) SpillingOperators() []*QueryOperator {
+	return slices.DeleteFunc(slices.Clone(qp.Operators), func(op *QueryOperator) bool {
+		return !op.HasSpill()
+	})
+}
+
+// PoorlyPrunedOperators returns the table scans reading more than the given fraction (0-1) of the partitions.
+func (qp *QueryProfile) PoorlyPrunedOperators(maxScannedFraction float64) []*QueryOperator {
+	return slices.DeleteFunc(slices.Clone(qp.Operators), func(op *QueryOperator) bool {
+		return op.Stats.PartitionsTotal == 0 || op.PruningRatio() <= maxScannedFraction
+	})
+}
+
+// HasSpill reports whether the operator spilled data to the local or remote storage.
+func (op *QueryOperator) HasSpill() bool {
+	return op.Stats.BytesSpilledLocalStorage > 0 || op.Stats.BytesSpilledRemoteStorage > 0
+}
+
+// PruningRatio returns the fraction of the partitions scanned by the operator, 0 if it scans no table.
+// The lower the ratio, the more partitions were pruned.
+func (op *QueryOperator) PruningRatio() float64 {
+	if op.Stats.PartitionsTotal == 0 {
+		return 0
+	}
+	return float64(op.Stats.PartitionsScanned) / float64(op.Stats.PartitionsTotal)
+}
@@ -0,0 +1,64 @@
+package gosnowflake
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/snowflakedb/gosnowflake/v2/internal/query"
+)
+
+func TestGetQueryProfile(t *testing.T) {
+	operatorStats := []string{
+		`{"STEP_ID": 1, "OPERATOR_ID": 0, "OPERATOR_TYPE": "Result", "OPERATOR_STATISTICS": {"input_rows": 10, "output_rows": 10},
+		  "EXECUTION_TIME_BREAKDOWN": {"overall_percentage": 0.1, "processing": 1}}`,
+		`{"STEP_ID": 1, "OPERATOR_ID": 1, "PARENT_OPERATORS": [0], "OPERATOR_TYPE": "Sort",
+		  "OPERATOR_STATISTICS": {"input_rows": 10, "output_rows": 10, "spilling": {"bytes_spilled_local_storage": 1024}},
+		  "EXECUTION_TIME_BREAKDOWN": {"overall_percentage": 0.6, "processing": 0.2, "local_disk_io": 0.8}}`,
+		`{"STEP_ID": 1, "OPERATOR_ID": 2, "PARENT_OPERATORS": [1], "OPERATOR_TYPE": "TableScan",
+		  "OPERATOR_STATISTICS": {"output_rows": 10, "io": {"bytes_scanned": 4096}, "pruning": {"partitions_scanned": 95, "partitions_total": 100}},
+		  "EXECUTION_TIME_BREAKDOWN": {"overall_percentage": 0.3}, "OPERATOR_ATTRIBUTES": {"table_name": "DB.PUBLIC.T"}}`,
+	}
+	var req execRequest
+	postQueryMock := func(_ context.Context, _ *snowflakeRestful, _ *url.Values, _ map[string]string, body []byte, _ time.Duration, _ UUID, _ *Config) (*execResponse, error) {
+		assertNilF(t, json.Unmarshal(body, &req))
+		rowSet := make([][]*string, len(operatorStats))
+		for i := range operatorStats {
+			rowSet[i] = []*string{&operatorStats[i]}
+		}
+		return &execResponse{Data: execResponseData{
+			QueryID:  "profile-query-id",
+			RowType:  []query.ExecResponseRowType{{Name: "TO_JSON(OBJECT_CONSTRUCT(*))", Type: "text"}},
+			RowSet:   rowSet,
+			Total:    int64(len(rowSet)),
+			Returned: int64(len(rowSet)),
+		}, Success: true}, nil
+	}
+	sc := &snowflakeConn{cfg: &Config{}, rest: &snowflakeRestful{FuncPostQuery: postQueryMock}}
+
+	profile, err := sc.GetQueryProfile(context.Background(), "01b2c3d4-0000-0000-0000-000000000001")
+	assertNilF(t, err)
+	assertEqualE(t, req.SQLText, queryOperatorStatsQuery)
+	assertTrueE(t, req.IsInternal)
+	assertEqualE(t, req.Bindings["1"].Value, any("01b2c3d4-0000-0000-0000-000000000001"))
+
+	assertEqualF(t, len(profile.Operators), 3)
+	assertEqualF(t, len(profile.Roots), 1)
+	result := profile.Roots[0]
+	assertEqualE(t, result.Type, "Result")
+	assertEqualF(t, len(result.Children), 1)
+	sort := result.Children[0]
+	assertEqualE(t, sort.Type, "Sort")
+	assertEqualE(t, sort.ExecutionTime.LocalDiskIO, 0.8)
+	assertEqualF(t, len(sort.Children), 1)
+	scan := sort.Children[0]
+	assertEqualE(t, scan.Stats.BytesScanned, int64(4096))
+	assertEqualE(t, scan.Attributes["table_name"], any("DB.PUBLIC.T"))
+	assertEqualE(t, scan.PruningRatio(), 0.95)
+
+	assertDeepEqualE(t, profile.SpillingOperators(), []*QueryOperator{sort})
+	assertDeepEqualE(t, profile.PoorlyPrunedOperators(0.5), []*QueryOperator{scan})
+	assertEqualE(t, len(profile.PoorlyPrunedOperators(0.99)), 0)
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync/atomic"
 	"time"
 )
 
@@ -59,6 +60,10 @@ type snowflakeRestful struct {
 	TokenAccessor TokenAccessor
 	HeartBeat     *heartbeat
 
+	instrumentation *instrumentation
+	// set when the session is closed or expired and cannot be renewed
+	sessionInvalid atomic.Bool
+
 	Connection *snowflakeConn
 
 	FuncPostQuery       func(context.Context, *snowflakeRestful, *url.Values, map[string]string, []byte, time.Duration, UUID, *Config) (*execResponse, error)
@@ -115,7 +120,9 @@ func (sr *snowflakeRestful) renewExpiredSessionToken(ctx context.Context, timeou
 	currentToken, _, _ := sr.TokenAccessor.GetTokens()
 	if expiredToken == currentToken || currentToken == "" {
 		// Only renew the session if the current token is still the expired token or current token is empty
-		return sr.FuncRenewSession(ctx, sr, timeout)
+		err = sr.FuncRenewSession(ctx, sr, timeout)
+		sr.markSessionInvalid(err)
+		return err
 	}
 	return nil
 }
@@ -225,6 +232,13 @@ func postRestfulQueryHelper(
 	requestID UUID,
 	cfg *Config) (
 	data *execResponse, err error) {
+	ctx, span := sr.instrumentation.startSpan(ctx, spanPostQuery, attrRequestID.String(requestID.String()))
+	defer func() {
+		if data != nil && data.Data.QueryID != "" {
+			span.SetAttributes(attrQueryID.String(data.Data.QueryID))
+		}
+		endSpan(span, err)
+	}()
 	logger.WithContext(ctx).Infof("params: %v", params)
 	params.Set(requestIDKey, requestID.String())
 	params.Set(requestGUIDKey, NewUUID().String())
@@ -13,6 +13,26 @@ import (
 	"time"
 )
 
+// recordingPostQuery returns a FuncPostQuery mock which appends the requests to requests
+// and returns the responses of respond.
+func recordingPostQuery(t *testing.T, requests *[]execRequest, respond func(req execRequest) *execResponse) func(context.Context, *snowflakeRestful, *url.Values, map[string]string, []byte, time.Duration, UUID, *Config) (*execResponse, error) {
+	return func(_ context.Context, _ *snowflakeRestful, _ *url.Values, _ map[string]string, body []byte, _ time.Duration, _ UUID, _ *Config) (*execResponse, error) {
+		var req execRequest
+		assertNilF(t, json.Unmarshal(body, &req))
+		*requests = append(*requests, req)
+		return respond(req), nil
+	}
+}
+
+// sqlTexts returns the SQL texts of the recorded requests.
+func sqlTexts(requests []execRequest) []string {
+	var texts []string
+	for _, req := range requests {
+		texts = append(texts, req.SQLText)
+	}
+	return texts
+}
+
 func postTestError(_ context.Context, _ *snowflakeRestful, _ *url.URL, _ map[string]string, _ []byte, _ time.Duration, _ currentTimeProvider, _ *Config) (*http.Response, error) {
 	return &http.Response{
 		StatusCode: http.StatusOK,
@@ -0,0 +1,445 @@
+package gosnowflake
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// version of the checkpoint files, checkpoints of other versions are discarded
+const transferCheckpointVersion = 1
+
+const (
+	defaultCheckpointDirName = "snowflake_transfer_checkpoints"
+	// number of bytes written sequentially between two saved checkpoints
+	checkpointInterval = 8 * 1024 * 1024
+	// S3 requires parts of at least 5MB except the last one and allows 10000 parts
+	minResumablePartSize = 5 * 1024 * 1024
+	maxResumableParts    = 10000
+)
+
+type transferPart struct {
+	Number int    `json:"number"`
+	ID     string `json:"id"` // S3 ETag or Azure block ID
+}
+
+// transferCheckpoint is the state of the resumable transfer of a single file, saved as JSON in the checkpoint directory.
+type transferCheckpoint struct {
+	Version       int    `json:"version"`
+	Command       string `json:"command"`
+	Source        string `json:"source"`
+	Destination   string `json:"destination"`
+	SourceSize    int64  `json:"sourceSize"`
+	SourceModTime int64  `json:"sourceModTime,omitempty"`
+	SourceDigest  string `json:"sourceDigest,omitempty"`
+	SourceVersion string `json:"sourceVersion,omitempty"` // GCS object generation or ETag
+	SourceKey     string `json:"sourceKey,omitempty"`     // encrypted file key of the staged file
+	SMKID         int64  `json:"smkId,omitempty"`
+
+	// PUT keeps the compressed and encrypted file until the upload completes, so the resumed upload sends the same bytes
+	PreparedFile  string         `json:"preparedFile,omitempty"`
+	UploadSize    int64          `json:"uploadSize,omitempty"`
+	SHA256Digest  string         `json:"sha256Digest,omitempty"`
+	EncryptionKey string         `json:"encryptionKey,omitempty"`
+	EncryptionIV  string         `json:"encryptionIv,omitempty"`
+	Matdesc       string         `json:"matdesc,omitempty"`
+	UploadID      string         `json:"uploadId,omitempty"` // S3 upload ID or GCS upload session URL
+	PartSize      int64          `json:"partSize,omitempty"`
+	Parts         []transferPart `json:"parts,omitempty"`
+
+	// number of bytes written to the destination file by GET and PUT to local stages, or persisted by GCS
+	Offset int64 `json:"offset,omitempty"`
+}
+
+// resumableTransfer saves the checkpoint of a file transfer every time a part completes.
+type resumableTransfer struct {
+	path    string // checkpoint file
+	dataDir string // directory of the prepared PUT file
+	resumed bool   // the checkpoint was left by an earlier transfer of the same file
+	mu      sync.Mutex
+	cp      transferCheckpoint
+}
+
+func checkpointDir(meta *fileMetadata) string {
+	if meta.options != nil && meta.options.CheckpointDir != "" {
+		return meta.options.CheckpointDir
+	}
+	tmpDirPath := ""
+	if meta.sfa != nil && meta.sfa.sc != nil && meta.sfa.sc.cfg != nil {
+		tmpDirPath = meta.sfa.sc.cfg.TmpDirPath
+	}
+	return filepath.Join(cmp.Or(tmpDirPath, os.TempDir()), defaultCheckpointDirName)
+}
+
+func isResumable(meta *fileMetadata) bool {
+	if meta.options == nil || !meta.options.Resumable || meta.fileStream != nil || meta.srcStream != nil {
+		return false
+	}
+	return meta.stageLocationType == s3Client || meta.stageLocationType == azureClient ||
+		meta.stageLocationType == gcsClient || meta.stageLocationType == local
+}
+
+// isResumableUpload reports whether the PUT of meta is resumable. GCS uploads use resumable upload sessions,
+// which are started with the access token, so the uploads with presigned URLs are not resumable.
+func isResumableUpload(meta *fileMetadata) bool {
+	if meta.stageLocationType != gcsClient {
+		return isResumable(meta)
+	}
+	return meta.options != nil && meta.options.Resumable && meta.fileStream == nil && meta.srcStream == nil && meta.presignedURL == nil
+}
+
+// newResumableTransfer returns the transfer of the source to the destination of the expected checkpoint.
+// The checkpoint left by an earlier transfer is loaded if its source did not change since.
+func newResumableTransfer(dir string, expected transferCheckpoint) (*resumableTransfer, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory %v. %w", dir, err)
+	}
+	expected.Version = transferCheckpointVersion
+	hash := sha256.Sum256([]byte(strings.Join([]string{expected.Command, expected.Source, expected.Destination}, "\x00")))
+	id := hex.EncodeToString(hash[:])
+	rt := &resumableTransfer{
+		path:    filepath.Join(dir, id+".json"),
+		dataDir: filepath.Join(dir, id),
+	}
+	var cp transferCheckpoint
+	data, err := os.ReadFile(rt.path)
+	if err == nil {
+		err = json.Unmarshal(data, &cp)
+	}
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		logger.Warnf("discarding unreadable checkpoint %v. %v", rt.path, err)
+	case cp.Version != expected.Version || cp.Source != expected.Source || cp.Destination != expected.Destination ||
+		cp.SourceSize != expected.SourceSize || cp.SourceModTime != expected.SourceModTime ||
+		cp.SourceDigest != expected.SourceDigest || cp.SourceVersion != expected.SourceVersion || cp.SourceKey != expected.SourceKey || cp.SMKID != expected.SMKID:
+		logger.Infof("discarding checkpoint %v, the source of %v changed", rt.path, expected.Source)
+	default:
+		rt.resumed = true
+		rt.cp = cp
+		return rt, nil
+	}
+	rt.cp = expected
+	if err = os.RemoveAll(rt.dataDir); err != nil {
+		return nil, err
+	}
+	return rt, rt.save()
+}
+
+func (rt *resumableTransfer) checkpoint() transferCheckpoint {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	cp := rt.cp
+	cp.Parts = append([]transferPart(nil), rt.cp.Parts...)
+	return cp
+}
+
+// update changes the checkpoint and saves it.
+func (rt *resumableTransfer) update(f func(cp *transferCheckpoint)) error {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	f(&rt.cp)
+	return rt.save()
+}
+
+func (rt *resumableTransfer) save() error {
+	data, err := json.Marshal(rt.cp)
+	if err != nil {
+		return err
+	}
+	// os.CreateTemp creates the file with 0600 permissions
+	tmpFile, err := os.CreateTemp(filepath.Dir(rt.path), filepath.Base(rt.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint file. %w", err)
+	}
+	defer func() {
+		if err := os.Remove(tmpFile.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("failed to remove temporary checkpoint file %v. %v", tmpFile.Name(), err)
+		}
+	}()
+	if _, err = tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("failed to write checkpoint file. %w", err)
+	}
+	if err = tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint file. %w", err)
+	}
+	if err = os.Rename(tmpFile.Name(), rt.path); err != nil {
+		return fmt.Errorf("failed to replace checkpoint file %v. %w", rt.path, err)
+	}
+	return nil
+}
+
+// remove deletes the checkpoint and the prepared file of a completed transfer.
+func (rt *resumableTransfer) remove() {
+	if err := os.RemoveAll(rt.dataDir); err != nil {
+		logger.Warnf("failed to remove checkpoint data %v. %v", rt.dataDir, err)
+	}
+	if err := os.Remove(rt.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warnf("failed to remove checkpoint %v. %v", rt.path, err)
+	}
+}
+
+// partSize returns the size of the parts a file of size bytes is uploaded in, keeping the size of an upload in progress.
+func (rt *resumableTransfer) partSize(size, preferred int64) int64 {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if rt.cp.PartSize > 0 {
+		return rt.cp.PartSize
+	}
+	return max(preferred, minResumablePartSize, (size+maxResumableParts-1)/maxResumableParts)
+}
+
+// uploadParts uploads the parts of a file of size bytes which are not in the checkpoint yet, at most concurrency
+// at a time. uploadPart returns the ID of the uploaded part, which is saved in the checkpoint.
+// It returns the IDs of all parts in order.
+func (rt *resumableTransfer) uploadParts(ctx context.Context, size, partSize int64, concurrency int,
+	uploadPart func(ctx context.Context, number int, offset, length int64) (string, error)) ([]transferPart, error) {
+	numParts := int(max(1, (size+partSize-1)/partSize))
+	uploaded := make(map[int]bool)
+	cp := rt.checkpoint()
+	for _, part := range cp.Parts {
+		uploaded[part.Number] = true
+	}
+	if len(uploaded) > 0 {
+		logger.Infof("resuming upload of %v, %v of %v parts already uploaded", cp.Source, len(uploaded), numParts)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var errOnce sync.Once
+	var firstErr error
+	numbers := make(chan int)
+	var wg sync.WaitGroup
+	for range max(1, concurrency) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for number := range numbers {
+				offset := int64(number-1) * partSize
+				id, err := uploadPart(ctx, number, offset, min(partSize, size-offset))
+				if err == nil {
+					err = rt.update(func(cp *transferCheckpoint) {
+						cp.Parts = append(cp.Parts, transferPart{Number: number, ID: id})
+					})
+				}
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+queue:
+	for number := 1; number <= numParts; number++ {
+		if uploaded[number] {
+			continue
+		}
+		select {
+		case numbers <- number:
+		case <-ctx.Done():
+			break queue
+		}
+	}
+	close(numbers)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]transferPart, numParts)
+	for _, part := range rt.checkpoint().Parts {
+		if part.Number >= 1 && part.Number <= numParts {
+			ids[part.Number-1] = part
+		}
+	}
+	return ids, nil
+}
+
+// resetParts discards the parts of an upload the storage does not know anymore, so the next attempt starts over.
+func (rt *resumableTransfer) resetParts() {
+	if err := rt.update(func(cp *transferCheckpoint) {
+		cp.UploadID = ""
+		cp.PartSize = 0
+		cp.Parts = nil
+	}); err != nil {
+		logger.Warnf("failed to reset checkpoint %v. %v", rt.path, err)
+	}
+}
+
+// writeFile writes a file of size bytes sequentially, continuing after the bytes written before.
+// writeFrom writes the content of the file from the offset to w.
+func (rt *resumableTransfer) writeFile(fileName string, size int64, writeFrom func(w io.Writer, offset int64) error) error {
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY, readWriteFileMode)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			logger.Warnf("failed to close %v file: %v", fileName, err)
+		}
+	}()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	// the file may contain bytes written after the last checkpoint
+	cp := rt.checkpoint()
+	offset := min(cp.Offset, fi.Size(), size)
+	if err = f.Truncate(offset); err != nil {
+		return err
+	}
+	if _, err = f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	if offset > 0 {
+		logger.Infof("resuming transfer of %v at byte %v of %v", cp.Source, offset, size)
+	}
+	w := &checkpointWriter{file: f, rt: rt, offset: offset, saved: offset}
+	if offset < size {
+		err = writeFrom(w, offset)
+	}
+	if saveErr := w.save(); err == nil {
+		err = saveErr
+	}
+	if err != nil {
+		return err
+	}
+	if w.offset != size {
+		return fmt.Errorf("transferred %v bytes of %v, expected %v bytes", w.offset, cp.Source, size)
+	}
+	return nil
+}
+
+// checkpointWriter writes to a file and saves the number of bytes written in the checkpoint
+// every checkpointInterval bytes.
+type checkpointWriter struct {
+	file   *os.File
+	rt     *resumableTransfer
+	offset int64
+	saved  int64
+}
+
+func (w *checkpointWriter) Write(p []byte) (int, error) {
+	n, err := w.file.Write(p)
+	w.offset += int64(n)
+	if err == nil && w.offset-w.saved >= checkpointInterval {
+		err = w.save()
+	}
+	return n, err
+}
+
+func (w *checkpointWriter) save() error {
+	if w.offset == w.saved {
+		return nil
+	}
+	// the bytes must be on disk before the checkpoint says they are
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	offset := w.offset
+	if err := w.rt.update(func(cp *transferCheckpoint) { cp.Offset = offset }); err != nil {
+		return err
+	}
+	w.saved = offset
+	return nil
+}
+
+// startResumableUpload loads the checkpoint of the upload of meta. If the upload was interrupted before,
+// meta is set up to upload the file prepared then and true is returned.
+func startResumableUpload(meta *fileMetadata) (bool, error) {
+	src, err := filepath.Abs(meta.srcFileName)
+	if err != nil {
+		return false, err
+	}
+	fi, err := os.Stat(src)
+	if err != nil {
+		return false, err
+	}
+	expected := transferCheckpoint{
+		Command:       "PUT",
+		Source:        src,
+		Destination:   meta.stageInfo.Location + meta.dstFileName,
+		SourceSize:    fi.Size(),
+		SourceModTime: fi.ModTime().UnixNano(),
+	}
+	if meta.encryptionMaterial != nil && meta.stageLocationType != local {
+		expected.SMKID = meta.encryptionMaterial.SMKID
+	}
+	rt, err := newResumableTransfer(checkpointDir(meta), expected)
+	if err != nil {
+		return false, err
+	}
+	meta.resumable = rt
+	meta.tmpDir = rt.dataDir
+	cp := rt.checkpoint()
+	if rt.resumed && cp.PreparedFile != "" {
+		if pfi, err := os.Stat(cp.PreparedFile); err == nil && pfi.Size() == cp.UploadSize {
+			meta.realSrcFileName = cp.PreparedFile
+			meta.uploadSize = cp.UploadSize
+			meta.sha256Digest = cp.SHA256Digest
+			if cp.EncryptionKey != "" {
+				meta.encryptMeta = &encryptMetadata{cp.EncryptionKey, cp.EncryptionIV, cp.Matdesc}
+			}
+			return true, nil
+		}
+		logger.Infof("prepared file of %v is missing, starting the upload over", src)
+	}
+	if err = rt.update(func(cp *transferCheckpoint) { *cp = expected; cp.Version = transferCheckpointVersion }); err != nil {
+		return false, err
+	}
+	rt.resumed = false
+	if err = os.RemoveAll(rt.dataDir); err != nil {
+		return false, err
+	}
+	return false, os.MkdirAll(rt.dataDir, 0700)
+}
+
+// savePreparedFile records the compressed and encrypted file of meta in the checkpoint.
+func savePreparedFile(meta *fileMetadata) error {
+	return meta.resumable.update(func(cp *transferCheckpoint) {
+		cp.PreparedFile = meta.realSrcFileName
+		cp.UploadSize = meta.uploadSize
+		cp.SHA256Digest = meta.sha256Digest
+		if meta.encryptMeta != nil {
+			cp.EncryptionKey = meta.encryptMeta.key
+			cp.EncryptionIV = meta.encryptMeta.iv
+			cp.Matdesc = meta.encryptMeta.matdesc
+		}
+	})
+}
+
+// startResumableDownload loads the checkpoint of the download of meta to fullDstFileName. The checkpoint is
+// discarded if the staged file is not the one downloaded before.
+func startResumableDownload(meta *fileMetadata, fullDstFileName string, size int64, digest, version string, modTime int64, fileKey string) error {
+	rt, err := newResumableTransfer(checkpointDir(meta), transferCheckpoint{
+		Command:       "GET",
+		Source:        meta.stageInfo.Location + strings.TrimLeft(meta.srcFileName, "/"),
+		Destination:   fullDstFileName,
+		SourceSize:    size,
+		SourceModTime: modTime,
+		SourceDigest:  digest,
+		SourceVersion: version,
+		SourceKey:     fileKey,
+	})
+	if err != nil {
+		return err
+	}
+	meta.resumable = rt
+	return nil
+}
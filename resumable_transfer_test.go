@@ -0,0 +1,566 @@
+package gosnowflake
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func randomTestFile(t *testing.T, size int) (string, []byte) {
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	assertNilF(t, err)
+	fileName := filepath.Join(t.TempDir(), "data.bin")
+	assertNilF(t, os.WriteFile(fileName, data, 0600))
+	return fileName, data
+}
+
+func TestResumableTransferCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	expected := transferCheckpoint{Command: "PUT", Source: "/data/file.csv", Destination: "stage/file.csv.gz", SourceSize: 100}
+	rt, err := newResumableTransfer(dir, expected)
+	assertNilF(t, err)
+	assertFalseE(t, rt.resumed, "new checkpoint should not be resumed")
+	assertNilF(t, rt.update(func(cp *transferCheckpoint) {
+		cp.UploadID = "upload-1"
+		cp.Parts = append(cp.Parts, transferPart{Number: 1, ID: "etag-1"})
+	}))
+	stat, err := os.Stat(rt.path)
+	assertNilF(t, err)
+	if !isWindows {
+		assertEqualE(t, stat.Mode().Perm(), os.FileMode(0600))
+	}
+
+	t.Run("same source", func(t *testing.T) {
+		loaded, err := newResumableTransfer(dir, expected)
+		assertNilF(t, err)
+		assertTrueE(t, loaded.resumed, "checkpoint should be resumed")
+		assertEqualE(t, loaded.checkpoint().UploadID, "upload-1")
+		assertDeepEqualE(t, loaded.checkpoint().Parts, []transferPart{{Number: 1, ID: "etag-1"}})
+	})
+
+	t.Run("changed source", func(t *testing.T) {
+		changed := expected
+		changed.SourceSize = 200
+		loaded, err := newResumableTransfer(dir, changed)
+		assertNilF(t, err)
+		assertFalseE(t, loaded.resumed, "checkpoint of a changed source should be discarded")
+		assertEqualE(t, loaded.checkpoint().UploadID, "")
+	})
+
+	t.Run("corrupted checkpoint", func(t *testing.T) {
+		assertNilF(t, os.WriteFile(rt.path, []byte("{not json"), 0600))
+		loaded, err := newResumableTransfer(dir, expected)
+		assertNilF(t, err)
+		assertFalseE(t, loaded.resumed, "unreadable checkpoint should be discarded")
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		rt.remove()
+		_, err := os.Stat(rt.path)
+		assertErrIsE(t, err, os.ErrNotExist)
+	})
+}
+
+func TestResumableTransferUploadParts(t *testing.T) {
+	rt, err := newResumableTransfer(t.TempDir(), transferCheckpoint{Command: "PUT", Source: "src", Destination: "dst"})
+	assertNilF(t, err)
+	var mu sync.Mutex
+	calls := map[int]int{}
+	failPart := 3
+	uploadPart := func(_ context.Context, number int, offset, length int64) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[number]++
+		assertEqualE(t, offset, int64(number-1)*10)
+		if number == failPart {
+			return "", errors.New("connection reset")
+		}
+		if number == 4 {
+			assertEqualE(t, length, int64(5))
+		}
+		return "id-" + strconv.Itoa(number), nil
+	}
+
+	_, err = rt.uploadParts(context.Background(), 35, 10, 1, uploadPart)
+	assertNotNilF(t, err)
+	assertEqualE(t, len(rt.checkpoint().Parts), 2)
+
+	failPart = 0
+	parts, err := rt.uploadParts(context.Background(), 35, 10, 2, uploadPart)
+	assertNilF(t, err)
+	assertDeepEqualE(t, parts, []transferPart{{1, "id-1"}, {2, "id-2"}, {3, "id-3"}, {4, "id-4"}})
+	assertDeepEqualE(t, calls, map[int]int{1: 1, 2: 1, 3: 2, 4: 1})
+}
+
+// failingReader returns an error after limit bytes, like an interrupted connection.
+type failingReader struct {
+	r     io.Reader
+	limit int
+}
+
+func (fr *failingReader) Read(p []byte) (int, error) {
+	if fr.limit <= 0 {
+		return 0, errors.New("connection reset by peer")
+	}
+	n, err := fr.r.Read(p[:min(len(p), fr.limit)])
+	fr.limit -= n
+	return n, err
+}
+
+func TestResumableTransferWriteFile(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), checkpointInterval/10+1000)
+	rt, err := newResumableTransfer(t.TempDir(), transferCheckpoint{Command: "GET", Source: "src", Destination: "dst"})
+	assertNilF(t, err)
+	fileName := filepath.Join(t.TempDir(), "file.tmp")
+	size := int64(len(data))
+
+	err = rt.writeFile(fileName, size, func(w io.Writer, offset int64) error {
+		assertEqualE(t, offset, int64(0))
+		_, err := io.Copy(w, &failingReader{r: bytes.NewReader(data), limit: checkpointInterval + 100})
+		return err
+	})
+	assertNotNilF(t, err)
+	assertEqualE(t, rt.checkpoint().Offset, int64(checkpointInterval+100))
+
+	// bytes written after the checkpoint are discarded
+	f, err := os.OpenFile(fileName, os.O_APPEND|os.O_WRONLY, 0600)
+	assertNilF(t, err)
+	_, err = f.Write([]byte("garbage"))
+	assertNilF(t, err)
+	assertNilF(t, f.Close())
+
+	err = rt.writeFile(fileName, size, func(w io.Writer, offset int64) error {
+		assertEqualE(t, offset, int64(checkpointInterval+100))
+		_, err := w.Write(data[offset:])
+		return err
+	})
+	assertNilF(t, err)
+	written, err := os.ReadFile(fileName)
+	assertNilF(t, err)
+	assertTrueE(t, bytes.Equal(written, data), "file should contain the data once")
+}
+
+type mockS3ObjectAPI struct {
+	mu        sync.Mutex
+	object    []byte
+	getLimit  int
+	ranges    []string
+	uploads   int
+	parts     map[int32][]byte
+	failPart  int32
+	completed []types.CompletedPart
+}
+
+func (m *mockS3ObjectAPI) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ranges = append(m.ranges, aws.ToString(params.Range))
+	offset, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(aws.ToString(params.Range), "bytes="), "-"))
+	if err != nil {
+		return nil, err
+	}
+	var body io.Reader = bytes.NewReader(m.object[offset:])
+	if m.getLimit > 0 {
+		body = &failingReader{r: body, limit: m.getLimit}
+		m.getLimit = 0
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(body)}, nil
+}
+
+func (m *mockS3ObjectAPI) CreateMultipartUpload(context.Context, *s3.CreateMultipartUploadInput, ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.uploads++
+	m.parts = map[int32][]byte{}
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String(fmt.Sprintf("upload-%v", m.uploads))}, nil
+}
+
+func (m *mockS3ObjectAPI) UploadPart(_ context.Context, params *s3.UploadPartInput, _ ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	number := aws.ToInt32(params.PartNumber)
+	if number == m.failPart {
+		return nil, errors.New("connection reset by peer")
+	}
+	m.parts[number] = data
+	return &s3.UploadPartOutput{ETag: aws.String(fmt.Sprintf("etag-%v", number))}, nil
+}
+
+func (m *mockS3ObjectAPI) CompleteMultipartUpload(_ context.Context, params *s3.CompleteMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.completed = params.MultipartUpload.Parts
+	var object []byte
+	for _, part := range m.completed {
+		object = append(object, m.parts[aws.ToInt32(part.PartNumber)]...)
+	}
+	m.object = object
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func TestS3UploadFileInPartsResumes(t *testing.T) {
+	info := execResponseStageInfo{Location: "sfc-teststage/users/1234/", LocationType: "S3"}
+	s3Cli, err := new(snowflakeS3Client).createClient(&info, false, &snowflakeTelemetry{})
+	assertNilF(t, err)
+	fileName, data := randomTestFile(t, 2*minResumablePartSize+1024)
+	checkpointDir := t.TempDir()
+	mock := &mockS3ObjectAPI{failPart: 2}
+	newUploadMeta := func() *fileMetadata {
+		rt, err := newResumableTransfer(checkpointDir, transferCheckpoint{Command: "PUT", Source: fileName, Destination: "data.bin"})
+		assertNilF(t, err)
+		return &fileMetadata{
+			name:              "data.bin",
+			stageLocationType: s3Client,
+			noSleepingTime:    true,
+			parallel:          1,
+			client:            s3Cli,
+			stageInfo:         &info,
+			dstFileName:       "data.bin",
+			srcFileName:       fileName,
+			realSrcFileName:   fileName,
+			uploadSize:        int64(len(data)),
+			overwrite:         true,
+			options:           &SnowflakeFileTransferOptions{Resumable: true},
+			resumable:         rt,
+			mockObjectAPI:     mock,
+		}
+	}
+	util := &snowflakeS3Client{cfg: &Config{}}
+
+	meta := newUploadMeta()
+	err = util.uploadFile(context.Background(), fileName, meta, 1, 0)
+	assertNotNilF(t, err)
+	assertEqualE(t, meta.resStatus, needRetry)
+	assertEqualE(t, len(meta.resumable.checkpoint().Parts), 1)
+
+	// a new PUT of the same file continues the multipart upload
+	mock.failPart = 0
+	meta = newUploadMeta()
+	assertTrueF(t, meta.resumable.resumed, "upload should be resumed")
+	assertNilF(t, util.uploadFile(context.Background(), fileName, meta, 2, 0))
+	assertEqualE(t, meta.resStatus, uploaded)
+	assertEqualE(t, mock.uploads, 1)
+	assertEqualE(t, len(mock.completed), 3)
+	for i, part := range mock.completed {
+		assertEqualE(t, aws.ToInt32(part.PartNumber), int32(i+1))
+	}
+	assertTrueE(t, bytes.Equal(mock.object, data), "uploaded object should match the file")
+}
+
+func TestS3DownloadOneFileResumes(t *testing.T) {
+	info := execResponseStageInfo{Location: "sfc-teststage/users/1234/", LocationType: "S3"}
+	s3Cli, err := new(snowflakeS3Client).createClient(&info, false, &snowflakeTelemetry{})
+	assertNilF(t, err)
+	_, data := randomTestFile(t, checkpointInterval+1024*1024)
+	mock := &mockS3ObjectAPI{object: data, getLimit: checkpointInterval + 1000}
+	checkpointDir := t.TempDir()
+	localLocation := t.TempDir()
+	meta := &fileMetadata{
+		name:              "data.bin",
+		stageLocationType: s3Client,
+		noSleepingTime:    true,
+		parallel:          1,
+		client:            s3Cli,
+		stageInfo:         &info,
+		srcFileName:       "data.bin",
+		dstFileName:       "data.bin",
+		localLocation:     localLocation,
+		options:           &SnowflakeFileTransferOptions{Resumable: true, CheckpointDir: checkpointDir},
+		mockObjectAPI:     mock,
+		mockHeader: mockHeaderAPI(func(context.Context, *s3.HeadObjectInput, ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+			return &s3.HeadObjectOutput{
+				ContentLength: aws.Int64(int64(len(data))),
+				Metadata:      map[string]string{sfcDigest: "digest"},
+			}, nil
+		}),
+		sfa: &snowflakeFileTransferAgent{sc: &snowflakeConn{cfg: &Config{}}},
+	}
+
+	assertNilF(t, new(remoteStorageUtil).downloadOneFile(context.Background(), meta))
+	assertEqualE(t, meta.resStatus, downloaded)
+	assertDeepEqualE(t, mock.ranges, []string{"bytes=0-", fmt.Sprintf("bytes=%v-", checkpointInterval+1000)})
+	downloaded, err := os.ReadFile(filepath.Join(localLocation, "data.bin"))
+	assertNilF(t, err)
+	assertTrueE(t, bytes.Equal(downloaded, data), "downloaded file should match the object")
+	checkpoints, err := os.ReadDir(checkpointDir)
+	assertNilF(t, err)
+	assertEqualE(t, len(checkpoints), 0)
+}
+
+func TestLocalUploadAndDownloadResumable(t *testing.T) {
+	stageDir := t.TempDir()
+	localLocation := t.TempDir()
+	checkpointDir := t.TempDir()
+	fileName, data := randomTestFile(t, 1024)
+	options := &SnowflakeFileTransferOptions{Resumable: true, CheckpointDir: checkpointDir}
+	sfa := &snowflakeFileTransferAgent{sc: &snowflakeConn{cfg: &Config{}}, stageLocationType: local, options: options}
+	stageInfo := &execResponseStageInfo{Location: stageDir, LocationType: string(local)}
+
+	uploadMeta := &fileMetadata{
+		name:              "data.bin",
+		sfa:               sfa,
+		stageLocationType: local,
+		stageInfo:         stageInfo,
+		srcFileName:       fileName,
+		dstFileName:       "data.bin",
+		options:           options,
+	}
+	_, err := sfa.uploadOneFile(uploadMeta)
+	assertNilF(t, err)
+	assertEqualE(t, uploadMeta.resStatus, uploaded)
+
+	downloadMeta := &fileMetadata{
+		name:              "data.bin",
+		sfa:               sfa,
+		stageLocationType: local,
+		stageInfo:         stageInfo,
+		srcFileName:       "data.bin",
+		dstFileName:       "data.bin",
+		localLocation:     localLocation,
+		options:           options,
+	}
+	assertNilF(t, new(localUtil).downloadOneFile(context.Background(), downloadMeta))
+	assertEqualE(t, downloadMeta.resStatus, downloaded)
+	downloaded, err := os.ReadFile(filepath.Join(localLocation, "data.bin"))
+	assertNilF(t, err)
+	assertTrueE(t, bytes.Equal(downloaded, data), "downloaded file should match the uploaded one")
+	checkpoints, err := os.ReadDir(checkpointDir)
+	assertNilF(t, err)
+	assertEqualE(t, len(checkpoints), 0)
+}
+
+// mockGcsSession is a GCS resumable upload session failing the upload of the chunk failChunk.
+type mockGcsSession struct {
+	sessions  int
+	chunks    int
+	failChunk int
+	object    []byte
+	done      bool
+}
+
+func (m *mockGcsSession) Do(req *http.Request) (*http.Response, error) {
+	resp := &http.Response{StatusCode: gcsResumeIncomplete, Header: make(http.Header), Body: http.NoBody}
+	if req.Method == http.MethodPost {
+		if req.Header.Get("x-goog-resumable") != "start" || req.Header.Get(gcsMetadataEncryptionDataProp) == "" {
+			resp.StatusCode = http.StatusBadRequest
+			return resp, nil
+		}
+		m.sessions++
+		resp.StatusCode = http.StatusCreated
+		resp.Header.Set("Location", "https://storage.googleapis.com/upload/session-"+strconv.Itoa(m.sessions))
+		return resp, nil
+	}
+	contentRange := strings.TrimPrefix(req.Header.Get("Content-Range"), "bytes ")
+	if !strings.HasPrefix(contentRange, "*/") {
+		m.chunks++
+		if m.chunks == m.failChunk {
+			resp.StatusCode = http.StatusServiceUnavailable
+			return resp, nil
+		}
+		var first int
+		_, err := fmt.Sscanf(contentRange, "%d-", &first)
+		if err != nil || first != len(m.object) {
+			resp.StatusCode = http.StatusBadRequest
+			return resp, nil
+		}
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		m.object = append(m.object, body...)
+		m.done = !strings.HasSuffix(contentRange, "/*")
+	}
+	if m.done {
+		resp.StatusCode = http.StatusOK
+	} else if len(m.object) > 0 {
+		resp.Header.Set("Range", fmt.Sprintf("bytes=0-%v", len(m.object)-1))
+	}
+	return resp, nil
+}
+
+func TestGcsUploadFileInSessionResumes(t *testing.T) {
+	info := execResponseStageInfo{Location: "gcs-blob/storage/users/456/", LocationType: "GCS"}
+	fileName, data := randomTestFile(t, 2*minResumablePartSize+1024)
+	checkpointDir := t.TempDir()
+	mock := &mockGcsSession{failChunk: 2}
+	newUploadMeta := func() *fileMetadata {
+		rt, err := newResumableTransfer(checkpointDir, transferCheckpoint{Command: "PUT", Source: fileName, Destination: "data.bin"})
+		assertNilF(t, err)
+		return &fileMetadata{
+			name:              "data.bin",
+			stageLocationType: gcsClient,
+			noSleepingTime:    true,
+			client:            "token",
+			stageInfo:         &info,
+			dstFileName:       "data.bin",
+			srcFileName:       fileName,
+			realSrcFileName:   fileName,
+			uploadSize:        int64(len(data)),
+			overwrite:         true,
+			encryptMeta:       &encryptMetadata{"key", "iv", "matdesc"},
+			options:           &SnowflakeFileTransferOptions{Resumable: true},
+			resumable:         rt,
+			mockGcsClient:     mock,
+		}
+	}
+	meta := newUploadMeta()
+	assertTrueE(t, isResumableUpload(meta))
+	util := &snowflakeGcsClient{cfg: &Config{}}
+
+	assertNotNilF(t, util.uploadFile(context.Background(), fileName, meta, 1, 0))
+	assertEqualE(t, meta.resStatus, needRetry)
+	cp := meta.resumable.checkpoint()
+	assertStringContainsE(t, cp.UploadID, "session-1")
+	assertEqualE(t, cp.Offset, int64(minResumablePartSize))
+
+	// a new PUT of the same file continues the upload session
+	meta = newUploadMeta()
+	assertTrueF(t, meta.resumable.resumed, "upload should be resumed")
+	assertNilF(t, util.uploadFile(context.Background(), fileName, meta, 1, 0))
+	assertEqualE(t, meta.resStatus, uploaded)
+	assertEqualE(t, mock.sessions, 1)
+	assertTrueE(t, bytes.Equal(mock.object, data), "uploaded object should match the file")
+}
+
+func TestGcsUploadFileInSessionExpired(t *testing.T) {
+	info := execResponseStageInfo{Location: "gcs-blob/storage/users/456/", LocationType: "GCS"}
+	fileName, data := randomTestFile(t, 1024)
+	rt, err := newResumableTransfer(t.TempDir(), transferCheckpoint{Command: "PUT", Source: fileName, Destination: "data.bin"})
+	assertNilF(t, err)
+	assertNilF(t, rt.update(func(cp *transferCheckpoint) { cp.UploadID = "https://storage.googleapis.com/upload/expired" }))
+	mock := &mockGcsSession{}
+	meta := &fileMetadata{
+		stageLocationType: gcsClient,
+		client:            "token",
+		stageInfo:         &info,
+		dstFileName:       "data.bin",
+		uploadSize:        int64(len(data)),
+		encryptMeta:       &encryptMetadata{"key", "iv", "matdesc"},
+		options:           &SnowflakeFileTransferOptions{Resumable: true},
+		resumable:         rt,
+		mockGcsClient: &clientMock{DoFunc: func(req *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(req.URL.Path, "/expired") {
+				return &http.Response{StatusCode: http.StatusGone, Header: make(http.Header), Body: http.NoBody}, nil
+			}
+			return mock.Do(req)
+		}},
+	}
+	assertNilF(t, (&snowflakeGcsClient{cfg: &Config{}}).uploadFile(context.Background(), fileName, meta, 1, 0))
+	assertEqualE(t, meta.resStatus, uploaded)
+	assertEqualE(t, mock.sessions, 1)
+	assertTrueE(t, bytes.Equal(mock.object, data), "uploaded object should match the file")
+}
+
+// mockGcsObject serves a GCS object to HEAD and ranged GET requests. The first GET is interrupted after getLimit bytes,
+// and replaces the object with the replacement if it is set.
+type mockGcsObject struct {
+	mu          sync.Mutex
+	object      []byte
+	generation  int
+	getLimit    int
+	replacement []byte
+	ranges      []string
+}
+
+func (m *mockGcsObject) Do(req *http.Request) (*http.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	header := http.Header{"X-Goog-Generation": {strconv.Itoa(m.generation)}}
+	if req.Method == http.MethodHead {
+		return &http.Response{StatusCode: http.StatusOK, Header: header, ContentLength: int64(len(m.object)), Body: http.NoBody}, nil
+	}
+	m.ranges = append(m.ranges, req.Header.Get("Range"))
+	offset, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(req.Header.Get("Range"), "bytes="), "-"))
+	if err != nil {
+		return nil, err
+	}
+	var body io.Reader = bytes.NewReader(m.object[offset:])
+	if m.getLimit > 0 {
+		body = &failingReader{r: body, limit: m.getLimit}
+		m.getLimit = 0
+		if m.replacement != nil {
+			m.object, m.replacement = m.replacement, nil
+			m.generation++
+		}
+	}
+	return &http.Response{StatusCode: http.StatusPartialContent, Header: header, Body: io.NopCloser(body)}, nil
+}
+
+func TestGcsDownloadOneFileResumes(t *testing.T) {
+	_, data := randomTestFile(t, checkpointInterval+1024*1024)
+	_, replacement := randomTestFile(t, 1024*1024)
+	testcases := []struct {
+		name     string
+		mock     *mockGcsObject
+		expected []byte
+		ranges   []string
+	}{
+		{
+			name:     "resumed",
+			mock:     &mockGcsObject{object: data, generation: 1, getLimit: checkpointInterval + 1000},
+			expected: data,
+			ranges:   []string{"bytes=0-", fmt.Sprintf("bytes=%v-", checkpointInterval+1000)},
+		},
+		{
+			name:     "object replaced",
+			mock:     &mockGcsObject{object: data, generation: 1, getLimit: checkpointInterval + 1000, replacement: replacement},
+			expected: replacement,
+			ranges:   []string{"bytes=0-", "bytes=0-"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			info := execResponseStageInfo{Location: "gcs-blob/storage/users/456/", LocationType: "GCS"}
+			presignedURL, err := url.Parse("https://storage.googleapis.com/gcs-blob/storage/users/456/data.bin?X-Goog-Signature=abc")
+			assertNilF(t, err)
+			checkpointDir := t.TempDir()
+			localLocation := t.TempDir()
+			meta := &fileMetadata{
+				name:              "data.bin",
+				stageLocationType: gcsClient,
+				noSleepingTime:    true,
+				parallel:          1,
+				client:            "",
+				presignedURL:      presignedURL,
+				stageInfo:         &info,
+				srcFileName:       "data.bin",
+				dstFileName:       "data.bin",
+				localLocation:     localLocation,
+				options:           &SnowflakeFileTransferOptions{Resumable: true, CheckpointDir: checkpointDir},
+				mockGcsClient:     tc.mock,
+				sfa:               &snowflakeFileTransferAgent{sc: &snowflakeConn{cfg: &Config{}}},
+			}
+			assertTrueE(t, isResumable(meta))
+
+			assertNilF(t, new(remoteStorageUtil).downloadOneFile(context.Background(), meta))
+			assertEqualE(t, meta.resStatus, downloaded)
+			assertDeepEqualE(t, tc.mock.ranges, tc.ranges)
+			downloaded, err := os.ReadFile(filepath.Join(localLocation, "data.bin"))
+			assertNilF(t, err)
+			assertTrueE(t, bytes.Equal(downloaded, tc.expected), "downloaded file should match the object")
+			checkpoints, err := os.ReadDir(checkpointDir)
+			assertNilF(t, err)
+			assertEqualE(t, len(checkpoints), 0)
+		})
+	}
+}
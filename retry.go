@@ -376,6 +376,7 @@ func (r *retryHTTP) execute() (res *http.Response, err error) {
 			retryReason = res.StatusCode
 		}
 		r.fullURL = retryReasonUpdater.replaceOrAdd(retryReason)
+		instrumentationFromContext(r.ctx).recordRetry(r.ctx, retryReason)
 		r.fullURL = ensureClientStartTimeIsSet(r.fullURL, clientStartTime)
 		logger.WithContext(r.ctx).Debugf("sleeping %v. to timeout: %v. retrying", sleepTime, totalTimeout)
 		logger.WithContext(r.ctx).Debugf("retry count: %v, retry reason: %v", retryCounter, retryReason)
@@ -84,6 +84,8 @@ func (rows *snowflakeRows) Close() (err error) {
 	logger.WithContext(rows.sc.ctx).Debug("Rows.Close")
 	if scd, ok := rows.ChunkDownloader.(*snowflakeChunkDownloader); ok {
 		scd.releaseRawArrowBatches()
+		scd.closeChunkMemory()
+		scd.releaseColumnRecords()
 	}
 	return nil
 }
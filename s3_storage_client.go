@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/logging"
 	"io"
@@ -25,6 +26,7 @@ const (
 	amzIv      = "x-amz-iv"
 
 	notFound             = "NotFound"
+	noSuchUpload         = "NoSuchUpload"
 	expiredToken         = "ExpiredToken"
 	errNoWsaeconnaborted = "10053"
 )
@@ -179,6 +181,14 @@ type s3UploadAPI interface {
 	Upload(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*manager.Uploader)) (*manager.UploadOutput, error)
 }
 
+// used by resumable transfers, which track the parts and ranges themselves
+type s3ObjectAPI interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+}
+
 // cloudUtil implementation
 func (util *snowflakeS3Client) uploadFile(
 	ctx context.Context,
@@ -218,18 +228,57 @@ func (util *snowflakeS3Client) uploadFile(
 		uploader = meta.mockUploader
 	}
 
-	_, err = withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (any, error) {
+	if meta.resumable != nil {
+		var objectAPI s3ObjectAPI = client
+		// for testing only
+		if meta.mockObjectAPI != nil {
+			objectAPI = meta.mockObjectAPI
+		}
+		err = util.uploadFileInParts(ctx, objectAPI, dataFile, meta, s3loc.bucketName, s3path, s3Meta, maxConcurrency, multiPartThreshold)
+	} else {
+		_, err = util.uploadWithUploader(ctx, uploader, dataFile, meta, s3loc.bucketName, s3path, s3Meta)
+	}
+
+	if err != nil {
+		var ae smithy.APIError
+		if errors.As(err, &ae) {
+			if ae.ErrorCode() == expiredToken {
+				meta.resStatus = renewToken
+				return err
+			} else if strings.Contains(ae.ErrorCode(), errNoWsaeconnaborted) {
+				meta.lastError = err
+				meta.resStatus = needRetryWithLowerConcurrency
+				return err
+			}
+		}
+		meta.lastError = err
+		meta.resStatus = needRetry
+		return fmt.Errorf("error while uploading file. %w", err)
+	}
+	meta.dstFileSize = meta.uploadSize
+	meta.resStatus = uploaded
+	return nil
+}
+
+func (util *snowflakeS3Client) uploadWithUploader(
+	ctx context.Context,
+	uploader s3UploadAPI,
+	dataFile string,
+	meta *fileMetadata,
+	bucketName string,
+	s3path string,
+	s3Meta map[string]string) (any, error) {
+	return withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (any, error) {
 		if meta.srcStream != nil {
 			uploadStream := cmp.Or(meta.realSrcStream, meta.srcStream)
 			return uploader.Upload(ctx, &s3.PutObjectInput{
-				Bucket:   &s3loc.bucketName,
+				Bucket:   &bucketName,
 				Key:      &s3path,
 				Body:     bytes.NewBuffer(uploadStream.Bytes()),
 				Metadata: s3Meta,
 			})
 		}
-		var file *os.File
-		file, err = os.Open(dataFile)
+		file, err := os.Open(dataFile)
 		if err != nil {
 			return nil, err
 		}
@@ -239,32 +288,106 @@ func (util *snowflakeS3Client) uploadFile(
 			}
 		}()
 		return uploader.Upload(ctx, &s3.PutObjectInput{
-			Bucket:   &s3loc.bucketName,
+			Bucket:   &bucketName,
 			Key:      &s3path,
 			Body:     file,
 			Metadata: s3Meta,
 		})
+	})
+}
 
+// uploadFileInParts uploads dataFile with a multipart upload recorded in the checkpoint of meta,
+// so an interrupted upload continues with the parts not uploaded yet.
+func (util *snowflakeS3Client) uploadFileInParts(
+	ctx context.Context,
+	client s3ObjectAPI,
+	dataFile string,
+	meta *fileMetadata,
+	bucketName string,
+	s3path string,
+	s3Meta map[string]string,
+	maxConcurrency int,
+	multiPartThreshold int64) error {
+	file, err := os.Open(dataFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = file.Close(); err != nil {
+			logger.Warnf("failed to close %v file: %v", dataFile, err)
+		}
+	}()
+
+	rt := meta.resumable
+	partSize := rt.partSize(meta.uploadSize, multiPartThreshold)
+	uploadID := rt.checkpoint().UploadID
+	if uploadID == "" {
+		output, err := withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (*s3.CreateMultipartUploadOutput, error) {
+			return client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+				Bucket:   &bucketName,
+				Key:      &s3path,
+				Metadata: s3Meta,
+			})
+		})
+		if err != nil {
+			return err
+		}
+		uploadID = aws.ToString(output.UploadId)
+		if err = rt.update(func(cp *transferCheckpoint) {
+			cp.UploadID = uploadID
+			cp.PartSize = partSize
+			cp.Parts = nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	parts, err := rt.uploadParts(ctx, meta.uploadSize, partSize, maxConcurrency, func(ctx context.Context, number int, offset, length int64) (string, error) {
+		output, err := withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (*s3.UploadPartOutput, error) {
+			return client.UploadPart(ctx, &s3.UploadPartInput{
+				Bucket:        &bucketName,
+				Key:           &s3path,
+				UploadId:      &uploadID,
+				PartNumber:    aws.Int32(int32(number)),
+				Body:          io.NewSectionReader(file, offset, length),
+				ContentLength: aws.Int64(length),
+			})
+		})
+		if err != nil {
+			return "", err
+		}
+		return aws.ToString(output.ETag), nil
 	})
+	if err != nil {
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == noSuchUpload {
+			rt.resetParts()
+		}
+		return err
+	}
 
+	completedParts := make([]types.CompletedPart, len(parts))
+	for i, part := range parts {
+		completedParts[i] = types.CompletedPart{
+			ETag:       aws.String(part.ID),
+			PartNumber: aws.Int32(int32(part.Number)),
+		}
+	}
+	_, err = withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (*s3.CompleteMultipartUploadOutput, error) {
+		return client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          &bucketName,
+			Key:             &s3path,
+			UploadId:        &uploadID,
+			MultipartUpload: &types.CompletedMultipartUpload{Parts: completedParts},
+		})
+	})
 	if err != nil {
 		var ae smithy.APIError
-		if errors.As(err, &ae) {
-			if ae.ErrorCode() == expiredToken {
-				meta.resStatus = renewToken
-				return err
-			} else if strings.Contains(ae.ErrorCode(), errNoWsaeconnaborted) {
-				meta.lastError = err
-				meta.resStatus = needRetryWithLowerConcurrency
-				return err
-			}
+		if errors.As(err, &ae) && ae.ErrorCode() == noSuchUpload {
+			rt.resetParts()
 		}
-		meta.lastError = err
-		meta.resStatus = needRetry
-		return fmt.Errorf("error while uploading file. %w", err)
+		return err
 	}
-	meta.dstFileSize = meta.uploadSize
-	meta.resStatus = uploaded
 	return nil
 }
 
@@ -298,7 +421,32 @@ func (util *snowflakeS3Client) nativeDownloadFile(
 		downloader = meta.mockDownloader
 	}
 
+	var objectAPI s3ObjectAPI = client
+	// for testing only
+	if meta.mockObjectAPI != nil {
+		objectAPI = meta.mockObjectAPI
+	}
+
 	_, err := withCloudStorageTimeout(ctx, util.cfg, func(ctx context.Context) (any, error) {
+		if meta.resumable != nil {
+			return nil, meta.resumable.writeFile(fullDstFileName, meta.srcFileSize, func(w io.Writer, offset int64) error {
+				output, err := objectAPI.GetObject(ctx, &s3.GetObjectInput{
+					Bucket: s3Obj.Bucket,
+					Key:    s3Obj.Key,
+					Range:  aws.String(fmt.Sprintf("bytes=%v-", offset)),
+				})
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if err = output.Body.Close(); err != nil {
+						logger.Warnf("failed to close the S3 object body: %v", err)
+					}
+				}()
+				_, err = io.Copy(w, output.Body)
+				return err
+			})
+		}
 		if isFileGetStream(ctx) {
 			buf := manager.NewWriteAtBuffer([]byte{})
 			if _, err := downloader.Download(ctx, buf, &s3.GetObjectInput{
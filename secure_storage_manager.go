@@ -52,6 +52,10 @@ func (t *secureTokenSpec) buildKey() (string, error) {
 	return buildCredentialsKey(t.host, t.user, t.tokenType)
 }
 
+func (t *secureTokenSpec) cacheKey() CredentialCacheKey {
+	return CredentialCacheKey{Host: t.host, User: t.user, TokenType: string(t.tokenType)}
+}
+
 func newMfaTokenSpec(host, user string) *secureTokenSpec {
 	return &secureTokenSpec{
 		host,
@@ -96,6 +100,14 @@ func newSecureStorageManager() secureStorageManager {
 	return defaultOsSpecificSecureStorageManager()
 }
 
+// credentialsStorageFor returns the storage of the credential cache configured in cfg or the default OS specific one.
+func credentialsStorageFor(cfg *Config) secureStorageManager {
+	if cfg != nil && cfg.CredentialCache != nil {
+		return &credentialCacheSecureStorageManager{cache: cfg.CredentialCache}
+	}
+	return credentialsStorage
+}
+
 type fileBasedSecureStorageManager struct {
 	credDirPath string
 }
@@ -516,3 +528,34 @@ func (ssm *threadSafeSecureStorageManager) deleteCredential(tokenSpec *secureTok
 	defer ssm.mu.Unlock()
 	ssm.delegate.deleteCredential(tokenSpec)
 }
+
+// credentialCacheSecureStorageManager adapts a user provided CredentialCache. Like the other managers it only logs errors,
+// a failing cache must not fail the authentication.
+type credentialCacheSecureStorageManager struct {
+	cache CredentialCache
+}
+
+func (ssm *credentialCacheSecureStorageManager) setCredential(tokenSpec *secureTokenSpec, value string) {
+	if value == "" {
+		logger.Debug("no token provided")
+		return
+	}
+	if err := ssm.cache.Set(tokenSpec.cacheKey(), value); err != nil {
+		logger.Warnf("Set credential failed. Authentication type: %v, User: %v. %v", tokenSpec.tokenType, tokenSpec.user, err)
+	}
+}
+
+func (ssm *credentialCacheSecureStorageManager) getCredential(tokenSpec *secureTokenSpec) string {
+	value, err := ssm.cache.Get(tokenSpec.cacheKey())
+	if err != nil {
+		logger.Warnf("Get credential failed. Authentication type: %v, User: %v. %v", tokenSpec.tokenType, tokenSpec.user, err)
+		return ""
+	}
+	return value
+}
+
+func (ssm *credentialCacheSecureStorageManager) deleteCredential(tokenSpec *secureTokenSpec) {
+	if err := ssm.cache.Delete(tokenSpec.cacheKey()); err != nil {
+		logger.Warnf("Delete credential failed. Authentication type: %v, User: %v. %v", tokenSpec.tokenType, tokenSpec.user, err)
+	}
+}
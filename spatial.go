@@ -0,0 +1,748 @@
+package gosnowflake
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// geographySRID is the SRID of all GEOGRAPHY values, i.e. WGS 84.
+const geographySRID = 4326
+
+const (
+	ewkbZFlag    = 0x80000000
+	ewkbMFlag    = 0x40000000
+	ewkbSRIDFlag = 0x20000000
+)
+
+// ShapeType is the type of the spatial object, named like in GeoJSON.
+type ShapeType string
+
+const (
+	// ShapePoint is a single position.
+	ShapePoint ShapeType = "Point"
+	// ShapeLineString is a line through the positions.
+	ShapeLineString ShapeType = "LineString"
+	// ShapePolygon is an area bounded by the exterior ring and optional interior rings (holes).
+	ShapePolygon ShapeType = "Polygon"
+	// ShapeMultiPoint is a collection of points.
+	ShapeMultiPoint ShapeType = "MultiPoint"
+	// ShapeMultiLineString is a collection of lines.
+	ShapeMultiLineString ShapeType = "MultiLineString"
+	// ShapeMultiPolygon is a collection of polygons.
+	ShapeMultiPolygon ShapeType = "MultiPolygon"
+	// ShapeGeometryCollection is a collection of shapes of any type.
+	ShapeGeometryCollection ShapeType = "GeometryCollection"
+)
+
+var shapeTypeCodes = map[ShapeType]uint32{
+	ShapePoint:              1,
+	ShapeLineString:         2,
+	ShapePolygon:            3,
+	ShapeMultiPoint:         4,
+	ShapeMultiLineString:    5,
+	ShapeMultiPolygon:       6,
+	ShapeGeometryCollection: 7,
+}
+
+func shapeTypeByCode(code uint32) (ShapeType, bool) {
+	for shapeType, c := range shapeTypeCodes {
+		if c == code {
+			return shapeType, true
+		}
+	}
+	return "", false
+}
+
+// Position is the x (longitude), y (latitude) and the optional z coordinate of a point.
+type Position []float64
+
+// Shape is a spatial object. Only the field matching the type is set.
+type Shape struct {
+	Type ShapeType
+	// Points are the position of a Point (none for POINT EMPTY), the points of a MultiPoint or the vertices of a LineString.
+	Points []Position
+	// Lines are the rings of a Polygon, the first one being the exterior ring, or the lines of a MultiLineString.
+	Lines [][]Position
+	// Polygons are the rings of the polygons of a MultiPolygon.
+	Polygons [][][]Position
+	// Shapes are the members of a GeometryCollection.
+	Shapes []Shape
+}
+
+// Geography is a GEOGRAPHY value. Scan decodes all GEOGRAPHY_OUTPUT_FORMAT values: GeoJSON, WKT, EWKT, WKB and EWKB.
+// Value returns the WKT of the shape, so Geography can be bound to insert or compare GEOGRAPHY values.
+type Geography struct {
+	Shape Shape
+	SRID  int  // always 4326 (WGS 84)
+	Valid bool // false if the value is NULL
+}
+
+// Geometry is a GEOMETRY value. Scan decodes all GEOMETRY_OUTPUT_FORMAT values: GeoJSON, WKT, EWKT, WKB and EWKB.
+// Value returns the EWKT of the shape if the SRID is set and the WKT otherwise.
+type Geometry struct {
+	Shape Shape
+	SRID  int  // 0 unless the value is read in EWKT or EWKB format or set before binding
+	Valid bool // false if the value is NULL
+}
+
+// Scan implements sql.Scanner.
+func (g *Geography) Scan(src any) error {
+	if src == nil {
+		*g = Geography{}
+		return nil
+	}
+	shape, _, err := parseSpatial(src)
+	if err != nil {
+		return err
+	}
+	*g = Geography{Shape: shape, SRID: geographySRID, Valid: true}
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (g Geography) Value() (driver.Value, error) {
+	if !g.Valid {
+		return nil, nil
+	}
+	return g.Shape.WKT(), nil
+}
+
+// Scan implements sql.Scanner.
+func (g *Geometry) Scan(src any) error {
+	if src == nil {
+		*g = Geometry{}
+		return nil
+	}
+	shape, srid, err := parseSpatial(src)
+	if err != nil {
+		return err
+	}
+	*g = Geometry{Shape: shape, SRID: srid, Valid: true}
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (g Geometry) Value() (driver.Value, error) {
+	if !g.Valid {
+		return nil, nil
+	}
+	if g.SRID != 0 {
+		return "SRID=" + strconv.Itoa(g.SRID) + ";" + g.Shape.WKT(), nil
+	}
+	return g.Shape.WKT(), nil
+}
+
+// parseSpatial detects the format of the spatial value and decodes it. The SRID is returned for EWKT and EWKB values.
+func parseSpatial(src any) (Shape, int, error) {
+	var text string
+	switch v := src.(type) {
+	case []byte:
+		if len(v) > 0 && (v[0] == 0 || v[0] == 1) {
+			return parseWKB(v)
+		}
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return Shape{}, 0, fmt.Errorf("cannot convert %T to a spatial value", src)
+	}
+	text = strings.TrimSpace(text)
+	switch {
+	case strings.HasPrefix(text, "{"):
+		shape, err := parseGeoJSON([]byte(text))
+		return shape, 0, err
+	case isHexWKB(text):
+		b, err := hex.DecodeString(text)
+		if err != nil {
+			return Shape{}, 0, err
+		}
+		return parseWKB(b)
+	}
+	return parseWKT(text)
+}
+
+// isHexWKB reports whether the text is hex encoded WKB, which starts with the byte order 00 or 01.
+// WKT never starts with a digit.
+func isHexWKB(text string) bool {
+	if len(text) < 10 || len(text)%2 != 0 || (!strings.HasPrefix(text, "00") && !strings.HasPrefix(text, "01")) {
+		return false
+	}
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') && !(c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+type geoJSONShape struct {
+	Type        ShapeType         `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+	Geometries  []json.RawMessage `json:"geometries"`
+}
+
+func parseGeoJSON(data []byte) (Shape, error) {
+	var g geoJSONShape
+	if err := json.Unmarshal(data, &g); err != nil {
+		return Shape{}, err
+	}
+	shape := Shape{Type: g.Type}
+	var err error
+	switch g.Type {
+	case ShapePoint:
+		var p Position
+		if err = json.Unmarshal(g.Coordinates, &p); err == nil && len(p) > 0 {
+			shape.Points = []Position{p}
+		}
+	case ShapeLineString, ShapeMultiPoint:
+		err = json.Unmarshal(g.Coordinates, &shape.Points)
+	case ShapePolygon, ShapeMultiLineString:
+		err = json.Unmarshal(g.Coordinates, &shape.Lines)
+	case ShapeMultiPolygon:
+		err = json.Unmarshal(g.Coordinates, &shape.Polygons)
+	case ShapeGeometryCollection:
+		for _, member := range g.Geometries {
+			s, err := parseGeoJSON(member)
+			if err != nil {
+				return Shape{}, err
+			}
+			shape.Shapes = append(shape.Shapes, s)
+		}
+	default:
+		return Shape{}, fmt.Errorf("unsupported GeoJSON type: %v", g.Type)
+	}
+	return shape, err
+}
+
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func parseWKB(data []byte) (Shape, int, error) {
+	r := &wkbReader{data: data}
+	shape, srid, err := r.readShape()
+	if err != nil {
+		return Shape{}, 0, err
+	}
+	if r.pos != len(data) {
+		return Shape{}, 0, fmt.Errorf("unexpected %v bytes after the WKB value", len(data)-r.pos)
+	}
+	return shape, srid, nil
+}
+
+func (r *wkbReader) need(n int) error {
+	if n < 0 || len(r.data)-r.pos < n {
+		return errors.New("unexpected end of the WKB value")
+	}
+	return nil
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if err := r.need(4); err != nil {
+		return 0, err
+	}
+	v := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+// readShape reads the shape of the WKB or EWKB (PostGIS) format, in which the Z, M and SRID flags are set in the type.
+func (r *wkbReader) readShape() (Shape, int, error) {
+	if err := r.need(1); err != nil {
+		return Shape{}, 0, err
+	}
+	switch r.data[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return Shape{}, 0, fmt.Errorf("invalid WKB byte order: %v", r.data[r.pos])
+	}
+	r.pos++
+	code, err := r.readUint32()
+	if err != nil {
+		return Shape{}, 0, err
+	}
+	srid := 0
+	if code&ewkbSRIDFlag != 0 {
+		v, err := r.readUint32()
+		if err != nil {
+			return Shape{}, 0, err
+		}
+		srid = int(v)
+	}
+	hasZ := code&ewkbZFlag != 0 || (code&0xffff)/1000 == 1 || (code&0xffff)/1000 == 3
+	hasM := code&ewkbMFlag != 0 || (code&0xffff)/1000 == 2 || (code&0xffff)/1000 == 3
+	dims := 2
+	if hasZ {
+		dims++
+	}
+	if hasM {
+		dims++
+	}
+
+	shapeType, ok := shapeTypeByCode((code & 0xffff) % 1000)
+	if !ok {
+		return Shape{}, 0, fmt.Errorf("unsupported WKB type: %v", code)
+	}
+	shape := Shape{Type: shapeType}
+	switch shapeType {
+	case ShapePoint:
+		p, err := r.readPosition(dims, hasZ)
+		if err != nil {
+			return Shape{}, 0, err
+		}
+		// POINT EMPTY is encoded with NaN coordinates
+		if !math.IsNaN(p[0]) || !math.IsNaN(p[1]) {
+			shape.Points = []Position{p}
+		}
+	case ShapeLineString:
+		shape.Points, err = r.readPositions(dims, hasZ)
+	case ShapePolygon:
+		shape.Lines, err = r.readRings(dims, hasZ)
+	default:
+		err = r.readMembers(&shape)
+	}
+	return shape, srid, err
+}
+
+func (r *wkbReader) readPosition(dims int, hasZ bool) (Position, error) {
+	if err := r.need(8 * dims); err != nil {
+		return nil, err
+	}
+	p := make(Position, 0, 3)
+	for i := range dims {
+		v := math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+		r.pos += 8
+		// the M coordinate is not kept
+		if i < 2 || (i == 2 && hasZ) {
+			p = append(p, v)
+		}
+	}
+	return p, nil
+}
+
+func (r *wkbReader) readPositions(dims int, hasZ bool) ([]Position, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.need(int(n) * 8 * dims); err != nil {
+		return nil, err
+	}
+	positions := make([]Position, n)
+	for i := range positions {
+		if positions[i], err = r.readPosition(dims, hasZ); err != nil {
+			return nil, err
+		}
+	}
+	return positions, nil
+}
+
+func (r *wkbReader) readRings(dims int, hasZ bool) ([][]Position, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.need(int(n) * 4); err != nil {
+		return nil, err
+	}
+	rings := make([][]Position, n)
+	for i := range rings {
+		if rings[i], err = r.readPositions(dims, hasZ); err != nil {
+			return nil, err
+		}
+	}
+	return rings, nil
+}
+
+// readMembers reads the members of a multi shape or a collection, each of them being a WKB shape with its own byte order.
+func (r *wkbReader) readMembers(shape *Shape) error {
+	n, err := r.readUint32()
+	if err != nil {
+		return err
+	}
+	if err = r.need(int(n) * 5); err != nil {
+		return err
+	}
+	for range n {
+		member, _, err := r.readShape()
+		if err != nil {
+			return err
+		}
+		switch {
+		case shape.Type == ShapeMultiPoint && member.Type == ShapePoint:
+			shape.Points = append(shape.Points, member.Points...)
+		case shape.Type == ShapeMultiLineString && member.Type == ShapeLineString:
+			shape.Lines = append(shape.Lines, member.Points)
+		case shape.Type == ShapeMultiPolygon && member.Type == ShapePolygon:
+			shape.Polygons = append(shape.Polygons, member.Lines)
+		case shape.Type == ShapeGeometryCollection:
+			shape.Shapes = append(shape.Shapes, member)
+		default:
+			return fmt.Errorf("unexpected %v in WKB %v", member.Type, shape.Type)
+		}
+	}
+	return nil
+}
+
+type wktParser struct {
+	text string
+	pos  int
+}
+
+// parseWKT parses the WKT or EWKT (SRID=4326;POINT(1 2)) value.
+func parseWKT(text string) (Shape, int, error) {
+	srid := 0
+	if len(text) > 5 && strings.EqualFold(text[:5], "SRID=") {
+		end := strings.IndexByte(text, ';')
+		if end < 0 {
+			return Shape{}, 0, fmt.Errorf("invalid EWKT value: %v", text)
+		}
+		var err error
+		if srid, err = strconv.Atoi(text[5:end]); err != nil {
+			return Shape{}, 0, fmt.Errorf("invalid SRID of EWKT value: %v", text)
+		}
+		text = text[end+1:]
+	}
+	p := &wktParser{text: text}
+	shape, err := p.parseShape()
+	if err != nil {
+		return Shape{}, 0, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.text) {
+		return Shape{}, 0, fmt.Errorf("unexpected %q after the WKT value", p.text[p.pos:])
+	}
+	return shape, srid, nil
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.text) && strings.IndexByte(" \t\r\n", p.text[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *wktParser) peek() byte {
+	p.skipSpaces()
+	if p.pos < len(p.text) {
+		return p.text[p.pos]
+	}
+	return 0
+}
+
+func (p *wktParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.text) && (p.text[p.pos] >= 'A' && p.text[p.pos] <= 'Z' || p.text[p.pos] >= 'a' && p.text[p.pos] <= 'z') {
+		p.pos++
+	}
+	return strings.ToUpper(p.text[start:p.pos])
+}
+
+func (p *wktParser) expect(c byte) error {
+	if p.peek() != c {
+		return fmt.Errorf("expected %q at position %v of WKT value %v", c, p.pos, p.text)
+	}
+	p.pos++
+	return nil
+}
+
+// list parses the comma separated items in parentheses.
+func (p *wktParser) list(item func() error) error {
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		if p.peek() != ',' {
+			return p.expect(')')
+		}
+		p.pos++
+	}
+}
+
+func (p *wktParser) position(hasZ, hasM bool) (Position, error) {
+	var values []float64
+	for {
+		p.skipSpaces()
+		start := p.pos
+		for p.pos < len(p.text) && strings.IndexByte("+-.0123456789eE", p.text[p.pos]) >= 0 {
+			p.pos++
+		}
+		if start == p.pos {
+			break
+		}
+		v, err := strconv.ParseFloat(p.text[start:p.pos], 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	if len(values) < 2 {
+		return nil, fmt.Errorf("invalid position at position %v of WKT value %v", p.pos, p.text)
+	}
+	// the M coordinate is not kept
+	if len(values) >= 3 && (hasZ || !hasM) {
+		return Position(values[:3]), nil
+	}
+	return Position(values[:2]), nil
+}
+
+func (p *wktParser) positions(hasZ, hasM bool) ([]Position, error) {
+	var positions []Position
+	err := p.list(func() error {
+		pos, err := p.position(hasZ, hasM)
+		positions = append(positions, pos)
+		return err
+	})
+	return positions, err
+}
+
+func (p *wktParser) rings(hasZ, hasM bool) ([][]Position, error) {
+	var rings [][]Position
+	err := p.list(func() error {
+		ring, err := p.positions(hasZ, hasM)
+		rings = append(rings, ring)
+		return err
+	})
+	return rings, err
+}
+
+func (p *wktParser) parseShape() (Shape, error) {
+	typeName := p.word()
+	var shape Shape
+	for shapeType := range shapeTypeCodes {
+		if strings.ToUpper(string(shapeType)) == typeName {
+			shape.Type = shapeType
+		}
+	}
+	if shape.Type == "" {
+		return Shape{}, fmt.Errorf("unsupported WKT type: %q", typeName)
+	}
+	var hasZ, hasM bool
+	if p.peek() != '(' {
+		switch p.word() {
+		case "Z":
+			hasZ = true
+		case "M":
+			hasM = true
+		case "ZM":
+			hasZ, hasM = true, true
+		case "EMPTY":
+			return shape, nil
+		default:
+			return Shape{}, fmt.Errorf("invalid WKT value: %v", p.text)
+		}
+		if p.peek() != '(' {
+			if p.word() == "EMPTY" {
+				return shape, nil
+			}
+			return Shape{}, fmt.Errorf("invalid WKT value: %v", p.text)
+		}
+	}
+
+	var err error
+	switch shape.Type {
+	case ShapePoint:
+		if shape.Points, err = p.positions(hasZ, hasM); err == nil && len(shape.Points) != 1 {
+			err = fmt.Errorf("invalid WKT point: %v", p.text)
+		}
+	case ShapeLineString:
+		shape.Points, err = p.positions(hasZ, hasM)
+	case ShapeMultiPoint:
+		// the points may be in parentheses, i.e. MULTIPOINT((1 2), (3 4)) or MULTIPOINT(1 2, 3 4)
+		err = p.list(func() error {
+			if p.peek() == '(' {
+				points, err := p.positions(hasZ, hasM)
+				shape.Points = append(shape.Points, points...)
+				return err
+			}
+			pos, err := p.position(hasZ, hasM)
+			shape.Points = append(shape.Points, pos)
+			return err
+		})
+	case ShapePolygon, ShapeMultiLineString:
+		shape.Lines, err = p.rings(hasZ, hasM)
+	case ShapeMultiPolygon:
+		err = p.list(func() error {
+			rings, err := p.rings(hasZ, hasM)
+			shape.Polygons = append(shape.Polygons, rings)
+			return err
+		})
+	case ShapeGeometryCollection:
+		err = p.list(func() error {
+			member, err := p.parseShape()
+			shape.Shapes = append(shape.Shapes, member)
+			return err
+		})
+	}
+	if err != nil {
+		return Shape{}, err
+	}
+	return shape, nil
+}
+
+func (s Shape) isEmpty() bool {
+	return len(s.Points) == 0 && len(s.Lines) == 0 && len(s.Polygons) == 0 && len(s.Shapes) == 0
+}
+
+// dimensions returns 3 if the positions of the shape have the z coordinate and 2 otherwise.
+func (s Shape) dimensions() int {
+	var first Position
+	switch {
+	case len(s.Points) > 0:
+		first = s.Points[0]
+	case len(s.Lines) > 0 && len(s.Lines[0]) > 0:
+		first = s.Lines[0][0]
+	case len(s.Polygons) > 0 && len(s.Polygons[0]) > 0 && len(s.Polygons[0][0]) > 0:
+		first = s.Polygons[0][0][0]
+	}
+	if len(first) >= 3 {
+		return 3
+	}
+	return 2
+}
+
+// WKT returns the Well-Known Text of the shape, e.g. POINT(-122.35 37.55).
+func (s Shape) WKT() string {
+	var b strings.Builder
+	s.writeWKT(&b)
+	return b.String()
+}
+
+func (s Shape) writeWKT(b *strings.Builder) {
+	b.WriteString(strings.ToUpper(string(s.Type)))
+	dims := s.dimensions()
+	if dims == 3 {
+		b.WriteString(" Z ")
+	}
+	if s.isEmpty() {
+		b.WriteString(" EMPTY")
+		return
+	}
+	writeList := func(n int, item func(i int)) {
+		b.WriteByte('(')
+		for i := range n {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			item(i)
+		}
+		b.WriteByte(')')
+	}
+	writePositions := func(positions []Position) {
+		writeList(len(positions), func(i int) {
+			for j := range dims {
+				if j > 0 {
+					b.WriteByte(' ')
+				}
+				b.WriteString(strconv.FormatFloat(coordinate(positions[i], j), 'f', -1, 64))
+			}
+		})
+	}
+	writeRings := func(rings [][]Position) {
+		writeList(len(rings), func(i int) { writePositions(rings[i]) })
+	}
+
+	switch s.Type {
+	case ShapePoint, ShapeLineString:
+		writePositions(s.Points)
+	case ShapeMultiPoint:
+		writeList(len(s.Points), func(i int) { writePositions(s.Points[i : i+1]) })
+	case ShapePolygon, ShapeMultiLineString:
+		writeRings(s.Lines)
+	case ShapeMultiPolygon:
+		writeList(len(s.Polygons), func(i int) { writeRings(s.Polygons[i]) })
+	case ShapeGeometryCollection:
+		writeList(len(s.Shapes), func(i int) { s.Shapes[i].writeWKT(b) })
+	}
+}
+
+// WKB returns the little-endian Well-Known Binary of the shape. The positions with the z coordinate are encoded in the ISO WKB format.
+func (s Shape) WKB() []byte {
+	return s.appendWKB(nil)
+}
+
+func (s Shape) appendWKB(b []byte) []byte {
+	dims := s.dimensions()
+	code := shapeTypeCodes[s.Type]
+	if dims == 3 {
+		code += 1000
+	}
+	b = append(b, 1)
+	b = binary.LittleEndian.AppendUint32(b, code)
+	appendPosition := func(b []byte, p Position) []byte {
+		for j := range dims {
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(coordinate(p, j)))
+		}
+		return b
+	}
+	appendPositions := func(b []byte, positions []Position) []byte {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(positions)))
+		for _, p := range positions {
+			b = appendPosition(b, p)
+		}
+		return b
+	}
+	appendRings := func(b []byte, rings [][]Position) []byte {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(rings)))
+		for _, ring := range rings {
+			b = appendPositions(b, ring)
+		}
+		return b
+	}
+
+	switch s.Type {
+	case ShapePoint:
+		if len(s.Points) == 0 {
+			return appendPosition(b, Position{math.NaN(), math.NaN(), math.NaN()})
+		}
+		return appendPosition(b, s.Points[0])
+	case ShapeLineString:
+		return appendPositions(b, s.Points)
+	case ShapePolygon:
+		return appendRings(b, s.Lines)
+	case ShapeMultiPoint:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(s.Points)))
+		for _, p := range s.Points {
+			b = Shape{Type: ShapePoint, Points: []Position{p}}.appendWKB(b)
+		}
+	case ShapeMultiLineString:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(s.Lines)))
+		for _, line := range s.Lines {
+			b = Shape{Type: ShapeLineString, Points: line}.appendWKB(b)
+		}
+	case ShapeMultiPolygon:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(s.Polygons)))
+		for _, polygon := range s.Polygons {
+			b = Shape{Type: ShapePolygon, Lines: polygon}.appendWKB(b)
+		}
+	case ShapeGeometryCollection:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(s.Shapes)))
+		for _, member := range s.Shapes {
+			b = member.appendWKB(b)
+		}
+	}
+	return b
+}
+
+func coordinate(p Position, i int) float64 {
+	if i < len(p) {
+		return p[i]
+	}
+	return 0
+}
@@ -0,0 +1,119 @@
+package gosnowflake
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"testing"
+)
+
+func TestGeographyScan(t *testing.T) {
+	polygon := Shape{Type: ShapePolygon, Lines: [][]Position{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}
+	// big-endian WKB of POINT(1 2)
+	pointWKBBigEndian := "00000000013ff00000000000004000000000000000"
+	testcases := []struct {
+		name     string
+		src      any
+		expected Shape
+	}{
+		{name: "GeoJSON point", src: `{"coordinates": [-122.35, 37.55], "type": "Point"}`, expected: Shape{Type: ShapePoint, Points: []Position{{-122.35, 37.55}}}},
+		{name: "GeoJSON polygon", src: `{"coordinates": [[[0,0],[1,0],[1,1],[0,0]]], "type": "Polygon"}`, expected: polygon},
+		{name: "GeoJSON collection", src: `{"geometries": [{"coordinates": [1, 2], "type": "Point"}, {"coordinates": [[1, 2], [3, 4]], "type": "LineString"}], "type": "GeometryCollection"}`,
+			expected: Shape{Type: ShapeGeometryCollection, Shapes: []Shape{
+				{Type: ShapePoint, Points: []Position{{1, 2}}},
+				{Type: ShapeLineString, Points: []Position{{1, 2}, {3, 4}}},
+			}}},
+		{name: "WKT point", src: "POINT(-122.35 37.55)", expected: Shape{Type: ShapePoint, Points: []Position{{-122.35, 37.55}}}},
+		{name: "WKT point Z", src: "POINT Z (1 2 3)", expected: Shape{Type: ShapePoint, Points: []Position{{1, 2, 3}}}},
+		{name: "WKT point M", src: "POINT M (1 2 3)", expected: Shape{Type: ShapePoint, Points: []Position{{1, 2}}}},
+		{name: "WKT polygon", src: "POLYGON((0 0, 1 0, 1 1, 0 0))", expected: polygon},
+		{name: "WKT multipoint", src: "MULTIPOINT((1 2), 3 4)", expected: Shape{Type: ShapeMultiPoint, Points: []Position{{1, 2}, {3, 4}}}},
+		{name: "WKT multipolygon", src: "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((0 0,1 0,1 1,0 0)))",
+			expected: Shape{Type: ShapeMultiPolygon, Polygons: [][][]Position{polygon.Lines, polygon.Lines}}},
+		{name: "WKT empty", src: "LINESTRING EMPTY", expected: Shape{Type: ShapeLineString}},
+		{name: "EWKT", src: "SRID=4326;POINT(-122.35 37.55)", expected: Shape{Type: ShapePoint, Points: []Position{{-122.35, 37.55}}}},
+		{name: "WKB big-endian hex", src: pointWKBBigEndian, expected: Shape{Type: ShapePoint, Points: []Position{{1, 2}}}},
+		{name: "WKB", src: polygon.WKB(), expected: polygon},
+		{name: "WKB hex", src: hex.EncodeToString(polygon.WKB()), expected: polygon},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var g Geography
+			assertNilF(t, g.Scan(tc.src))
+			assertTrueE(t, g.Valid)
+			assertEqualE(t, g.SRID, 4326)
+			assertDeepEqualE(t, g.Shape, tc.expected)
+		})
+	}
+
+	var g Geography
+	assertNilF(t, g.Scan(nil))
+	assertFalseE(t, g.Valid)
+	assertNotNilE(t, g.Scan("POINT(1)"))
+	assertNotNilE(t, g.Scan(`{"type": "Feature"}`))
+	assertNotNilE(t, g.Scan(polygon.WKB()[:20]))
+}
+
+func TestGeometryScanSRID(t *testing.T) {
+	var g Geometry
+	assertNilF(t, g.Scan("SRID=3857;POINT(1 2)"))
+	assertEqualE(t, g.SRID, 3857)
+
+	// EWKB of SRID=3857;POINT(1 2)
+	ewkb, err := hex.DecodeString("0101000020110f0000000000000000f03f0000000000000040")
+	assertNilF(t, err)
+	assertNilF(t, g.Scan(ewkb))
+	assertEqualE(t, g.SRID, 3857)
+	assertDeepEqualE(t, g.Shape, Shape{Type: ShapePoint, Points: []Position{{1, 2}}})
+
+	value, err := g.Value()
+	assertNilF(t, err)
+	assertEqualE(t, value, driver.Value("SRID=3857;POINT(1 2)"))
+
+	assertNilF(t, g.Scan("POINT(1 2)"))
+	assertEqualE(t, g.SRID, 0)
+}
+
+func TestShapeWKTAndWKB(t *testing.T) {
+	testcases := []string{
+		"POINT(-122.35 37.55)",
+		"POINT Z (1 2 3)",
+		"POINT EMPTY",
+		"LINESTRING(0 0,1 1,2 0.5)",
+		"POLYGON((0 0,10 0,10 10,0 0),(1 1,2 1,2 2,1 1))",
+		"MULTIPOINT((1 2),(3 4))",
+		"MULTILINESTRING((0 0,1 1),(2 2,3 3))",
+		"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))",
+		"GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(1 2,3 4))",
+	}
+	for _, wkt := range testcases {
+		t.Run(wkt, func(t *testing.T) {
+			shape, _, err := parseWKT(wkt)
+			assertNilF(t, err)
+			assertEqualE(t, shape.WKT(), wkt)
+			fromWKB, _, err := parseWKB(shape.WKB())
+			assertNilF(t, err)
+			assertEqualE(t, fromWKB.WKT(), wkt)
+		})
+	}
+}
+
+func TestSpatialBindValues(t *testing.T) {
+	wkb := Shape{Type: ShapePoint, Points: []Position{{1, 2}}}.WKB()
+	bindValues, err := getBindValues([]driver.NamedValue{
+		{Ordinal: 1, Value: DataTypeGeography},
+		{Ordinal: 2, Value: wkb},
+		{Ordinal: 3, Value: "POINT(1 2)"},
+	}, nil)
+	assertNilF(t, err)
+	assertEqualE(t, bindValues["1"].Type, "TEXT")
+	assertEqualE(t, *bindValues["1"].Value.(*string), hex.EncodeToString(wkb))
+	assertEqualE(t, bindValues["2"].Type, "TEXT")
+	assertEqualE(t, *bindValues["2"].Value.(*string), "POINT(1 2)")
+
+	value, err := Geography{Shape: Shape{Type: ShapePoint, Points: []Position{{1.5, -2}}}, Valid: true}.Value()
+	assertNilF(t, err)
+	assertEqualE(t, value, driver.Value("POINT(1.5 -2)"))
+	value, err = Geography{}.Value()
+	assertNilF(t, err)
+	assertNilE(t, value)
+}
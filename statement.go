@@ -5,18 +5,116 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/snowflakedb/gosnowflake/v2/internal/query"
 )
 
+// multiStatementCountMismatchCode is returned by the server when a describe request
+// contains more statements than the (implicit) statement count of one.
+const multiStatementCountMismatchCode = 8
+
 // SnowflakeStmt represents the prepared statement in driver.
 type SnowflakeStmt interface {
 	GetQueryID() string
 }
 
+// SnowflakeStmtMetadata is implemented by the prepared statements of the driver and provides
+// the metadata returned by the server when the statement was described.
+type SnowflakeStmtMetadata interface {
+	// ColumnTypes returns the result set metadata reported by the server when the statement was prepared.
+	// It is empty if the statement does not return rows or could not be described (PUT/GET and multi-statement queries).
+	ColumnTypes() []ColumnMetadata
+	// BindTypes returns the bind parameter metadata reported by the server when the statement was prepared.
+	BindTypes() []ColumnMetadata
+}
+
+// ColumnMetadata describes a result column or a bind parameter of a prepared statement.
+type ColumnMetadata struct {
+	Name      string
+	Type      string // Snowflake type name in upper case, e.g. FIXED, TEXT or TIMESTAMP_NTZ
+	Precision int64
+	Scale     int64
+	Length    int64
+	Nullable  bool
+}
+
 type snowflakeStmt struct {
 	sc          *snowflakeConn
 	query       string
 	lastQueryID string
+	numInput    int
+	bindTypes   []query.ExecResponseRowType
+	rowTypes    []query.ExecResponseRowType
+}
+
+// describe sends a describe only request for the statement and caches the number of binds,
+// the bind types and the result set metadata returned by the server.
+func (stmt *snowflakeStmt) describe(ctx context.Context) error {
+	if isFileTransfer(stmt.query) || ctx.Value(multiStatementCount) != nil {
+		logger.WithContext(ctx).Debug("Stmt.describe: skipping describe of PUT/GET or multi-statement query")
+		return nil
+	}
+	// the describe request must not run asynchronously nor consume the query ID channel of the actual execution
+	ctx = WithQueryIDChan(context.WithValue(ctx, asyncMode, false), nil)
+	ctx = setResultType(ctx, queryResultType)
+	data, err := stmt.sc.exec(ctx, stmt.query, false /* noResult */, isInternal(ctx), true /* describeOnly */, nil)
+	if err != nil {
+		var se *SnowflakeError
+		if errors.As(err, &se) && se.Number == multiStatementCountMismatchCode {
+			logger.WithContext(ctx).Debugf("Stmt.describe: multi-statement query cannot be described, queryId: %v", se.QueryID)
+			return nil
+		}
+		logger.WithContext(ctx).Errorf("error: %v", err)
+		if data != nil {
+			code, e := strconv.Atoi(data.Code)
+			if e != nil {
+				return e
+			}
+			return exceptionTelemetry(&SnowflakeError{
+				Number:   code,
+				SQLState: data.Data.SQLState,
+				Message:  err.Error(),
+				QueryID:  data.Data.QueryID,
+			}, stmt.sc)
+		}
+		return err
+	}
+	stmt.numInput = data.Data.NumberOfBinds
+	if _, names := replaceNamedParameters(stmt.query); len(names) > 0 {
+		// :name may be a named parameter bound with sql.Named or a Snowflake Scripting variable,
+		// which is known only when the statement is executed, so database/sql must not check the number of arguments
+		stmt.numInput = -1
+	}
+	stmt.bindTypes = data.Data.MetaDataOfBinds
+	stmt.rowTypes = data.Data.RowType
+	logger.WithContext(ctx).Debugf("Stmt.describe: queryId: %v, number of binds: %v, number of columns: %v", data.Data.QueryID, stmt.numInput, len(stmt.rowTypes))
+	return nil
+}
+
+func (stmt *snowflakeStmt) ColumnTypes() []ColumnMetadata {
+	return toColumnMetadata(stmt.rowTypes)
+}
+
+func (stmt *snowflakeStmt) BindTypes() []ColumnMetadata {
+	return toColumnMetadata(stmt.bindTypes)
+}
+
+func toColumnMetadata(rowTypes []query.ExecResponseRowType) []ColumnMetadata {
+	columns := make([]ColumnMetadata, len(rowTypes))
+	for i, rowType := range rowTypes {
+		columns[i] = ColumnMetadata{
+			Name:      rowType.Name,
+			Type:      strings.ToUpper(rowType.Type),
+			Precision: rowType.Precision,
+			Scale:     rowType.Scale,
+			Length:    rowType.Length,
+			Nullable:  rowType.Nullable,
+		}
+	}
+	return columns
 }
 
 func (stmt *snowflakeStmt) Close() error {
@@ -27,8 +125,8 @@ func (stmt *snowflakeStmt) Close() error {
 
 func (stmt *snowflakeStmt) NumInput() int {
 	logger.WithContext(stmt.sc.ctx).Info("Stmt.NumInput")
-	// Go Snowflake doesn't know the number of binding parameters.
-	return -1
+	// -1 if the number of binding parameters is unknown, i.e. the statement was not described.
+	return stmt.numInput
 }
 
 func (stmt *snowflakeStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
@@ -6,12 +6,15 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"testing"
 	"time"
+
+	"github.com/snowflakedb/gosnowflake/v2/internal/query"
 )
 
 func openDB(t *testing.T) *sql.DB {
@@ -176,8 +179,9 @@ func TestFailedQueryIdInSnowflakeError(t *testing.T) {
 
 func TestSetFailedQueryId(t *testing.T) {
 	ctx := context.Background()
-	failingQuery := "SELECTT 1"
-	failingExec := "INSERT 1 INTO NON_EXISTENT_TABLE"
+	// both statements compile, so they pass the describe in PrepareContext and fail during execution
+	failingQuery := "SELECT 1/0"
+	failingExec := "SELECT TO_NUMBER('not a number')"
 
 	runDBTest(t, func(dbt *DBTest) {
 		testcases := []struct {
@@ -245,7 +249,7 @@ func TestAsyncFailQueryId(t *testing.T) {
 	ctx := WithAsyncMode(context.Background())
 	runDBTest(t, func(dbt *DBTest) {
 		err := dbt.conn.Raw(func(x any) error {
-			stmt, err := x.(driver.ConnPrepareContext).PrepareContext(ctx, "SELECTT 1")
+			stmt, err := x.(driver.ConnPrepareContext).PrepareContext(ctx, "SELECT 1/0")
 			if err != nil {
 				t.Error(err)
 			}
@@ -834,3 +838,118 @@ func TestWithQueryTag(t *testing.T) {
 		assertEqualF(t, tag.String, testQueryTag)
 	})
 }
+
+func TestPrepareDescribesStatement(t *testing.T) {
+	postQueryMock := func(_ context.Context, _ *snowflakeRestful, _ *url.Values,
+		_ map[string]string, body []byte, _ time.Duration, _ UUID, _ *Config) (*execResponse, error) {
+		var req execRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, err
+		}
+		assertTrueF(t, req.DescribeOnly, "prepare should send a describe only request")
+		return &execResponse{
+			Data: execResponseData{
+				QueryID:       "describe-query-id",
+				NumberOfBinds: 2,
+				MetaDataOfBinds: []query.ExecResponseRowType{
+					{Name: "1", Type: "fixed", Precision: 38, Nullable: true},
+					{Name: "2", Type: "text", Length: 16777216, Nullable: true},
+				},
+				RowType: []query.ExecResponseRowType{
+					{Name: "ID", Type: "fixed", Precision: 38, Scale: 0},
+					{Name: "NAME", Type: "text", Length: 100, Nullable: true},
+				},
+			},
+			Code:    "0",
+			Success: true,
+		}, nil
+	}
+	sc := &snowflakeConn{
+		cfg: &Config{},
+		rest: &snowflakeRestful{
+			FuncPostQuery: postQueryMock,
+			TokenAccessor: getSimpleTokenAccessor(),
+		},
+	}
+	stmt, err := sc.PrepareContext(context.Background(), "SELECT id, name FROM t WHERE id = ? AND name = ?")
+	assertNilF(t, err)
+	assertEqualE(t, stmt.NumInput(), 2)
+	assertEqualE(t, stmt.(SnowflakeStmt).GetQueryID(), "", "describe should not set the last query id")
+	assertDeepEqualE(t, stmt.(SnowflakeStmtMetadata).BindTypes(), []ColumnMetadata{
+		{Name: "1", Type: "FIXED", Precision: 38, Nullable: true},
+		{Name: "2", Type: "TEXT", Length: 16777216, Nullable: true},
+	})
+	assertDeepEqualE(t, stmt.(SnowflakeStmtMetadata).ColumnTypes(), []ColumnMetadata{
+		{Name: "ID", Type: "FIXED", Precision: 38},
+		{Name: "NAME", Type: "TEXT", Length: 100, Nullable: true},
+	})
+}
+
+func TestPrepareFailsOnCompilationError(t *testing.T) {
+	postQueryMock := func(_ context.Context, _ *snowflakeRestful, _ *url.Values,
+		_ map[string]string, _ []byte, _ time.Duration, _ UUID, _ *Config) (*execResponse, error) {
+		return &execResponse{
+			Data: execResponseData{
+				QueryID:  "failed-query-id",
+				SQLState: "42000",
+			},
+			Message: "SQL compilation error",
+			Code:    "1003",
+			Success: false,
+		}, nil
+	}
+	sc := &snowflakeConn{
+		cfg: &Config{},
+		rest: &snowflakeRestful{
+			FuncPostQuery: postQueryMock,
+			TokenAccessor: getSimpleTokenAccessor(),
+		},
+	}
+	_, err := sc.PrepareContext(context.Background(), "SELECTT 1")
+	assertNotNilF(t, err)
+	var se *SnowflakeError
+	assertErrorsAsF(t, err, &se)
+	assertEqualE(t, se.Number, 1003)
+	assertEqualE(t, se.SQLState, "42000")
+	assertEqualE(t, se.QueryID, "failed-query-id")
+}
+
+func TestPrepareSkipsDescribe(t *testing.T) {
+	testcases := []struct {
+		name  string
+		ctx   context.Context
+		query string
+		code  string
+	}{
+		{name: "file transfer", ctx: context.Background(), query: "PUT file:///tmp/data.csv @~"},
+		{name: "multi-statement context", ctx: WithMultiStatement(context.Background(), 2), query: "SELECT 1; SELECT 2"},
+		{name: "multi-statement query", ctx: context.Background(), query: "SELECT 1; SELECT 2", code: "000008"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			postQueryMock := func(_ context.Context, _ *snowflakeRestful, _ *url.Values,
+				_ map[string]string, _ []byte, _ time.Duration, _ UUID, _ *Config) (*execResponse, error) {
+				if tc.code == "" {
+					t.Fatal("describe should have been skipped")
+				}
+				return &execResponse{
+					Data:    execResponseData{QueryID: "failed-query-id"},
+					Message: "Actual statement count 2 did not match the desired statement count 1.",
+					Code:    tc.code,
+					Success: false,
+				}, nil
+			}
+			sc := &snowflakeConn{
+				cfg: &Config{},
+				rest: &snowflakeRestful{
+					FuncPostQuery: postQueryMock,
+					TokenAccessor: getSimpleTokenAccessor(),
+				},
+			}
+			stmt, err := sc.PrepareContext(tc.ctx, tc.query)
+			assertNilF(t, err)
+			assertEqualE(t, stmt.NumInput(), -1)
+			assertEqualE(t, len(stmt.(SnowflakeStmtMetadata).ColumnTypes()), 0)
+		})
+	}
+}
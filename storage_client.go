@@ -207,6 +207,16 @@ func (rsu *remoteStorageUtil) downloadOneFile(ctx context.Context, meta *fileMet
 	if header != nil {
 		meta.srcFileSize = header.contentLength
 	}
+	// GCS starts the checkpoint in nativeDownloadFile with the object generation, which is returned with presigned URLs too
+	if header != nil && isResumable(meta) && !isFileGetStream(ctx) && meta.stageLocationType != gcsClient {
+		var fileKey string
+		if header.encryptionMetadata != nil {
+			fileKey = header.encryptionMetadata.key
+		}
+		if err = startResumableDownload(meta, fullDstFileName, header.contentLength, header.digest, "", 0, fileKey); err != nil {
+			return err
+		}
+	}
 
 	maxConcurrency := meta.parallel
 	partSize := meta.options.MultiPartThreshold
@@ -214,9 +224,13 @@ func (rsu *remoteStorageUtil) downloadOneFile(ctx context.Context, meta *fileMet
 	maxRetry := defaultMaxRetry
 
 	timer := time.Now()
-	for range maxRetry {
+	for retry := range maxRetry {
 		tempDownloadFile := fullDstFileName + ".tmp"
 		defer func() {
+			// the partially downloaded file is kept to resume the download
+			if meta.resumable != nil && meta.resStatus != downloaded {
+				return
+			}
 			// Clean up temp file if it still exists
 			if _, statErr := os.Stat(tempDownloadFile); statErr == nil {
 				logger.Debugf("Cleaning up temporary download file: %s", tempDownloadFile)
@@ -227,6 +241,14 @@ func (rsu *remoteStorageUtil) downloadOneFile(ctx context.Context, meta *fileMet
 		}()
 
 		if err = utilClass.nativeDownloadFile(ctx, meta, tempDownloadFile, maxConcurrency, partSize); err != nil {
+			if meta.resumable != nil && meta.resStatus == needRetry && ctx.Err() == nil {
+				logger.Warnf("Download of %v interrupted, resuming from the checkpoint. Current retry: %v. %v", meta.srcFileName, retry, err)
+				lastErr = err
+				if !meta.noSleepingTime {
+					time.Sleep(time.Second * time.Duration(intMin(int(math.Exp2(float64(retry))), 16)))
+				}
+				continue
+			}
 			logger.Errorf("Failed to download file to temporary location %s: %v", tempDownloadFile, err)
 			return err
 		}
@@ -281,6 +303,9 @@ func (rsu *remoteStorageUtil) downloadOneFile(ctx context.Context, meta *fileMet
 				}
 			}
 			logger.Debugf("File download completed successfully for %s (size: %d bytes)", meta.srcFileName, meta.dstFileSize)
+			if meta.resumable != nil {
+				meta.resumable.remove()
+			}
 			return nil
 		}
 		lastErr = meta.lastError
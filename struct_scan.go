@@ -0,0 +1,212 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ScanStructs reads all rows into values of the struct type T and closes the rows.
+// The columns are mapped to the exported fields by the name in the sf tag, e.g. `sf:"order_id"`,
+// or without the tag by the case-insensitive field name. The fields of embedded structs are mapped like the fields of T,
+// fields tagged with `sf:",ignore"` are skipped. Pointer fields are set to nil for NULL values.
+// OBJECT, ARRAY, MAP and VARIANT columns can be scanned into struct, slice and map fields:
+// structured types are set from the returned values and semi-structured values are decoded from JSON,
+// which also applies to the fields implementing json.Unmarshaler, e.g. json.RawMessage.
+// An error is returned if a column matches no field.
+func ScanStructs[T any](rows *sql.Rows) ([]T, error) {
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Warnf("failed to close rows. err: %v", err)
+		}
+	}()
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot scan rows into %v, expected a struct type", typ)
+	}
+	indexes, err := structFieldIndexes(typ, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []T
+	dest := make([]any, len(columns))
+	for rows.Next() {
+		var value T
+		v := reflect.ValueOf(&value).Elem()
+		for i, index := range indexes {
+			field, err := fieldByIndexAlloc(v, index)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = scanDestination(field, columns[i])
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, rows.Err()
+}
+
+// QueryStructs runs the query with *sql.DB, *sql.Conn or *sql.Tx and reads the rows into values of the struct type T
+// like ScanStructs.
+func QueryStructs[T any](ctx context.Context, db interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}, query string, args ...any) ([]T, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return ScanStructs[T](rows)
+}
+
+type structScanField struct {
+	index  []int
+	name   string
+	tagged bool
+}
+
+// structScanFields returns the fields the columns can be scanned into, including the fields of embedded structs.
+func structScanFields(typ reflect.Type, index []int) []structScanField {
+	var fields []structScanField
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if shouldIgnoreField(field) {
+			continue
+		}
+		fieldIndex := append(slices.Clone(index), i)
+		name := strings.Split(field.Tag.Get("sf"), ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && !isScanTarget(embedded) {
+				fields = append(fields, structScanFields(embedded, fieldIndex)...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			fields = append(fields, structScanField{index: fieldIndex, name: field.Name})
+		} else {
+			fields = append(fields, structScanField{index: fieldIndex, name: name, tagged: true})
+		}
+	}
+	return fields
+}
+
+// structFieldIndexes returns the index of the field for every column. Tagged fields take precedence
+// over field names, and shallower fields over the fields of embedded structs.
+func structFieldIndexes(typ reflect.Type, columns []*sql.ColumnType) ([][]int, error) {
+	fields := structScanFields(typ, nil)
+	indexes := make([][]int, len(columns))
+	for i, column := range columns {
+		var match *structScanField
+		for j, field := range fields {
+			if !strings.EqualFold(field.name, column.Name()) {
+				continue
+			}
+			if match == nil || (field.tagged && !match.tagged) || (field.tagged == match.tagged && len(field.index) < len(match.index)) {
+				match = &fields[j]
+			}
+		}
+		if match == nil {
+			return nil, fmt.Errorf("no field of %v matches column %v", typ, column.Name())
+		}
+		indexes[i] = match.index
+	}
+	return indexes, nil
+}
+
+// fieldByIndexAlloc returns the field allocating the nil embedded struct pointers on the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// isScanTarget reports whether database/sql scans into the type itself, e.g. sql.NullString, Geography or time.Time.
+func isScanTarget(typ reflect.Type) bool {
+	return typ == reflect.TypeFor[time.Time]() || reflect.PointerTo(typ).Implements(reflect.TypeFor[sql.Scanner]())
+}
+
+// scanDestination returns the pointer to the field, or a scanner converting the value of the structured
+// or semi-structured column to the field, which database/sql cannot do.
+func scanDestination(field reflect.Value, column *sql.ColumnType) any {
+	typ := field.Type()
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch column.DatabaseTypeName() {
+	case "OBJECT", "ARRAY", "MAP", "VARIANT":
+		if !isScanTarget(typ) && (typ.Kind() == reflect.Struct || typ.Kind() == reflect.Map ||
+			(typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8) ||
+			reflect.PointerTo(typ).Implements(reflect.TypeFor[json.Unmarshaler]())) {
+			return &structuredFieldScanner{field: field}
+		}
+	}
+	return field.Addr().Interface()
+}
+
+type structuredFieldScanner struct {
+	field reflect.Value
+}
+
+func (s *structuredFieldScanner) Scan(src any) error {
+	if src == nil {
+		s.field.SetZero()
+		return nil
+	}
+	target := s.field
+	if target.Kind() == reflect.Pointer {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+	switch v := src.(type) {
+	case *structuredType:
+		if target.Kind() == reflect.Struct {
+			return v.scanTo(target)
+		}
+	case string:
+		return json.Unmarshal([]byte(v), target.Addr().Interface())
+	case []byte:
+		return json.Unmarshal(v, target.Addr().Interface())
+	}
+	value := reflect.ValueOf(src)
+	if value.Type().AssignableTo(target.Type()) {
+		target.Set(value)
+		return nil
+	}
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Map {
+		// structured arrays and maps of other element types, e.g. []int64 into []float64
+		b, err := json.Marshal(src)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(b, target.Addr().Interface())
+	}
+	return fmt.Errorf("cannot scan %T into %v", src, target.Type())
+}
@@ -0,0 +1,128 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+)
+
+type structScanTestConnector struct {
+	columns []string
+	dbTypes []string
+	rows    [][]driver.Value
+}
+
+func (c *structScanTestConnector) Connect(context.Context) (driver.Conn, error) {
+	return &structScanTestConn{c}, nil
+}
+
+func (c *structScanTestConnector) Driver() driver.Driver {
+	return SnowflakeDriver{}
+}
+
+type structScanTestConn struct {
+	connector *structScanTestConnector
+}
+
+func (c *structScanTestConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *structScanTestConn) Close() error {
+	return nil
+}
+
+func (c *structScanTestConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *structScanTestConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &structScanTestRows{connector: c.connector}, nil
+}
+
+type structScanTestRows struct {
+	connector *structScanTestConnector
+	next      int
+}
+
+func (r *structScanTestRows) Columns() []string {
+	return r.connector.columns
+}
+
+func (r *structScanTestRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.connector.dbTypes[index]
+}
+
+func (r *structScanTestRows) Close() error {
+	return nil
+}
+
+func (r *structScanTestRows) Next(dest []driver.Value) error {
+	if r.next == len(r.connector.rows) {
+		return io.EOF
+	}
+	copy(dest, r.connector.rows[r.next])
+	r.next++
+	return nil
+}
+
+type structScanTestAudit struct {
+	CreatedAt time.Time
+	UpdatedBy *string
+}
+
+type structScanTestAddress struct {
+	City string
+	Zip  int64
+}
+
+type structScanTestOrder struct {
+	structScanTestAudit
+	ID       int64  `sf:"order_id"`
+	Customer string // matched by the case-insensitive name
+	Note     *string
+	Address  structScanTestAddress
+	Tags     []string
+	Internal string `sf:"id,ignore"`
+}
+
+func TestQueryStructs(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	connector := &structScanTestConnector{
+		columns: []string{"ORDER_ID", "CUSTOMER", "NOTE", "CREATEDAT", "UPDATEDBY", "ADDRESS", "TAGS"},
+		dbTypes: []string{"FIXED", "TEXT", "TEXT", "TIMESTAMP_NTZ", "TEXT", "OBJECT", "VARIANT"},
+		rows: [][]driver.Value{
+			{int64(1), "ACME", "fragile", createdAt, "bot", buildStructuredTypeFromMap(map[string]any{"city": "Warsaw", "zip": int64(1234)}, nil, nil), `["a","b"]`},
+			{int64(2), "Initech", nil, createdAt, nil, `{"City": "Austin", "Zip": 73301}`, nil},
+		},
+	}
+	db := sql.OpenDB(connector)
+
+	orders, err := QueryStructs[structScanTestOrder](context.Background(), db, "SELECT ...")
+	assertNilF(t, err)
+	assertEqualF(t, len(orders), 2)
+
+	assertEqualE(t, orders[0].ID, int64(1))
+	assertEqualE(t, orders[0].Customer, "ACME")
+	assertEqualE(t, *orders[0].Note, "fragile")
+	assertEqualE(t, orders[0].CreatedAt, createdAt)
+	assertEqualE(t, *orders[0].UpdatedBy, "bot")
+	assertEqualE(t, orders[0].Address, structScanTestAddress{City: "Warsaw", Zip: 1234})
+	assertDeepEqualE(t, orders[0].Tags, []string{"a", "b"})
+	assertEqualE(t, orders[0].Internal, "")
+
+	assertEqualE(t, orders[1].ID, int64(2))
+	assertNilE(t, orders[1].Note)
+	assertNilE(t, orders[1].UpdatedBy)
+	assertEqualE(t, orders[1].Address, structScanTestAddress{City: "Austin", Zip: 73301})
+	assertNilE(t, orders[1].Tags)
+
+	connector.columns = append(connector.columns, "UNKNOWN")
+	connector.dbTypes = append(connector.dbTypes, "TEXT")
+	_, err = QueryStructs[structScanTestOrder](context.Background(), db, "SELECT ...")
+	assertNotNilF(t, err)
+	assertStringContainsE(t, err.Error(), "UNKNOWN")
+}
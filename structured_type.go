@@ -1003,7 +1003,11 @@ func (st *structuredType) GetRaw(fieldName string) (any, error) {
 }
 
 func (st *structuredType) ScanTo(sc sql.Scanner) error {
-	v := reflect.Indirect(reflect.ValueOf(sc))
+	return st.scanTo(reflect.Indirect(reflect.ValueOf(sc)))
+}
+
+// scanTo sets the fields of the struct v, which also allows to scan into structs not implementing sql.Scanner.
+func (st *structuredType) scanTo(v reflect.Value) error {
 	t := v.Type()
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
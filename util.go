@@ -31,6 +31,7 @@ const (
 	fileGetStream          ContextKey = "STREAMING_GET_FILE"
 	fileTransferOptions    ContextKey = "FILE_TRANSFER_OPTIONS"
 	enableDecfloat         ContextKey = "ENABLE_DECFLOAT"
+	enableDecimal          ContextKey = "ENABLE_DECIMAL"
 	arrowAlloc             ContextKey = "ARROW_ALLOC"
 	queryTag               ContextKey = "QUERY_TAG"
 	enableStructuredTypes  ContextKey = "ENABLE_STRUCTURED_TYPES"
@@ -41,6 +42,9 @@ const (
 	truncatedResponseRetry ContextKey = "TRUNCATED_RESPONSE_RETRY"
 	logQueryText           ContextKey = "LOG_QUERY_TEXT"
 	logQueryParameters     ContextKey = "LOG_QUERY_PARAMETERS"
+	columnarResults        ContextKey = "COLUMNAR_RESULTS"
+	timestampTzLocation    ContextKey = "TIMESTAMP_TZ_LOCATION"
+	queryParameters        ContextKey = "QUERY_PARAMETERS"
 )
 
 var (
@@ -109,6 +113,13 @@ func WithDecfloatMappingEnabled(ctx context.Context) context.Context {
 	return context.WithValue(ctx, enableDecfloat, true)
 }
 
+// WithDecimalMappingEnabled returns a context that returns FIXED columns with a non-zero scale, e.g. NUMBER(38,2),
+// as Decimal, which represents the values exactly. It takes precedence over WithHigherPrecision for these columns.
+// FIXED columns with the zero scale and the numbers nested in structured types are not affected.
+func WithDecimalMappingEnabled(ctx context.Context) context.Context {
+	return context.WithValue(ctx, enableDecimal, true)
+}
+
 // WithArrowAllocator returns a context embedding the provided allocator
 // which will be utilized by chunk downloaders when constructing Arrow
 // objects.
@@ -122,6 +133,20 @@ func WithQueryTag(ctx context.Context, tag string) context.Context {
 	return context.WithValue(ctx, queryTag, tag)
 }
 
+// WithQueryParameters returns a context that will set the given session parameters,
+// e.g. STATEMENT_TIMEOUT_IN_SECONDS or TIMEZONE, on any queries that are run, without changing the session.
+// The parameters are added to the ones set earlier in the context.
+func WithQueryParameters(ctx context.Context, params map[string]any) context.Context {
+	merged := make(map[string]any, len(params))
+	if existing, ok := ctx.Value(queryParameters).(map[string]any); ok {
+		maps.Copy(merged, existing)
+	}
+	for name, value := range params {
+		merged[strings.ToUpper(name)] = value
+	}
+	return context.WithValue(ctx, queryParameters, merged)
+}
+
 // WithStructuredTypesEnabled changes how structured types are returned.
 // Without this context structured types are returned as strings.
 // With this context enabled, structured types are returned as native Go types.
@@ -136,6 +161,27 @@ func WithEmbeddedValuesNullable(ctx context.Context) context.Context {
 	return context.WithValue(ctx, embeddedValuesNullable, true)
 }
 
+// WithColumnarResults returns a context that keeps Arrow results in columnar form.
+// The driver rows have to be read with ColumnChunkReader.NextColumnChunk instead of Next.
+func WithColumnarResults(ctx context.Context) context.Context {
+	return context.WithValue(ctx, columnarResults, true)
+}
+
+// WithTimestampTzLocation returns a context that returns TIMESTAMP_TZ values in the given (e.g. IANA named) location
+// instead of the offset-based location, if the location had the offset of the value at that time.
+// The values with other offsets keep their offset-based location.
+func WithTimestampTzLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, timestampTzLocation, loc)
+}
+
+func getTimestampTzLocation(ctx context.Context) *time.Location {
+	if ctx == nil {
+		return nil
+	}
+	loc, _ := ctx.Value(timestampTzLocation).(*time.Location)
+	return loc
+}
+
 // WithInternal sets the internal query flag.
 func WithInternal(ctx context.Context) context.Context {
 	return context.WithValue(ctx, internalQuery, true)
@@ -224,6 +270,12 @@ func toNamedValues(values []driver.Value) []driver.NamedValue {
 // TokenAccessor manages the session token and master token
 type TokenAccessor = sfconfig.TokenAccessor
 
+// CredentialCache stores the ID, MFA and OAuth tokens used to skip authentication prompts.
+type CredentialCache = sfconfig.CredentialCache
+
+// CredentialCacheKey identifies a token stored in CredentialCache.
+type CredentialCacheKey = sfconfig.CredentialCacheKey
+
 type simpleTokenAccessor struct {
 	token        string
 	masterToken  string
@@ -332,6 +384,12 @@ func (sp *syncParams) set(key string, value *string) {
 	sp.params[key] = value
 }
 
+func (sp *syncParams) delete(key string) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	delete(sp.params, key)
+}
+
 // All returns an iterator over all params, holding the lock for the
 // duration of iteration. Callers use: for k, v := range sp.All() { ... }
 func (sp *syncParams) All() iter.Seq2[string, string] {
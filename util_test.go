@@ -3,9 +3,11 @@ package gosnowflake
 import (
 	"context"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"math/rand"
+	"net/url"
 	"os"
 	"runtime"
 	"strconv"
@@ -557,3 +559,44 @@ func TestSyncParamsAll(t *testing.T) {
 		wg.Wait()
 	})
 }
+
+func TestWithQueryParameters(t *testing.T) {
+	var parameters map[string]any
+	postQueryMock := func(_ context.Context, _ *snowflakeRestful, _ *url.Values, _ map[string]string, body []byte, _ time.Duration, _ UUID, _ *Config) (*execResponse, error) {
+		var req execRequest
+		assertNilF(t, json.Unmarshal(body, &req))
+		parameters = req.Parameters
+		return &execResponse{Data: execResponseData{QueryID: "query-id"}, Success: true}, nil
+	}
+	sc := &snowflakeConn{cfg: &Config{}, rest: &snowflakeRestful{FuncPostQuery: postQueryMock}}
+
+	ctx := WithQueryParameters(context.Background(), map[string]any{"timezone": "UTC", "QUERY_TAG": "overridden"})
+	ctx = WithQueryParameters(WithQueryTag(ctx, "tag"), map[string]any{"ROWS_PER_RESULTSET": 10})
+	_, err := sc.ExecContext(ctx, "SELECT 1", nil)
+	assertNilF(t, err)
+	assertDeepEqualE(t, parameters, map[string]any{"TIMEZONE": "UTC", "QUERY_TAG": "tag", "ROWS_PER_RESULTSET": float64(10)})
+
+	t.Run("statement timeout from deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+		defer cancel()
+		_, err := sc.ExecContext(ctx, "SELECT 1", nil)
+		assertNilF(t, err)
+		assertEqualE(t, parameters["STATEMENT_TIMEOUT_IN_SECONDS"], float64(90))
+
+		_, err = sc.ExecContext(WithQueryParameters(ctx, map[string]any{"STATEMENT_TIMEOUT_IN_SECONDS": 0}), "SELECT 1", nil)
+		assertNilF(t, err)
+		assertEqualE(t, parameters["STATEMENT_TIMEOUT_IN_SECONDS"], float64(0))
+
+		_, err = sc.ExecContext(WithAsyncMode(ctx), "SELECT 1", nil)
+		assertNilF(t, err)
+		_, ok := parameters["STATEMENT_TIMEOUT_IN_SECONDS"]
+		assertFalseE(t, ok, "async queries should not be limited by the deadline of the submission")
+
+		sessionTimeout := "60"
+		sc.syncParams.set(sessionStatementTimeoutInSeconds, &sessionTimeout)
+		_, err = sc.ExecContext(ctx, "SELECT 1", nil)
+		assertNilF(t, err)
+		_, ok = parameters["STATEMENT_TIMEOUT_IN_SECONDS"]
+		assertFalseE(t, ok, "shorter session timeout should be kept")
+	})
+}
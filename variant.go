@@ -0,0 +1,97 @@
+package gosnowflake
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Variant is a VARIANT value of the type T, which is bound and scanned as JSON.
+// Variant[T] can be bound as a parameter or in Array to insert VARIANT values without serializing them first,
+// and scanned from VARIANT, OBJECT and ARRAY columns, e.g. Variant[map[string]any] or Variant[json.RawMessage].
+// Structured OBJECT, ARRAY and MAP values are set directly when T is a matching struct, slice or map.
+type Variant[T any] struct {
+	V     T
+	Valid bool // false if the value is NULL
+}
+
+// NewVariant returns the valid Variant of v.
+func NewVariant[T any](v T) Variant[T] {
+	return Variant[T]{V: v, Valid: true}
+}
+
+// MarshalJSON implements json.Marshaler. NULL is marshaled as JSON null.
+func (v Variant[T]) MarshalJSON() ([]byte, error) {
+	if !v.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v.V)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON null is unmarshaled as NULL.
+func (v *Variant[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*v = Variant[T]{}
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*v = NewVariant(value)
+	return nil
+}
+
+// Scan implements sql.Scanner.
+func (v *Variant[T]) Scan(src any) error {
+	var value T
+	if err := (&structuredFieldScanner{field: reflect.ValueOf(&value).Elem()}).Scan(src); err != nil {
+		return err
+	}
+	*v = Variant[T]{V: value, Valid: src != nil}
+	return nil
+}
+
+// isVariantValue reports whether the value is bound as VARIANT, i.e. it implements json.Marshaler
+// and none of the interfaces or types the driver binds differently.
+func isVariantValue(v driver.Value) bool {
+	if _, ok := v.(json.Marshaler); !ok {
+		return false
+	}
+	switch v.(type) {
+	case driver.Valuer, StructuredObjectWriter, time.Time:
+		return false
+	}
+	return true
+}
+
+// variantToString marshals the value to the JSON binding value. JSON null is bound as NULL.
+func variantToString(v any) (*string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal %T to VARIANT: %w", v, err)
+	}
+	if string(b) == "null" {
+		return nil, nil
+	}
+	s := string(b)
+	return &s, nil
+}
+
+// toVariantArray returns the array binding of a slice of json.Marshaler values.
+func toVariantArray(a any) (*variantArray, bool) {
+	v := reflect.ValueOf(a)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice || !v.Type().Elem().Implements(reflect.TypeFor[json.Marshaler]()) {
+		return nil, false
+	}
+	arr := make(variantArray, v.Len())
+	for i := range arr {
+		arr[i] = v.Index(i).Interface()
+	}
+	return &arr, true
+}
@@ -0,0 +1,119 @@
+package gosnowflake
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+type variantTestPayload struct {
+	Name string `json:"name"`
+	Age  int64  `json:"age"`
+}
+
+type variantTestMarshaler struct {
+	id string
+}
+
+func (m variantTestMarshaler) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"id": m.id})
+}
+
+func TestVariantBindValues(t *testing.T) {
+	sc := &snowflakeConn{cfg: &Config{}}
+	for _, value := range []any{NewVariant(1), Variant[string]{}, json.RawMessage(`{}`), variantTestMarshaler{}, &variantTestMarshaler{}} {
+		assertNilE(t, sc.CheckNamedValue(&driver.NamedValue{Value: value}))
+	}
+	assertFalseE(t, isVariantValue(sql.NullString{}))
+
+	arr, err := Array([]Variant[variantTestPayload]{NewVariant(variantTestPayload{Name: "Jones", Age: 42}), {}})
+	assertNilF(t, err)
+	bindValues, err := getBindValues([]driver.NamedValue{
+		{Ordinal: 1, Value: NewVariant(map[string]any{"key": "value"})},
+		{Ordinal: 2, Value: json.RawMessage(`[1, 2]`)},
+		{Ordinal: 3, Value: variantTestMarshaler{id: "x"}},
+		{Ordinal: 4, Value: Variant[int]{}},
+		{Ordinal: 5, Value: "plain"},
+		{Ordinal: 6, Value: arr},
+	}, nil)
+	assertNilF(t, err)
+
+	expected := []string{`{"key":"value"}`, `[1,2]`, `{"id":"x"}`}
+	for i, value := range expected {
+		bindValue := bindValues[strconv.Itoa(i+1)]
+		assertEqualE(t, bindValue.Type, "VARIANT")
+		assertEqualE(t, bindValue.Format, jsonFormatStr)
+		assertEqualE(t, *bindValue.Value.(*string), value)
+	}
+	assertEqualE(t, bindValues["4"].Type, "VARIANT")
+	assertNilE(t, bindValues["4"].Value.(*string))
+	assertEqualE(t, bindValues["5"].Type, "TEXT")
+	assertEqualE(t, bindValues["6"].Type, "VARIANT")
+	assertEqualE(t, bindValues["6"].Format, jsonFormatStr)
+	values := bindValues["6"].Value.([]*string)
+	assertEqualF(t, len(values), 2)
+	assertEqualE(t, *values[0], `{"name":"Jones","age":42}`)
+	assertNilE(t, values[1])
+
+	_, err = Array([]variantTestMarshaler{{id: "a"}})
+	assertNilE(t, err)
+	_, err = Array([]struct{}{{}})
+	assertNotNilE(t, err)
+}
+
+func TestVariantScan(t *testing.T) {
+	var payload Variant[variantTestPayload]
+	assertNilF(t, payload.Scan(`{"name": "Jones", "age": 42}`))
+	assertTrueE(t, payload.Valid)
+	assertEqualE(t, payload.V, variantTestPayload{Name: "Jones", Age: 42})
+
+	assertNilF(t, payload.Scan(buildStructuredTypeFromMap(map[string]any{"name": "Smith", "age": int64(7)}, nil, nil)))
+	assertEqualE(t, payload.V, variantTestPayload{Name: "Smith", Age: 7})
+
+	assertNilF(t, payload.Scan(nil))
+	assertFalseE(t, payload.Valid)
+	assertEqualE(t, payload.V, variantTestPayload{})
+
+	var raw Variant[json.RawMessage]
+	assertNilF(t, raw.Scan([]byte(`[1, {"a": null}]`)))
+	assertEqualE(t, string(raw.V), `[1, {"a": null}]`)
+
+	var floats Variant[[]float64]
+	assertNilF(t, floats.Scan([]int64{1, 2}))
+	assertDeepEqualE(t, floats.V, []float64{1, 2})
+
+	assertNotNilE(t, payload.Scan(`{"name": 1}`))
+	assertNotNilE(t, payload.Scan(true))
+
+	var nested struct {
+		Payload Variant[variantTestPayload] `json:"payload"`
+		Missing Variant[int]                `json:"missing"`
+	}
+	assertNilF(t, json.Unmarshal([]byte(`{"payload": {"name": "Jones", "age": 42}, "missing": null}`), &nested))
+	assertTrueE(t, nested.Payload.Valid)
+	assertFalseE(t, nested.Missing.Valid)
+	b, err := json.Marshal(nested)
+	assertNilF(t, err)
+	assertEqualE(t, string(b), `{"payload":{"name":"Jones","age":42},"missing":null}`)
+}
+
+func TestQueryStructsVariant(t *testing.T) {
+	connector := &structScanTestConnector{
+		columns: []string{"RAW", "PAYLOAD"},
+		dbTypes: []string{"VARIANT", "OBJECT"},
+		rows:    [][]driver.Value{{`{"a": 1}`, `{"name": "Jones", "age": 42}`}, {nil, nil}},
+	}
+	rows, err := QueryStructs[struct {
+		Raw     json.RawMessage
+		Payload Variant[variantTestPayload]
+	}](context.Background(), sql.OpenDB(connector), "SELECT ...")
+	assertNilF(t, err)
+	assertEqualF(t, len(rows), 2)
+	assertEqualE(t, string(rows[0].Raw), `{"a": 1}`)
+	assertEqualE(t, rows[0].Payload, NewVariant(variantTestPayload{Name: "Jones", Age: 42}))
+	assertNilE(t, rows[1].Raw)
+	assertFalseE(t, rows[1].Payload.Valid)
+}